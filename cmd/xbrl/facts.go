@@ -2,6 +2,9 @@ package main
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -16,6 +19,8 @@ var (
 	onlyNil         bool
 	excludeNil      bool
 	normalizeSpaces bool
+	taxonomyPath    string
+	showDims        bool
 )
 
 var factsCmd = &cobra.Command{
@@ -38,6 +43,12 @@ Examples:
 
   # List non-nil Revenue facts in unit U1
   xbrl-go facts --concept-local Revenue --unit U1 --exclude-nil sample.xbrl
+
+  # Print typed values using a taxonomy schema
+  xbrl-go facts --taxonomy sample.xsd sample.xbrl
+
+  # Print dimensional qualifiers of each fact's context
+  xbrl-go facts --show-dims sample.xbrl
 `,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -52,6 +63,16 @@ Examples:
 			return fmt.Errorf("parse instance: %w", err)
 		}
 
+		if taxonomyPath != "" {
+			tax, err := xbrl.ParseTaxonomyFile(taxonomyPath)
+			if err != nil {
+				return fmt.Errorf("parse taxonomy: %w", err)
+			}
+			doc.SetTaxonomy(tax)
+		}
+
+		out := cmd.OutOrStdout()
+
 		// Build filter
 		filter := xbrl.NewFactFilter().
 			ConceptLocal(conceptLocal).
@@ -71,11 +92,11 @@ Examples:
 		}
 
 		if len(facts) == 0 {
-			fmt.Println("no facts matched the filter")
+			fmt.Fprintln(out, "no facts matched the filter")
 			return nil
 		}
 
-		fmt.Println("---- facts ----")
+		fmt.Fprintln(out, "---- facts ----")
 		for _, f := range facts {
 			if f == nil {
 				continue
@@ -93,20 +114,84 @@ Examples:
 				value = "(nil)"
 			}
 
-			fmt.Printf(
-				"%s\tctx=%s\tunit=%s\tdecimals=%s\tvalue=%s\n",
+			line := fmt.Sprintf(
+				"%s\tctx=%s\tunit=%s\tdecimals=%s\tvalue=%s",
 				name,
 				f.ContextRef(),
 				f.UnitRef(),
 				f.Decimals(),
 				value,
 			)
+
+			if doc.Taxonomy() != nil {
+				kind, typed := typedValue(doc, f)
+				line += fmt.Sprintf("\tkind=%s\ttyped=%s", kind, typed)
+			}
+
+			if showDims {
+				line += "\tdims=" + formatDims(doc, f)
+			}
+
+			fmt.Fprintln(out, line)
 		}
 
 		return nil
 	},
 }
 
+// typedValue returns the concept value kind of the fact and its value
+// converted with the As* helpers. If the conversion fails, typed is empty.
+func typedValue(doc *xbrl.Document, f *xbrl.Fact) (kind xbrl.ConceptValueKind, typed string) {
+	c, ok := doc.ConceptOf(f)
+	if !ok {
+		return xbrl.ConceptValueUnknown, ""
+	}
+	kind = c.ValueKind()
+
+	switch kind {
+	case xbrl.ConceptValueNumeric, xbrl.ConceptValueMonetary:
+		if n, err := doc.AsInt64(f); err == nil {
+			return kind, strconv.FormatInt(n, 10)
+		}
+		if n, err := doc.AsFloat64(f); err == nil {
+			return kind, strconv.FormatFloat(n, 'f', -1, 64)
+		}
+	case xbrl.ConceptValueBoolean:
+		if b, err := doc.AsBool(f); err == nil {
+			return kind, strconv.FormatBool(b)
+		}
+	case xbrl.ConceptValueDate:
+		if t, err := doc.AsTime(f, nil); err == nil {
+			return kind, t.Format(time.DateOnly)
+		}
+	case xbrl.ConceptValueDateTime:
+		if t, err := doc.AsTime(f, nil); err == nil {
+			return kind, t.Format(time.RFC3339)
+		}
+	}
+	return kind, ""
+}
+
+// formatDims renders the dimensions of the fact's context as a
+// comma-separated list of "axis=member" pairs using local names.
+// Typed members are rendered with their raw inner XML.
+func formatDims(doc *xbrl.Document, f *xbrl.Fact) string {
+	ctx, ok := doc.ContextOf(f)
+	if !ok {
+		return ""
+	}
+
+	var parts []string
+	for _, d := range ctx.Dimensions() {
+		if d.IsExplicit() {
+			parts = append(parts, d.Dimension().Local()+"="+d.Member().Local())
+		} else {
+			parts = append(parts, d.Dimension().Local()+"="+d.TypedValue())
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
 func init() {
 	// Register subcommand on the root command.
 	rootCmd.AddCommand(factsCmd)
@@ -119,4 +204,6 @@ func init() {
 	factsCmd.Flags().BoolVar(&onlyNil, "only-nil", false, "filter only nil facts (xsi:nil=\"true\")")
 	factsCmd.Flags().BoolVar(&excludeNil, "exclude-nil", false, "filter only non-nil facts (xsi:nil!=\"true\")")
 	factsCmd.Flags().BoolVar(&normalizeSpaces, "normalize-spaces", false, "normalize spaces in fact values for human-readable output")
+	factsCmd.Flags().StringVar(&taxonomyPath, "taxonomy", "", "taxonomy schema (XSD) used to print typed values")
+	factsCmd.Flags().BoolVar(&showDims, "show-dims", false, "print dimensional qualifiers (explicit and typed members) of each fact")
 }
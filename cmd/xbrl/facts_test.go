@@ -0,0 +1,183 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testInstance = `<?xml version="1.0" encoding="UTF-8"?>
+<xbrli:xbrl
+    xmlns:xbrli="http://www.xbrl.org/2003/instance"
+    xmlns:iso4217="http://www.xbrl.org/2003/iso4217"
+    xmlns:ex="http://example.com/ex">
+  <xbrli:context id="C1">
+    <xbrli:entity>
+      <xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier>
+    </xbrli:entity>
+    <xbrli:period>
+      <xbrli:instant>2025-03-31</xbrli:instant>
+    </xbrli:period>
+  </xbrli:context>
+  <xbrli:unit id="JPY">
+    <xbrli:measure>iso4217:JPY</xbrli:measure>
+  </xbrli:unit>
+  <ex:Revenue contextRef="C1" unitRef="JPY" decimals="0">12345</ex:Revenue>
+  <ex:Listed contextRef="C1">true</ex:Listed>
+</xbrli:xbrl>
+`
+
+const testSchema = `<?xml version="1.0" encoding="UTF-8"?>
+<xs:schema
+    xmlns:xs="http://www.w3.org/2001/XMLSchema"
+    xmlns:xbrli="http://www.xbrl.org/2003/instance"
+    xmlns:ex="http://example.com/ex"
+    targetNamespace="http://example.com/ex">
+  <xs:element name="Revenue" substitutionGroup="xbrli:item" type="xbrli:monetaryItemType" periodType="instant"/>
+  <xs:element name="Listed" substitutionGroup="xbrli:item" type="xbrli:booleanItemType" periodType="instant"/>
+</xs:schema>
+`
+
+const dimInstance = `<?xml version="1.0" encoding="UTF-8"?>
+<xbrli:xbrl
+    xmlns:xbrli="http://www.xbrl.org/2003/instance"
+    xmlns:xbrldi="http://xbrl.org/2006/xbrldi"
+    xmlns:iso4217="http://www.xbrl.org/2003/iso4217"
+    xmlns:ex="http://example.com/ex">
+  <xbrli:context id="C1">
+    <xbrli:entity>
+      <xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier>
+      <xbrli:segment>
+        <xbrldi:explicitMember dimension="ex:Region">ex:Japan</xbrldi:explicitMember>
+      </xbrli:segment>
+    </xbrli:entity>
+    <xbrli:period>
+      <xbrli:startDate>2025-01-01</xbrli:startDate>
+      <xbrli:endDate>2025-12-31</xbrli:endDate>
+    </xbrli:period>
+    <xbrli:scenario>
+      <xbrldi:typedMember dimension="ex:Scenario"><ex:ScenarioType>Base</ex:ScenarioType></xbrldi:typedMember>
+    </xbrli:scenario>
+  </xbrli:context>
+  <xbrli:context id="C2">
+    <xbrli:entity>
+      <xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier>
+    </xbrli:entity>
+    <xbrli:period>
+      <xbrli:instant>2025-12-31</xbrli:instant>
+    </xbrli:period>
+  </xbrli:context>
+  <xbrli:unit id="JPY">
+    <xbrli:measure>iso4217:JPY</xbrli:measure>
+  </xbrli:unit>
+  <ex:Revenue contextRef="C1" unitRef="JPY" decimals="0">12345</ex:Revenue>
+  <ex:Assets contextRef="C2" unitRef="JPY" decimals="0">999</ex:Assets>
+</xbrli:xbrl>
+`
+
+// writeTestFile writes content into a file under a temporary directory.
+func writeTestFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+// runCommand executes the root command with args and returns its output.
+// Flags of the facts command are reset to their defaults beforehand.
+func runCommand(t *testing.T, args ...string) string {
+	t.Helper()
+
+	factsCmd.Flags().VisitAll(func(f *pflag.Flag) {
+		_ = f.Value.Set(f.DefValue)
+		f.Changed = false
+	})
+
+	var buf bytes.Buffer
+	rootCmd.SetOut(&buf)
+	rootCmd.SetErr(&buf)
+	rootCmd.SetArgs(args)
+	require.NoError(t, rootCmd.Execute())
+	return buf.String()
+}
+
+func TestFactsCmd_Taxonomy(t *testing.T) {
+	instance := writeTestFile(t, "instance.xbrl", testInstance)
+	schema := writeTestFile(t, "schema.xsd", testSchema)
+
+	tests := []struct {
+		name       string
+		args       []string
+		contains   []string
+		notContain []string
+	}{
+		{
+			name:       "without taxonomy prints raw values only",
+			args:       []string{"facts", instance},
+			contains:   []string{"value=12345", "value=true"},
+			notContain: []string{"kind=", "typed="},
+		},
+		{
+			name: "with taxonomy prints typed values",
+			args: []string{"facts", "--taxonomy", schema, instance},
+			contains: []string{
+				"value=12345\tkind=monetary\ttyped=12345",
+				"value=true\tkind=boolean\ttyped=true",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := runCommand(t, tt.args...)
+			for _, s := range tt.contains {
+				assert.Contains(t, out, s)
+			}
+			for _, s := range tt.notContain {
+				assert.NotContains(t, out, s)
+			}
+		})
+	}
+}
+
+func TestFactsCmd_ShowDims(t *testing.T) {
+	instance := writeTestFile(t, "instance.xbrl", dimInstance)
+
+	tests := []struct {
+		name       string
+		args       []string
+		contains   []string
+		notContain []string
+	}{
+		{
+			name:       "without flag dimensions are not printed",
+			args:       []string{"facts", instance},
+			notContain: []string{"dims="},
+		},
+		{
+			name: "with flag explicit and typed members are printed",
+			args: []string{"facts", "--show-dims", instance},
+			contains: []string{
+				"value=12345\tdims=Region=Japan,Scenario=<ex:ScenarioType>Base</ex:ScenarioType>",
+				"value=999\tdims=\n",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := runCommand(t, tt.args...)
+			for _, s := range tt.contains {
+				assert.Contains(t, out, s)
+			}
+			for _, s := range tt.notContain {
+				assert.NotContains(t, out, s)
+			}
+		})
+	}
+}
@@ -16,10 +16,11 @@ var rootCmd = &cobra.Command{
 	Long: `xbrl is a CLI tool built on top of the xbrl-go library.
 
 By default it prints a summary of the instance document:
-  - number of schemaRefs
-  - number of contexts
-  - number of units
-  - number of facts
+  - number of schemaRefs, contexts, units and facts
+  - number of distinct concepts
+  - entity identifiers
+  - reporting period range
+  - currencies used
 
 Use the 'facts' subcommand to inspect individual facts with filters.`,
 	Args: cobra.ExactArgs(1),
@@ -31,10 +32,7 @@ Use the 'facts' subcommand to inspect individual facts with filters.`,
 			return fmt.Errorf("parse instance: %w", err)
 		}
 
-		fmt.Printf("schemaRefs: %d\n", len(doc.SchemaRefs()))
-		fmt.Printf("contexts  : %d\n", len(doc.Contexts()))
-		fmt.Printf("units     : %d\n", len(doc.Units()))
-		fmt.Printf("facts     : %d\n", len(doc.Facts()))
+		fmt.Fprint(cmd.OutOrStdout(), doc.Summary())
 
 		return nil
 	},
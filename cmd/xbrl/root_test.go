@@ -0,0 +1,21 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRootCmd_Summary(t *testing.T) {
+	instance := writeTestFile(t, "instance.xbrl", testInstance)
+
+	out := runCommand(t, instance)
+	assert.Equal(t, "schemaRefs: 0\n"+
+		"contexts  : 1\n"+
+		"units     : 1\n"+
+		"facts     : 2\n"+
+		"concepts  : 2\n"+
+		"entities  : ABC (http://example.com/entity)\n"+
+		"period    : 2025-03-31 - 2025-03-31\n"+
+		"currencies: JPY\n", out)
+}
@@ -51,10 +51,7 @@ func main() {
 
 	// --- Summary ---
 	fmt.Println("== Summary ==")
-	fmt.Printf("schemaRefs: %d\n", len(doc.SchemaRefs()))
-	fmt.Printf("contexts  : %d\n", len(doc.Contexts()))
-	fmt.Printf("units     : %d\n", len(doc.Units()))
-	fmt.Printf("facts     : %d\n", len(doc.Facts()))
+	fmt.Print(doc.Summary())
 	fmt.Println()
 
 	// --- List all facts ---
@@ -0,0 +1,85 @@
+package xbrl
+
+import "fmt"
+
+// SumByMember sums the numeric values of the facts for concept, grouped by
+// their explicit member on the given axis.
+//
+// Concept and axis are compared by URI and local name. Facts whose context
+// has no explicit member for axis are summed under the zero-value QName.
+// Nil facts are skipped.
+//
+// The taxonomy must be attached to the Document, since values are parsed
+// with AsFloat64.
+func (d *Document) SumByMember(concept QName, axis QName) (map[QName]float64, error) {
+	if d == nil {
+		return nil, fmt.Errorf("xbrl: document is nil")
+	}
+	if d.taxonomy == nil {
+		return nil, ErrNoTaxonomy
+	}
+
+	out := make(map[QName]float64)
+	for _, f := range d.facts {
+		if f == nil || f.nil {
+			continue
+		}
+		if f.name.uri != concept.uri || f.name.local != concept.local {
+			continue
+		}
+
+		v, err := d.AsFloat64(f)
+		if err != nil {
+			return nil, fmt.Errorf("xbrl: sum fact %s in context %q: %w", f.name, f.contextRef, err)
+		}
+
+		var member QName
+		if ctx, ok := d.contexts[f.contextRef]; ok {
+			if dim, ok := ctx.DimensionByQName(axis); ok && dim.explicit {
+				member = dim.member
+			}
+		}
+		out[member] += v
+	}
+	return out, nil
+}
+
+// WeightedSum returns the sum of the numeric values of facts, each
+// multiplied by the weight of its concept, as in a calculation linkbase
+// rollup. Concepts without an entry in weights have weight 1. Weights are
+// matched by URI and local name. Nil facts are skipped.
+//
+// The taxonomy must be attached to the Document, since values are parsed
+// with AsFloat64.
+func (d *Document) WeightedSum(facts []*Fact, weights map[QName]float64) (float64, error) {
+	if d == nil {
+		return 0, fmt.Errorf("xbrl: document is nil")
+	}
+	if d.taxonomy == nil {
+		return 0, ErrNoTaxonomy
+	}
+
+	byKey := make(map[conceptKey]float64, len(weights))
+	for q, w := range weights {
+		byKey[conceptKey{q.uri, q.local}] = w
+	}
+
+	var sum float64
+	for _, f := range facts {
+		if f == nil || f.nil {
+			continue
+		}
+
+		v, err := d.AsFloat64(f)
+		if err != nil {
+			return 0, fmt.Errorf("xbrl: sum fact %s in context %q: %w", f.name, f.contextRef, err)
+		}
+
+		w, ok := byKey[conceptKey{f.name.uri, f.name.local}]
+		if !ok {
+			w = 1
+		}
+		sum += w * v
+	}
+	return sum, nil
+}
@@ -0,0 +1,210 @@
+package xbrl_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aethiopicuschan/xbrl-go/pkg/xbrl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const regionInstance = `
+<xbrli:xbrl
+    xmlns:xbrli="http://www.xbrl.org/2003/instance"
+    xmlns:xbrldi="http://xbrl.org/2006/xbrldi"
+    xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"
+    xmlns:iso4217="http://www.xbrl.org/2003/iso4217"
+    xmlns:ex="http://example.com/xbrl">
+  <xbrli:context id="Total">
+    <xbrli:entity>
+      <xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier>
+    </xbrli:entity>
+    <xbrli:period><xbrli:instant>2025-03-31</xbrli:instant></xbrli:period>
+  </xbrli:context>
+  <xbrli:context id="Japan">
+    <xbrli:entity>
+      <xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier>
+      <xbrli:segment>
+        <xbrldi:explicitMember dimension="ex:RegionAxis">ex:JapanMember</xbrldi:explicitMember>
+      </xbrli:segment>
+    </xbrli:entity>
+    <xbrli:period><xbrli:instant>2025-03-31</xbrli:instant></xbrli:period>
+  </xbrli:context>
+  <xbrli:context id="Japan2024">
+    <xbrli:entity>
+      <xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier>
+      <xbrli:segment>
+        <xbrldi:explicitMember dimension="ex:RegionAxis">ex:JapanMember</xbrldi:explicitMember>
+      </xbrli:segment>
+    </xbrli:entity>
+    <xbrli:period><xbrli:instant>2024-03-31</xbrli:instant></xbrli:period>
+  </xbrli:context>
+  <xbrli:context id="US">
+    <xbrli:entity>
+      <xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier>
+      <xbrli:segment>
+        <xbrldi:explicitMember dimension="ex:RegionAxis">ex:USMember</xbrldi:explicitMember>
+      </xbrli:segment>
+    </xbrli:entity>
+    <xbrli:period><xbrli:instant>2025-03-31</xbrli:instant></xbrli:period>
+  </xbrli:context>
+  <xbrli:unit id="JPY"><xbrli:measure>iso4217:JPY</xbrli:measure></xbrli:unit>
+  <ex:Revenue contextRef="Total" unitRef="JPY" decimals="0">1000</ex:Revenue>
+  <ex:Revenue contextRef="Japan" unitRef="JPY" decimals="0">600</ex:Revenue>
+  <ex:Revenue contextRef="Japan2024" unitRef="JPY" decimals="0">50</ex:Revenue>
+  <ex:Revenue contextRef="US" unitRef="JPY" decimals="0">400</ex:Revenue>
+  <ex:Revenue contextRef="US" unitRef="JPY" xsi:nil="true"/>
+  <ex:Cost contextRef="US" unitRef="JPY" decimals="0">1</ex:Cost>
+</xbrli:xbrl>
+`
+
+func newRegionDoc(t *testing.T, revenueType string) *xbrl.Document {
+	t.Helper()
+
+	doc, err := xbrl.Parse(strings.NewReader(regionInstance))
+	require.NoError(t, err)
+
+	q := xbrl.NewQNameForTest("ex", "Revenue", "http://example.com/xbrl")
+	typ := xbrl.NewQNameForTest("xbrli", revenueType, nsXBRLI)
+	c := xbrl.NewConceptForTest(q, "", xbrl.QName{}, typ, false, true, "instant", "credit")
+	doc.SetTaxonomy(xbrl.NewTaxonomyForTest(map[xbrl.QName]*xbrl.Concept{q: c}))
+	return doc
+}
+
+func TestDocument_SumByMember(t *testing.T) {
+	t.Parallel()
+
+	// Prefixes are ignored when matching concept and axis.
+	revenue := xbrl.NewQNameForTest("", "Revenue", "http://example.com/xbrl")
+	axis := xbrl.NewQNameForTest("", "RegionAxis", "http://example.com/xbrl")
+
+	japan := xbrl.NewQNameForTest("ex", "JapanMember", "http://example.com/xbrl")
+	us := xbrl.NewQNameForTest("ex", "USMember", "http://example.com/xbrl")
+
+	doc := newRegionDoc(t, "monetaryItemType")
+	got, err := doc.SumByMember(revenue, axis)
+	require.NoError(t, err)
+	assert.Equal(t, map[xbrl.QName]float64{
+		{}:    1000,
+		japan: 650,
+		us:    400,
+	}, got)
+}
+
+func TestDocument_SumByMember_Errors(t *testing.T) {
+	t.Parallel()
+
+	revenue := xbrl.NewQNameForTest("ex", "Revenue", "http://example.com/xbrl")
+	axis := xbrl.NewQNameForTest("ex", "RegionAxis", "http://example.com/xbrl")
+
+	tests := []struct {
+		name    string
+		doc     func(t *testing.T) *xbrl.Document
+		wantErr error
+		wantMsg string
+	}{
+		{
+			name:    "nil document",
+			doc:     func(t *testing.T) *xbrl.Document { return nil },
+			wantMsg: "xbrl: document is nil",
+		},
+		{
+			name:    "no taxonomy",
+			doc:     func(t *testing.T) *xbrl.Document { return xbrl.NewDocumentForTest(nil, nil, nil, nil, nil) },
+			wantErr: xbrl.ErrNoTaxonomy,
+		},
+		{
+			name:    "non numeric concept",
+			doc:     func(t *testing.T) *xbrl.Document { return newRegionDoc(t, "stringItemType") },
+			wantErr: xbrl.ErrUnsupportedType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := tt.doc(t).SumByMember(revenue, axis)
+			assert.Nil(t, got)
+			if tt.wantMsg != "" {
+				assert.EqualError(t, err, tt.wantMsg)
+			} else {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDocument_WeightedSum(t *testing.T) {
+	t.Parallel()
+
+	revenue := xbrl.NewQNameForTest("ex", "Revenue", "http://example.com/xbrl")
+	cost := xbrl.NewQNameForTest("ex", "Cost", "http://example.com/xbrl")
+
+	doc, err := xbrl.Parse(strings.NewReader(regionInstance))
+	require.NoError(t, err)
+	monetary := xbrl.NewQNameForTest("xbrli", "monetaryItemType", nsXBRLI)
+	doc.SetTaxonomy(xbrl.NewTaxonomyForTest(map[xbrl.QName]*xbrl.Concept{
+		revenue: xbrl.NewConceptForTest(revenue, "", xbrl.QName{}, monetary, false, true, "instant", "credit"),
+		cost:    xbrl.NewConceptForTest(cost, "", xbrl.QName{}, monetary, false, true, "instant", "debit"),
+	}))
+
+	us := doc.FilterFacts(xbrl.NewFactFilter().ContextID("US"))
+	require.Len(t, us, 3, "revenue, nil revenue and cost")
+
+	tests := []struct {
+		name    string
+		facts   []*xbrl.Fact
+		weights map[xbrl.QName]float64
+		want    float64
+	}{
+		{
+			name:    "difference of two facts",
+			facts:   us,
+			weights: map[xbrl.QName]float64{revenue: 1, cost: -1},
+			want:    399,
+		},
+		{
+			name:    "default weight is one",
+			facts:   us,
+			weights: nil,
+			want:    401,
+		},
+		{
+			// Prefixes are ignored when matching weights.
+			name:    "weights matched by URI and local name",
+			facts:   us,
+			weights: map[xbrl.QName]float64{xbrl.NewQNameForTest("", "Cost", "http://example.com/xbrl"): 0.5},
+			want:    400.5,
+		},
+		{
+			name:  "no facts",
+			facts: nil,
+			want:  0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := doc.WeightedSum(tt.facts, tt.weights)
+			require.NoError(t, err)
+			assert.InDelta(t, tt.want, got, 1e-9)
+		})
+	}
+}
+
+func TestDocument_WeightedSum_Errors(t *testing.T) {
+	t.Parallel()
+
+	var nilDoc *xbrl.Document
+	_, err := nilDoc.WeightedSum(nil, nil)
+	assert.EqualError(t, err, "xbrl: document is nil")
+
+	_, err = xbrl.NewDocumentForTest(nil, nil, nil, nil, nil).WeightedSum(nil, nil)
+	assert.ErrorIs(t, err, xbrl.ErrNoTaxonomy)
+
+	doc := newRegionDoc(t, "stringItemType")
+	_, err = doc.WeightedSum(doc.Facts(), nil)
+	assert.ErrorIs(t, err, xbrl.ErrUnsupportedType)
+}
@@ -0,0 +1,70 @@
+package xbrl
+
+import (
+	"maps"
+	"slices"
+	"strings"
+)
+
+// CanonicalizeContexts removes redundant contexts from the document.
+// Contexts with the same Signature whose dimensions are also placed in
+// the same container (segment or scenario) are equivalent; of each group,
+// the context with the lexically smallest ID is kept, the contextRef of
+// every fact is rewritten to it, and the others are removed.
+//
+// It returns a map from each removed context ID to the ID that replaced
+// it, or nil if no context was removed. The document is modified in
+// place; use Clone first to keep the original.
+func (d *Document) CanonicalizeContexts() map[string]string {
+	if d == nil {
+		return nil
+	}
+
+	canonical := make(map[string]string) // signature -> kept ID
+	var mapping map[string]string
+	for _, id := range slices.Sorted(maps.Keys(d.contexts)) {
+		c := d.contexts[id]
+		if c == nil {
+			continue
+		}
+		sig := canonicalContextKey(c)
+		keep, ok := canonical[sig]
+		if !ok {
+			canonical[sig] = id
+			continue
+		}
+		if mapping == nil {
+			mapping = make(map[string]string)
+		}
+		mapping[id] = keep
+		delete(d.contexts, id)
+	}
+	if mapping == nil {
+		return nil
+	}
+
+	for _, f := range d.facts {
+		if f == nil {
+			continue
+		}
+		if keep, ok := mapping[f.contextRef]; ok {
+			f.contextRef = keep
+			if f.context != nil {
+				f.context = d.contexts[keep]
+			}
+		}
+	}
+	return mapping
+}
+
+// canonicalContextKey extends the Signature of c with the placement of
+// each dimension, since XBRL does not treat a dimension in the segment as
+// equal to the same dimension in the scenario.
+func canonicalContextKey(c *Context) string {
+	placements := make([]string, len(c.dimensions))
+	for i, d := range c.dimensions {
+		placements[i] = QName{local: d.dimension.local, uri: d.dimension.uri}.String() + "@" + d.source.String()
+	}
+	slices.Sort(placements)
+	return c.Signature() + "|" + strings.Join(placements, "|")
+}
@@ -0,0 +1,115 @@
+package xbrl_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aethiopicuschan/xbrl-go/pkg/xbrl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const redundantContextsInstance = `<xbrli:xbrl xmlns:xbrli="http://www.xbrl.org/2003/instance"
+    xmlns:xbrldi="http://xbrl.org/2006/xbrldi" xmlns:ex="http://example.com/xbrl">
+  <xbrli:context id="FY2025">
+    <xbrli:entity><xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:instant>2025-03-31</xbrli:instant></xbrli:period>
+  </xbrli:context>
+  <xbrli:context id="AsOf2025">
+    <xbrli:entity><xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:instant>2025-03-31</xbrli:instant></xbrli:period>
+  </xbrli:context>
+  <xbrli:context id="Seg_A">
+    <xbrli:entity>
+      <xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier>
+      <xbrli:segment>
+        <xbrldi:explicitMember dimension="ex:Axis1">ex:M1</xbrldi:explicitMember>
+        <xbrldi:explicitMember dimension="ex:Axis2">ex:M2</xbrldi:explicitMember>
+      </xbrli:segment>
+    </xbrli:entity>
+    <xbrli:period><xbrli:instant>2025-03-31</xbrli:instant></xbrli:period>
+  </xbrli:context>
+  <xbrli:context id="Seg_B">
+    <xbrli:entity>
+      <xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier>
+      <xbrli:segment>
+        <xbrldi:explicitMember dimension="ex:Axis2">ex:M2</xbrldi:explicitMember>
+        <xbrldi:explicitMember dimension="ex:Axis1">ex:M1</xbrldi:explicitMember>
+      </xbrli:segment>
+    </xbrli:entity>
+    <xbrli:period><xbrli:instant>2025-03-31</xbrli:instant></xbrli:period>
+  </xbrli:context>
+  <xbrli:context id="Prior">
+    <xbrli:entity><xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:instant>2024-03-31</xbrli:instant></xbrli:period>
+  </xbrli:context>
+  <ex:Assets contextRef="FY2025">100</ex:Assets>
+  <ex:Liabilities contextRef="AsOf2025">40</ex:Liabilities>
+  <ex:Assets contextRef="Seg_B">60</ex:Assets>
+  <ex:Assets contextRef="Prior">90</ex:Assets>
+</xbrli:xbrl>`
+
+func TestDocument_CanonicalizeContexts(t *testing.T) {
+	t.Parallel()
+
+	for _, resolve := range []bool{false, true} {
+		doc, err := xbrl.ParseWithOptions(strings.NewReader(redundantContextsInstance),
+			xbrl.ParseOptions{ResolveReferences: resolve})
+		require.NoError(t, err)
+
+		mapping := doc.CanonicalizeContexts()
+		assert.Equal(t, map[string]string{"FY2025": "AsOf2025", "Seg_B": "Seg_A"}, mapping)
+
+		var ids []string
+		for id := range doc.Contexts() {
+			ids = append(ids, id)
+		}
+		assert.ElementsMatch(t, []string{"AsOf2025", "Seg_A", "Prior"}, ids)
+
+		var refs []string
+		for _, f := range doc.Facts() {
+			refs = append(refs, f.ContextRef())
+			ctx, ok := doc.ContextOf(f)
+			require.True(t, ok)
+			assert.Equal(t, f.ContextRef(), ctx.ID())
+			if resolve {
+				assert.Same(t, ctx, f.Context())
+			}
+		}
+		assert.Equal(t, []string{"AsOf2025", "AsOf2025", "Seg_A", "Prior"}, refs)
+
+		assert.Nil(t, doc.CanonicalizeContexts(), "already canonical")
+	}
+
+	var nilDoc *xbrl.Document
+	assert.Nil(t, nilDoc.CanonicalizeContexts())
+}
+
+func TestDocument_CanonicalizeContexts_DimensionPlacement(t *testing.T) {
+	t.Parallel()
+
+	const src = `<xbrli:xbrl xmlns:xbrli="http://www.xbrl.org/2003/instance"
+    xmlns:xbrldi="http://xbrl.org/2006/xbrldi" xmlns:ex="http://example.com/ex">
+  <xbrli:context id="A">
+    <xbrli:entity>
+      <xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier>
+      <xbrli:segment><xbrldi:explicitMember dimension="ex:Region">ex:Japan</xbrldi:explicitMember></xbrli:segment>
+    </xbrli:entity>
+    <xbrli:period><xbrli:instant>2025-12-31</xbrli:instant></xbrli:period>
+  </xbrli:context>
+  <xbrli:context id="B">
+    <xbrli:entity><xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:instant>2025-12-31</xbrli:instant></xbrli:period>
+    <xbrli:scenario><xbrldi:explicitMember dimension="ex:Region">ex:Japan</xbrldi:explicitMember></xbrli:scenario>
+  </xbrli:context>
+  <ex:Sales contextRef="A">1</ex:Sales>
+  <ex:Sales contextRef="B">2</ex:Sales>
+</xbrli:xbrl>`
+
+	doc, err := xbrl.Parse(strings.NewReader(src))
+	require.NoError(t, err)
+
+	assert.Nil(t, doc.CanonicalizeContexts(), "segment and scenario dimensions are not equivalent")
+	assert.Len(t, doc.Contexts(), 2)
+	assert.Equal(t, "B", doc.Facts()[1].ContextRef())
+}
@@ -0,0 +1,137 @@
+package xbrl
+
+import (
+	"maps"
+	"slices"
+)
+
+// Clone returns a deep copy of the document.
+//
+// Contexts, units and facts (including their dimension and measure
+// slices) are copied, so the clone can be mutated without affecting the
+// original. The attached taxonomy is shared; use Taxonomy.Clone and
+// SetTaxonomy on the result if an independent copy is needed.
+func (d *Document) Clone() *Document {
+	if d == nil {
+		return nil
+	}
+
+	out := &Document{
+		schemaRefs:    slices.Clone(d.schemaRefs),
+		linkbaseRefs:  slices.Clone(d.linkbaseRefs),
+		taxonomy:      d.taxonomy,
+		rootAttrs:     slices.Clone(d.rootAttrs),
+		warnings:      slices.Clone(d.warnings),
+		footnotes:     maps.Clone(d.footnotes),
+		hasDimensions: d.hasDimensions,
+	}
+
+	if d.contexts != nil {
+		out.contexts = make(map[string]*Context, len(d.contexts))
+		for id, c := range d.contexts {
+			out.contexts[id] = c.clone()
+		}
+	}
+	if d.units != nil {
+		out.units = make(map[string]*Unit, len(d.units))
+		for id, u := range d.units {
+			out.units[id] = u.clone()
+		}
+	}
+	if d.facts != nil {
+		out.facts = make([]*Fact, len(d.facts))
+		resolved := false
+		for i, f := range d.facts {
+			out.facts[i] = f.clone()
+			resolved = resolved || (f != nil && (f.context != nil || f.unit != nil))
+		}
+		// Resolved references must point into the clone.
+		if resolved {
+			out.resolveReferences()
+		}
+	}
+
+	return out
+}
+
+// Clone returns a copy of the taxonomy whose concepts and linkbase data
+// can be modified independently of the original.
+func (t *Taxonomy) Clone() *Taxonomy {
+	if t == nil {
+		return nil
+	}
+	out := NewTaxonomy()
+	for q, c := range t.concepts {
+		if c == nil {
+			out.concepts[q] = nil
+			continue
+		}
+		cc := *c
+		out.concepts[q] = &cc
+	}
+
+	out.linkbaseRefs = slices.Clone(t.linkbaseRefs)
+	out.relationships = slices.Clone(t.relationships)
+	if t.labels != nil {
+		out.labels = make(map[conceptKey][]Label, len(t.labels))
+		for k, ls := range t.labels {
+			out.labels[k] = slices.Clone(ls)
+		}
+	}
+	if t.references != nil {
+		out.references = make(map[conceptKey][]Reference, len(t.references))
+		for k, rs := range t.references {
+			refs := make([]Reference, len(rs))
+			for i, r := range rs {
+				refs[i] = Reference{role: r.role, parts: slices.Clone(r.parts)}
+			}
+			out.references[k] = refs
+		}
+	}
+	return out
+}
+
+func (c *Context) clone() *Context {
+	if c == nil {
+		return nil
+	}
+	out := *c
+	out.period = c.period.clone()
+	out.dimensions = slices.Clone(c.dimensions)
+	return &out
+}
+
+func (p Period) clone() Period {
+	out := p
+	out.instant = cloneStringPtr(p.instant)
+	out.startDate = cloneStringPtr(p.startDate)
+	out.endDate = cloneStringPtr(p.endDate)
+	return out
+}
+
+func (u *Unit) clone() *Unit {
+	if u == nil {
+		return nil
+	}
+	out := *u
+	out.measures = slices.Clone(u.measures)
+	out.numerator = slices.Clone(u.numerator)
+	out.denominator = slices.Clone(u.denominator)
+	return &out
+}
+
+func (f *Fact) clone() *Fact {
+	if f == nil {
+		return nil
+	}
+	out := *f
+	return &out
+}
+
+func cloneStringPtr(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	v := *s
+	return &v
+}
@@ -0,0 +1,73 @@
+package xbrl_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aethiopicuschan/xbrl-go/pkg/xbrl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocument_Clone_Nil(t *testing.T) {
+	t.Parallel()
+
+	var d *xbrl.Document
+	assert.Nil(t, d.Clone())
+
+	var tax *xbrl.Taxonomy
+	assert.Nil(t, tax.Clone())
+}
+
+func TestDocument_Clone_DeepCopy(t *testing.T) {
+	t.Parallel()
+
+	orig, err := xbrl.Parse(strings.NewReader(extendedInstance))
+	require.NoError(t, err)
+	tax := xbrl.NewTaxonomy()
+	orig.SetTaxonomy(tax)
+
+	clone := orig.Clone()
+	require.NotNil(t, clone)
+
+	// Same content.
+	assert.Equal(t, orig.SchemaRefs(), clone.SchemaRefs())
+	assert.Equal(t, orig.Contexts(), clone.Contexts())
+	assert.Equal(t, orig.Units(), clone.Units())
+	assert.Equal(t, orig.Facts(), clone.Facts())
+	assert.Same(t, tax, clone.Taxonomy())
+
+	// Distinct pointers.
+	for i, f := range clone.Facts() {
+		assert.NotSame(t, orig.Facts()[i], f)
+	}
+	for id, c := range clone.Contexts() {
+		assert.NotSame(t, orig.Contexts()[id], c)
+	}
+	for id, u := range clone.Units() {
+		assert.NotSame(t, orig.Units()[id], u)
+	}
+
+	// Mutating the clone does not affect the original.
+	xbrl.SetFactValueForTest(clone.Facts()[0], "99999")
+	assert.Equal(t, "99999", clone.Facts()[0].Value())
+	assert.Equal(t, "12345", orig.Facts()[0].Value())
+
+	origStart, _ := orig.Contexts()["C1"].Period().StartDate()
+	cloneStart, _ := clone.Contexts()["C1"].Period().StartDate()
+	assert.Equal(t, origStart, cloneStart)
+}
+
+func TestTaxonomy_Clone(t *testing.T) {
+	t.Parallel()
+
+	q := xbrl.NewQNameForTest("ex", "Revenue", "http://example.com")
+	c := xbrl.NewConceptForTest(q, "ex_Revenue", xbrl.QName{}, xbrl.QName{}, false, false, "duration", "credit")
+	orig := xbrl.NewTaxonomyForTest(map[xbrl.QName]*xbrl.Concept{q: c})
+
+	clone := orig.Clone()
+	got, ok := clone.Concept(q)
+	require.True(t, ok)
+	assert.Equal(t, c, got)
+	assert.NotSame(t, c, got)
+}
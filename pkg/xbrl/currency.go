@@ -0,0 +1,154 @@
+package xbrl
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// ReportingCurrencies returns the distinct ISO 4217 currency codes of the
+// units referenced by facts in the document, sorted alphabetically.
+//
+// Units that are not currencies (see Unit.CurrencyCode) are ignored.
+func (d *Document) ReportingCurrencies() []string {
+	if d == nil {
+		return nil
+	}
+	counts := d.currencyCounts()
+	if len(counts) == 0 {
+		return nil
+	}
+	return slices.Sorted(maps.Keys(counts))
+}
+
+// PrimaryCurrency returns the currency code used by the largest number of
+// facts in the document. Ties are broken alphabetically.
+//
+// The second return value is false if no fact references a currency unit.
+func (d *Document) PrimaryCurrency() (string, bool) {
+	if d == nil {
+		return "", false
+	}
+	counts := d.currencyCounts()
+
+	var (
+		best  string
+		bestN int
+	)
+	for _, code := range slices.Sorted(maps.Keys(counts)) {
+		if n := counts[code]; n > bestN {
+			best, bestN = code, n
+		}
+	}
+	return best, bestN > 0
+}
+
+// UnitConsistencyByConcept maps each concept to the distinct currency
+// codes of the units its facts use, sorted alphabetically. Concepts are
+// compared by namespace URI and local name and keyed by the name of their
+// first fact. Facts without a currency unit are ignored, so concepts
+// that never use one are absent.
+//
+// Monetary concepts are usually reported in a single currency; see
+// MixedCurrencyConcepts for those that are not.
+func (d *Document) UnitConsistencyByConcept() map[QName][]string {
+	if d == nil {
+		return nil
+	}
+
+	names := make(map[conceptKey]QName)
+	codes := make(map[conceptKey]map[string]struct{})
+	for _, f := range d.facts {
+		if f == nil || f.unitRef == "" {
+			continue
+		}
+		code, ok := d.units[f.unitRef].CurrencyCode()
+		if !ok {
+			continue
+		}
+		k := conceptKey{f.name.uri, f.name.local}
+		if _, ok := names[k]; !ok {
+			names[k] = f.name
+			codes[k] = make(map[string]struct{})
+		}
+		codes[k][code] = struct{}{}
+	}
+
+	out := make(map[QName][]string, len(names))
+	for k, q := range names {
+		out[q] = slices.Sorted(maps.Keys(codes[k]))
+	}
+	return out
+}
+
+// MixedCurrencyConcepts returns the concepts whose facts use more than one
+// currency (see UnitConsistencyByConcept), sorted by namespace URI and
+// then by local name.
+func (d *Document) MixedCurrencyConcepts() []QName {
+	var out []QName
+	for q, codes := range d.UnitConsistencyByConcept() {
+		if len(codes) > 1 {
+			out = append(out, q)
+		}
+	}
+	slices.SortFunc(out, func(a, b QName) int {
+		if c := cmp.Compare(a.uri, b.uri); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.local, b.local)
+	})
+	return out
+}
+
+// AsFloat64InCurrency returns the value of a monetary fact converted to
+// the target currency (an ISO 4217 code such as "USD").
+//
+// The value is parsed as by AsFloat64 and the source currency is read
+// from the fact's unit (see Unit.CurrencyCode). Unless it already is the
+// target currency, the value is multiplied by rate(source, target).
+// Facts whose concept is not monetary, or whose unit is missing or not a
+// currency, yield an error wrapping ErrUnsupportedType.
+func (d *Document) AsFloat64InCurrency(f *Fact, target string, rate func(from, to string) (float64, error)) (float64, error) {
+	if rate == nil {
+		return 0, fmt.Errorf("xbrl: rate function is nil")
+	}
+	v, err := d.AsFloat64(f)
+	if err != nil {
+		return 0, err
+	}
+	if c, _ := d.ConceptOf(f); c.ValueKind() != ConceptValueMonetary {
+		return 0, ErrUnsupportedType
+	}
+
+	from, ok := d.units[f.unitRef].CurrencyCode()
+	if !ok {
+		return 0, fmt.Errorf("%w: unit %q is not a currency", ErrUnsupportedType, f.unitRef)
+	}
+	if from == target {
+		return v, nil
+	}
+	r, err := rate(from, target)
+	if err != nil {
+		return 0, fmt.Errorf("xbrl: convert %s to %s: %w", from, target, err)
+	}
+	return v * r, nil
+}
+
+// currencyCounts counts facts per currency code of their unit.
+func (d *Document) currencyCounts() map[string]int {
+	counts := make(map[string]int)
+	for _, f := range d.facts {
+		if f == nil || f.unitRef == "" {
+			continue
+		}
+		u, ok := d.units[f.unitRef]
+		if !ok {
+			continue
+		}
+		if code, ok := u.CurrencyCode(); ok {
+			counts[code]++
+		}
+	}
+	return counts
+}
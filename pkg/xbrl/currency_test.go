@@ -0,0 +1,231 @@
+package xbrl_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/aethiopicuschan/xbrl-go/pkg/xbrl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const multiCurrencyInstance = `
+<xbrli:xbrl
+    xmlns:xbrli="http://www.xbrl.org/2003/instance"
+    xmlns:iso4217="http://www.xbrl.org/2003/iso4217"
+    xmlns:ex="http://example.com/xbrl">
+  <xbrli:context id="C1">
+    <xbrli:entity>
+      <xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier>
+    </xbrli:entity>
+    <xbrli:period>
+      <xbrli:instant>2025-03-31</xbrli:instant>
+    </xbrli:period>
+  </xbrli:context>
+  <xbrli:unit id="JPY">
+    <xbrli:measure>iso4217:JPY</xbrli:measure>
+  </xbrli:unit>
+  <xbrli:unit id="USD">
+    <xbrli:measure>iso4217:USD</xbrli:measure>
+  </xbrli:unit>
+  <xbrli:unit id="shares">
+    <xbrli:measure>xbrli:shares</xbrli:measure>
+  </xbrli:unit>
+  <xbrli:unit id="JPYPerShare">
+    <xbrli:divide>
+      <xbrli:unitNumerator><xbrli:measure>iso4217:JPY</xbrli:measure></xbrli:unitNumerator>
+      <xbrli:unitDenominator><xbrli:measure>xbrli:shares</xbrli:measure></xbrli:unitDenominator>
+    </xbrli:divide>
+  </xbrli:unit>
+  <ex:Revenue contextRef="C1" unitRef="JPY" decimals="0">100</ex:Revenue>
+  <ex:Cost contextRef="C1" unitRef="JPY" decimals="0">50</ex:Cost>
+  <ex:Profit contextRef="C1" unitRef="JPY" decimals="0">50</ex:Profit>
+  <ex:ForeignRevenue contextRef="C1" unitRef="USD" decimals="0">1</ex:ForeignRevenue>
+  <ex:SharesOutstanding contextRef="C1" unitRef="shares" decimals="0">10</ex:SharesOutstanding>
+  <ex:EPS contextRef="C1" unitRef="JPYPerShare" decimals="2">5.00</ex:EPS>
+  <ex:Name contextRef="C1">ABC</ex:Name>
+</xbrli:xbrl>
+`
+
+func TestUnit_CurrencyCode(t *testing.T) {
+	t.Parallel()
+
+	jpy := xbrl.NewQNameForTest("iso4217", "JPY", "http://www.xbrl.org/2003/iso4217")
+	unresolved := xbrl.NewQNameForTest("iso4217", "EUR", "")
+	shares := xbrl.NewQNameForTest("xbrli", "shares", "http://www.xbrl.org/2003/instance")
+
+	tests := []struct {
+		name     string
+		unit     *xbrl.Unit
+		wantCode string
+		wantOK   bool
+	}{
+		{"nil unit", nil, "", false},
+		{"iso4217 namespace", xbrl.NewUnitSimpleForTest("U", []xbrl.QName{jpy}), "JPY", true},
+		{"unresolved iso4217 prefix", xbrl.NewUnitSimpleForTest("U", []xbrl.QName{unresolved}), "EUR", true},
+		{"non-currency measure", xbrl.NewUnitSimpleForTest("U", []xbrl.QName{shares}), "", false},
+		{"multiple measures", xbrl.NewUnitSimpleForTest("U", []xbrl.QName{jpy, jpy}), "", false},
+		{"divide unit", xbrl.NewUnitDivideForTest("U", []xbrl.QName{jpy}, []xbrl.QName{shares}), "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			code, ok := tt.unit.CurrencyCode()
+			assert.Equal(t, tt.wantCode, code)
+			assert.Equal(t, tt.wantOK, ok)
+		})
+	}
+}
+
+func TestDocument_ReportingCurrencies(t *testing.T) {
+	t.Parallel()
+
+	doc, err := xbrl.Parse(strings.NewReader(multiCurrencyInstance))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"JPY", "USD"}, doc.ReportingCurrencies())
+
+	code, ok := doc.PrimaryCurrency()
+	assert.True(t, ok)
+	assert.Equal(t, "JPY", code)
+}
+
+func TestDocument_ReportingCurrencies_NoCurrency(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		doc  *xbrl.Document
+	}{
+		{"nil document", nil},
+		{"no facts", xbrl.NewDocumentForTest(nil, nil, nil, nil, nil)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Nil(t, tt.doc.ReportingCurrencies())
+			code, ok := tt.doc.PrimaryCurrency()
+			assert.False(t, ok)
+			assert.Empty(t, code)
+		})
+	}
+}
+
+func TestDocument_AsFloat64InCurrency(t *testing.T) {
+	t.Parallel()
+
+	doc, err := xbrl.Parse(strings.NewReader(multiCurrencyInstance))
+	require.NoError(t, err)
+
+	concepts := make(map[xbrl.QName]*xbrl.Concept)
+	for local, typ := range map[string]string{
+		"Revenue":           "monetaryItemType",
+		"ForeignRevenue":    "monetaryItemType",
+		"EPS":               "monetaryItemType",
+		"SharesOutstanding": "sharesItemType",
+		"Name":              "stringItemType",
+	} {
+		q := xbrl.NewQNameForTest("ex", local, "http://example.com/xbrl")
+		concepts[q] = xbrl.NewConceptForTest(q, "", xbrl.QName{}, xbrl.NewQNameForTest("xbrli", typ, nsXBRLI), false, false, "instant", "")
+	}
+	doc.SetTaxonomy(xbrl.NewTaxonomyForTest(concepts))
+
+	fact := func(local string) *xbrl.Fact {
+		facts := doc.FilterFacts(xbrl.NewFactFilter().ConceptLocal(local))
+		require.Len(t, facts, 1)
+		return facts[0]
+	}
+
+	// rate is a stub with a single JPY->USD rate.
+	rate := func(from, to string) (float64, error) {
+		if from == "JPY" && to == "USD" {
+			return 0.0065, nil
+		}
+		return 0, errors.New("no rate")
+	}
+
+	tests := []struct {
+		name    string
+		fact    string
+		target  string
+		want    float64
+		wantErr error
+		wantMsg string
+	}{
+		{name: "JPY to USD", fact: "Revenue", target: "USD", want: 0.65},
+		{name: "already in target", fact: "ForeignRevenue", target: "USD", want: 1},
+		{name: "rate error", fact: "ForeignRevenue", target: "EUR", wantMsg: "xbrl: convert USD to EUR: no rate"},
+		{name: "not monetary", fact: "SharesOutstanding", target: "USD", wantErr: xbrl.ErrUnsupportedType},
+		{name: "string", fact: "Name", target: "USD", wantErr: xbrl.ErrUnsupportedType},
+		{name: "unit is not a currency", fact: "EPS", target: "USD", wantErr: xbrl.ErrUnsupportedType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := doc.AsFloat64InCurrency(fact(tt.fact), tt.target, rate)
+			switch {
+			case tt.wantErr != nil:
+				assert.ErrorIs(t, err, tt.wantErr)
+			case tt.wantMsg != "":
+				assert.EqualError(t, err, tt.wantMsg)
+			default:
+				require.NoError(t, err)
+				assert.InDelta(t, tt.want, got, 1e-9)
+			}
+		})
+	}
+
+	t.Run("nil rate", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := doc.AsFloat64InCurrency(fact("Revenue"), "USD", nil)
+		assert.EqualError(t, err, "xbrl: rate function is nil")
+	})
+}
+
+func TestDocument_UnitConsistencyByConcept(t *testing.T) {
+	t.Parallel()
+
+	// Revenue is also reported in USD, under another prefix.
+	src := strings.Replace(multiCurrencyInstance, `xmlns:ex="http://example.com/xbrl">`,
+		`xmlns:ex="http://example.com/xbrl" xmlns:ex2="http://example.com/xbrl">`, 1)
+	src = strings.Replace(src, `<ex:Name contextRef="C1">ABC</ex:Name>`,
+		`<ex:Name contextRef="C1">ABC</ex:Name>
+  <ex2:Revenue contextRef="C1" unitRef="USD" decimals="0">1</ex2:Revenue>
+  <ex:Cost contextRef="C1" unitRef="JPY" decimals="0">60</ex:Cost>`, 1)
+	doc, err := xbrl.Parse(strings.NewReader(src))
+	require.NoError(t, err)
+
+	// Concepts are compared by URI and local name; the prefix of the key
+	// is whichever the first fact used.
+	type concept struct{ uri, local string }
+	got := make(map[concept][]string)
+	for q, codes := range doc.UnitConsistencyByConcept() {
+		got[concept{q.URI(), q.Local()}] = codes
+	}
+	const ns = "http://example.com/xbrl"
+	assert.Equal(t, map[concept][]string{
+		{ns, "Revenue"}:        {"JPY", "USD"},
+		{ns, "Cost"}:           {"JPY"},
+		{ns, "Profit"}:         {"JPY"},
+		{ns, "ForeignRevenue"}: {"USD"},
+	}, got)
+
+	mixed := doc.MixedCurrencyConcepts()
+	require.Len(t, mixed, 1)
+	assert.Equal(t, ns, mixed[0].URI())
+	assert.Equal(t, "Revenue", mixed[0].Local())
+
+	single, err := xbrl.Parse(strings.NewReader(multiCurrencyInstance))
+	require.NoError(t, err)
+	assert.Nil(t, single.MixedCurrencyConcepts())
+
+	var nilDoc *xbrl.Document
+	assert.Nil(t, nilDoc.UnitConsistencyByConcept())
+	assert.Nil(t, nilDoc.MixedCurrencyConcepts())
+}
@@ -0,0 +1,30 @@
+package xbrl
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseDecimals parses the value of a decimals or precision attribute.
+//
+// Surrounding whitespace is ignored. An empty value reports present as
+// false. "INF" reports inf as true; any other value must be an xsd:integer
+// such as "2", "-3" or "+0" and is returned as value. Negative values are
+// valid for decimals only; callers parsing precision should reject them.
+// Malformed values return an error wrapping ErrInvalidValue, with present
+// set to true.
+func ParseDecimals(s string) (value int, inf bool, present bool, err error) {
+	s = strings.TrimSpace(s)
+	switch s {
+	case "":
+		return 0, false, false, nil
+	case "INF":
+		return 0, true, true, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, false, true, fmt.Errorf("%w: decimals/precision %q", ErrInvalidValue, s)
+	}
+	return n, false, true, nil
+}
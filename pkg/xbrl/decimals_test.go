@@ -0,0 +1,52 @@
+package xbrl_test
+
+import (
+	"testing"
+
+	"github.com/aethiopicuschan/xbrl-go/pkg/xbrl"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDecimals(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		in          string
+		wantValue   int
+		wantInf     bool
+		wantPresent bool
+		wantErr     bool
+	}{
+		{name: "empty", in: ""},
+		{name: "whitespace only", in: "  "},
+		{name: "zero", in: "0", wantPresent: true},
+		{name: "positive", in: "2", wantValue: 2, wantPresent: true},
+		{name: "explicit plus", in: "+2", wantValue: 2, wantPresent: true},
+		{name: "negative", in: "-6", wantValue: -6, wantPresent: true},
+		{name: "surrounding whitespace", in: " -3\n", wantValue: -3, wantPresent: true},
+		{name: "INF", in: "INF", wantInf: true, wantPresent: true},
+		{name: "INF with whitespace", in: " INF ", wantInf: true, wantPresent: true},
+		{name: "lowercase inf", in: "inf", wantPresent: true, wantErr: true},
+		{name: "negative INF", in: "-INF", wantPresent: true, wantErr: true},
+		{name: "fraction", in: "1.5", wantPresent: true, wantErr: true},
+		{name: "word", in: "two", wantPresent: true, wantErr: true},
+		{name: "out of range", in: "99999999999999999999", wantPresent: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			value, inf, present, err := xbrl.ParseDecimals(tt.in)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, xbrl.ErrInvalidValue)
+				assert.ErrorContains(t, err, "decimals/precision")
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantValue, value)
+			assert.Equal(t, tt.wantInf, inf)
+			assert.Equal(t, tt.wantPresent, present)
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package xbrl
+
+import "fmt"
+
+// DimensionCoordinate returns the dimensional coordinate of the fact: a map
+// from each axis QName of the fact's context to its member.
+//
+// For explicit dimensions the value is the member QName's String(); for
+// typed dimensions it is the raw typed value. A fact without dimensions
+// yields an empty map. If the fact's context is missing, the error wraps
+// ErrNoContext.
+func (d *Document) DimensionCoordinate(f *Fact) (map[QName]string, error) {
+	if d == nil {
+		return nil, fmt.Errorf("xbrl: document is nil")
+	}
+	if f == nil {
+		return nil, fmt.Errorf("xbrl: fact is nil")
+	}
+
+	ctx, ok := d.ContextOf(f)
+	if !ok || ctx == nil {
+		return nil, fmt.Errorf("%w: %q", ErrNoContext, f.contextRef)
+	}
+
+	out := make(map[QName]string, len(ctx.dimensions))
+	for _, dim := range ctx.dimensions {
+		if dim.explicit {
+			out[dim.dimension] = dim.member.String()
+		} else {
+			out[dim.dimension] = dim.typedValue
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,80 @@
+package xbrl_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aethiopicuschan/xbrl-go/pkg/xbrl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocument_DimensionCoordinate(t *testing.T) {
+	t.Parallel()
+
+	doc, err := xbrl.Parse(strings.NewReader(extendedInstance))
+	require.NoError(t, err)
+
+	region := xbrl.NewQNameForTest("ex", "Region", "http://example.com/xbrl")
+	scenario := xbrl.NewQNameForTest("ex", "Scenario", "http://example.com/xbrl")
+
+	revenue := doc.Facts()[0]
+	noDims := xbrl.NewFactForTest(xbrl.FactKindItem, xbrl.QName{}, "", "C2", "", "", "", "", "", false)
+	missing := xbrl.NewFactForTest(xbrl.FactKindItem, xbrl.QName{}, "", "C404", "", "", "", "", "", false)
+
+	tests := []struct {
+		name    string
+		doc     *xbrl.Document
+		fact    *xbrl.Fact
+		want    map[xbrl.QName]string
+		wantErr string
+	}{
+		{
+			name: "explicit and typed dimensions",
+			doc:  doc,
+			fact: revenue,
+			want: map[xbrl.QName]string{
+				region:   "{http://example.com/xbrl}Japan",
+				scenario: "<ex:ScenarioType> Base </ex:ScenarioType>",
+			},
+		},
+		{
+			name: "context without dimensions",
+			doc:  doc,
+			fact: noDims,
+			want: map[xbrl.QName]string{},
+		},
+		{
+			name:    "missing context",
+			doc:     doc,
+			fact:    missing,
+			wantErr: `xbrl: context not found for fact: "C404"`,
+		},
+		{
+			name:    "nil fact",
+			doc:     doc,
+			fact:    nil,
+			wantErr: "xbrl: fact is nil",
+		},
+		{
+			name:    "nil document",
+			doc:     nil,
+			fact:    revenue,
+			wantErr: "xbrl: document is nil",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := tt.doc.DimensionCoordinate(tt.fact)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.Nil(t, got)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package xbrl
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DocInfoMapping names the concepts that hold document and entity
+// information (DEI) in a filing, such as dei:EntityRegistrantName in SEC
+// filings or jpdei_cor:FilerNameInJapaneseDEI in EDINET filings. Concepts
+// are compared by namespace URI and local name, and fields left as the
+// zero QName are not extracted.
+type DocInfoMapping struct {
+	EntityName    QName
+	FiscalYear    QName
+	FiscalYearEnd QName
+	PeriodEnd     QName
+}
+
+// DocInfo is the document and entity information extracted by
+// Document.DocumentInfo. Fields whose concept is not mapped, not reported
+// or not parseable are left as their zero value.
+type DocInfo struct {
+	EntityName string
+
+	// FiscalYear is the fiscal year the document reports on, e.g. 2025.
+	FiscalYear int
+
+	// FiscalYearEnd is the end of the fiscal year. Values given as an
+	// xsd:gMonthDay such as "--03-31" have year 0.
+	FiscalYearEnd time.Time
+
+	// PeriodEnd is the end date of the period the document covers.
+	PeriodEnd time.Time
+}
+
+// DocumentInfo extracts the document and entity information facts named
+// by mapping into a DocInfo. No taxonomy is needed: values are parsed
+// from their lexical form.
+//
+// For each concept, xsi:nil facts are ignored and a fact in a context
+// without dimensions is preferred; otherwise the first fact in document
+// order is used.
+func (d *Document) DocumentInfo(mapping DocInfoMapping) DocInfo {
+	var info DocInfo
+	if d == nil {
+		return info
+	}
+
+	if v, ok := d.docInfoValue(mapping.EntityName); ok {
+		info.EntityName = v
+	}
+	if v, ok := d.docInfoValue(mapping.FiscalYear); ok {
+		if year, err := strconv.Atoi(v); err == nil {
+			info.FiscalYear = year
+		}
+	}
+	if v, ok := d.docInfoValue(mapping.FiscalYearEnd); ok {
+		if t, err := time.Parse("--01-02", v); err == nil {
+			info.FiscalYearEnd = t
+		} else if t, ok := parseXSDDate(v); ok {
+			info.FiscalYearEnd = t
+		}
+	}
+	if v, ok := d.docInfoValue(mapping.PeriodEnd); ok {
+		if t, ok := parseXSDDate(v); ok {
+			info.PeriodEnd = t
+		}
+	}
+	return info
+}
+
+// docInfoValue returns the trimmed value of the fact chosen for concept q
+// by DocumentInfo.
+func (d *Document) docInfoValue(q QName) (string, bool) {
+	if q.local == "" {
+		return "", false
+	}
+	var found *Fact
+	for _, f := range d.facts {
+		if f == nil || f.nil || !sameExpandedName(f.name, q) {
+			continue
+		}
+		if c := d.contexts[f.contextRef]; c == nil || len(c.dimensions) == 0 {
+			found = f
+			break
+		}
+		if found == nil {
+			found = f
+		}
+	}
+	if found == nil {
+		return "", false
+	}
+	return strings.TrimSpace(found.value), true
+}
@@ -0,0 +1,113 @@
+package xbrl_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aethiopicuschan/xbrl-go/pkg/xbrl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const deiInstance = `<xbrli:xbrl xmlns:xbrli="http://www.xbrl.org/2003/instance"
+    xmlns:xbrldi="http://xbrl.org/2006/xbrldi"
+    xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"
+    xmlns:dei="http://example.com/dei" xmlns:ex="http://example.com/xbrl">
+  <xbrli:context id="D">
+    <xbrli:entity><xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:startDate>2024-04-01</xbrli:startDate><xbrli:endDate>2025-03-31</xbrli:endDate></xbrli:period>
+  </xbrli:context>
+  <xbrli:context id="D_Segment">
+    <xbrli:entity>
+      <xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier>
+      <xbrli:segment><xbrldi:explicitMember dimension="ex:Axis">ex:Member</xbrldi:explicitMember></xbrli:segment>
+    </xbrli:entity>
+    <xbrli:period><xbrli:startDate>2024-04-01</xbrli:startDate><xbrli:endDate>2025-03-31</xbrli:endDate></xbrli:period>
+  </xbrli:context>
+  <dei:EntityRegistrantName contextRef="D_Segment">Segment Name</dei:EntityRegistrantName>
+  <dei:EntityRegistrantName contextRef="D" xsi:nil="true"/>
+  <dei:EntityRegistrantName contextRef="D"> ACME Corp. </dei:EntityRegistrantName>
+  <dei:DocumentFiscalYearFocus contextRef="D">2025</dei:DocumentFiscalYearFocus>
+  <dei:CurrentFiscalYearEndDate contextRef="D">2025-03-31</dei:CurrentFiscalYearEndDate>
+  <dei:DocumentPeriodEndDate contextRef="D">2025-03-31</dei:DocumentPeriodEndDate>
+  <dei:FiscalYearEndMonthDay contextRef="D">--03-31</dei:FiscalYearEndMonthDay>
+  <dei:BadDate contextRef="D">March 31</dei:BadDate>
+  <dei:SegmentOnly contextRef="D_Segment">Only in a segment</dei:SegmentOnly>
+</xbrli:xbrl>`
+
+func TestDocument_DocumentInfo(t *testing.T) {
+	t.Parallel()
+
+	doc, err := xbrl.Parse(strings.NewReader(deiInstance))
+	require.NoError(t, err)
+
+	dei := func(local string) xbrl.QName {
+		return xbrl.NewQNameForTest("dei", local, "http://example.com/dei")
+	}
+	march31 := time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		mapping xbrl.DocInfoMapping
+		want    xbrl.DocInfo
+	}{
+		{
+			name: "all fields",
+			mapping: xbrl.DocInfoMapping{
+				EntityName:    dei("EntityRegistrantName"),
+				FiscalYear:    dei("DocumentFiscalYearFocus"),
+				FiscalYearEnd: dei("CurrentFiscalYearEndDate"),
+				PeriodEnd:     dei("DocumentPeriodEndDate"),
+			},
+			want: xbrl.DocInfo{
+				EntityName:    "ACME Corp.",
+				FiscalYear:    2025,
+				FiscalYearEnd: march31,
+				PeriodEnd:     march31,
+			},
+		},
+		{
+			name:    "month-day fiscal year end",
+			mapping: xbrl.DocInfoMapping{FiscalYearEnd: dei("FiscalYearEndMonthDay")},
+			want:    xbrl.DocInfo{FiscalYearEnd: time.Date(0, 3, 31, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			name: "prefix is ignored",
+			mapping: xbrl.DocInfoMapping{
+				EntityName: xbrl.NewQNameForTest("other", "EntityRegistrantName", "http://example.com/dei"),
+			},
+			want: xbrl.DocInfo{EntityName: "ACME Corp."},
+		},
+		{
+			name: "dimensioned fact as fallback",
+			mapping: xbrl.DocInfoMapping{
+				EntityName: dei("SegmentOnly"),
+			},
+			want: xbrl.DocInfo{EntityName: "Only in a segment"},
+		},
+		{
+			name: "unparseable and missing values",
+			mapping: xbrl.DocInfoMapping{
+				EntityName: dei("Missing"),
+				FiscalYear: dei("EntityRegistrantName"),
+				PeriodEnd:  dei("BadDate"),
+			},
+			want: xbrl.DocInfo{},
+		},
+		{
+			name: "empty mapping",
+			want: xbrl.DocInfo{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, doc.DocumentInfo(tt.mapping))
+		})
+	}
+
+	var nilDoc *xbrl.Document
+	assert.Equal(t, xbrl.DocInfo{}, nilDoc.DocumentInfo(xbrl.DocInfoMapping{EntityName: dei("EntityRegistrantName")}))
+}
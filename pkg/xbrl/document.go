@@ -1,18 +1,33 @@
 package xbrl
 
 import (
+	"encoding/xml"
 	"fmt"
 	"io"
 	"maps"
+	"slices"
+	"strings"
+	"time"
 )
 
 // Document represents a parsed XBRL instance document.
 type Document struct {
-	schemaRefs []SchemaRef
-	contexts   map[string]*Context
-	units      map[string]*Unit
-	facts      []*Fact
-	taxonomy   *Taxonomy
+	schemaRefs   []SchemaRef
+	linkbaseRefs []LinkbaseRef
+	contexts     map[string]*Context
+	units        map[string]*Unit
+	facts        []*Fact
+	taxonomy     *Taxonomy
+	rootAttrs    []xml.Attr
+	warnings     []string
+
+	// footnotes maps fact IDs to the footnotes linked to them.
+	footnotes map[string][]Footnote
+
+	// hasDimensions caches whether any context has dimensions, so that
+	// dimension filters can be skipped for non-dimensional documents.
+	// It must be kept in sync whenever contexts are added.
+	hasDimensions bool
 }
 
 // SchemaRef represents a <schemaRef> element in an XBRL instance.
@@ -98,15 +113,57 @@ type Fact struct {
 	id         string
 	lang       string
 	nil        bool
+
+	// index is the position of the fact in document order (0-based).
+	index int
+
+	// source is the raw outer XML of the fact when captured at parse time.
+	source string
+
+	// context and unit are set when references are resolved at parse time
+	// (see ParseOptions.ResolveReferences).
+	context *Context
+	unit    *Unit
+
+	// concept is the concept of the fact in the taxonomy attached to the
+	// document, cached when the taxonomy is attached. It is valid while
+	// conceptTax is the attached taxonomy and its generation is still
+	// conceptGen.
+	concept    *Concept
+	conceptTax *Taxonomy
+	conceptGen uint64
+}
+
+// DimensionSource describes where a dimension was declared in a context.
+type DimensionSource int
+
+const (
+	DimensionSourceUnknown  DimensionSource = iota
+	DimensionSourceSegment                  // <entity><segment>
+	DimensionSourceScenario                 // <scenario>
+)
+
+// String implements fmt.Stringer.
+func (s DimensionSource) String() string {
+	switch s {
+	case DimensionSourceSegment:
+		return "segment"
+	case DimensionSourceScenario:
+		return "scenario"
+	default:
+		return "unknown"
+	}
 }
 
 // Dimension represents a dimensional qualifier (explicit or typed)
 // attached to a context via <segment> or <scenario>.
 type Dimension struct {
-	dimension  QName  // the dimension QName from the "dimension" attribute
-	explicit   bool   // true for explicitMember, false for typedMember
-	member     QName  // explicit member QName (zero value if typed)
-	typedValue string // raw inner XML for typedMember (empty for explicit)
+	dimension  QName           // the dimension QName from the "dimension" attribute
+	explicit   bool            // true for explicitMember, false for typedMember
+	member     QName           // explicit member QName (zero value if typed)
+	memberText string          // explicit member text as written (empty if typed)
+	typedValue string          // raw inner XML for typedMember (empty for explicit)
+	source     DimensionSource // container the dimension was declared in
 }
 
 // Dimension returns the QName of the dimension (the @dimension attribute).
@@ -126,6 +183,14 @@ func (d Dimension) Member() QName {
 	return d.member
 }
 
+// MemberText returns the text content of an explicitMember as it
+// appeared in the instance, e.g. "ex:Japan". It is trimmed unless
+// ParseOptions.RawIdentifiers was set. For typed dimensions it returns
+// an empty string.
+func (d Dimension) MemberText() string {
+	return d.memberText
+}
+
 // TypedValue returns the raw inner XML for a typed dimension.
 //
 // For explicit dimensions this returns an empty string.
@@ -133,6 +198,12 @@ func (d Dimension) TypedValue() string {
 	return d.typedValue
 }
 
+// Source reports whether the dimension was declared in the entity's
+// <segment> or in the context's <scenario>.
+func (d Dimension) Source() DimensionSource {
+	return d.source
+}
+
 // SchemaRefs returns a copy of the schema references in the document.
 func (d *Document) SchemaRefs() []SchemaRef {
 	if d == nil {
@@ -173,6 +244,107 @@ func (d *Document) Facts() []*Fact {
 	return out
 }
 
+// RootAttributes returns a copy of the attributes of the root <xbrl>
+// element, including namespace declarations.
+func (d *Document) RootAttributes() []xml.Attr {
+	if d == nil {
+		return nil
+	}
+	out := make([]xml.Attr, len(d.rootAttrs))
+	copy(out, d.rootAttrs)
+	return out
+}
+
+// Namespaces returns the namespace declarations of the root <xbrl>
+// element as a map from prefix to namespace URI. The default namespace,
+// if declared, has the empty prefix.
+func (d *Document) Namespaces() map[string]string {
+	if d == nil {
+		return nil
+	}
+	out := make(map[string]string)
+	for _, a := range d.rootAttrs {
+		if prefix, ok := namespaceDecl(a); ok {
+			out[prefix] = a.Value
+		}
+	}
+	return out
+}
+
+// namespaceDecl reports whether a is a namespace declaration and returns
+// the declared prefix ("" for the default namespace).
+func namespaceDecl(a xml.Attr) (string, bool) {
+	switch {
+	case a.Name.Space == "xmlns":
+		return a.Name.Local, true
+	case a.Name.Space == "" && a.Name.Local == "xmlns":
+		return "", true
+	}
+	return "", false
+}
+
+// SchemaLocations returns the namespace/location pairs of the
+// xsi:schemaLocation attribute of the root <xbrl> element, as a map from
+// namespace URI to schema location. A trailing namespace without a
+// location is ignored. It returns an empty map if the attribute is absent.
+func (d *Document) SchemaLocations() map[string]string {
+	if d == nil {
+		return nil
+	}
+	out := make(map[string]string)
+	for _, a := range d.rootAttrs {
+		if a.Name.Space != nsXSI || a.Name.Local != "schemaLocation" {
+			continue
+		}
+		fields := strings.Fields(a.Value)
+		for i := 0; i+1 < len(fields); i += 2 {
+			out[fields[i]] = fields[i+1]
+		}
+	}
+	return out
+}
+
+// taxonomyHrefs returns the schemas to load for the document's taxonomy:
+// the schemaRef hrefs or, if the document has none, the
+// xsi:schemaLocation locations of namespaces other than those of the
+// XBRL specifications, ordered by namespace.
+func (d *Document) taxonomyHrefs() []string {
+	var hrefs []string
+	for _, sr := range d.schemaRefs {
+		hrefs = append(hrefs, sr.href)
+	}
+	if len(hrefs) > 0 {
+		return hrefs
+	}
+
+	locs := d.SchemaLocations()
+	for _, ns := range slices.Sorted(maps.Keys(locs)) {
+		switch ns {
+		case nsXBRLI, nsLink, nsXLink, nsXBRLDI, nsISO4217:
+			continue
+		}
+		hrefs = append(hrefs, locs[ns])
+	}
+	return hrefs
+}
+
+// RootAttr returns the value of the first root element attribute with the
+// given local name. Namespace declarations (xmlns:*) are not considered.
+func (d *Document) RootAttr(local string) (string, bool) {
+	if d == nil {
+		return "", false
+	}
+	for _, a := range d.rootAttrs {
+		if a.Name.Space == "xmlns" {
+			continue
+		}
+		if a.Name.Local == local {
+			return a.Value, true
+		}
+	}
+	return "", false
+}
+
 // ContextByID returns the context with the given ID, if present.
 func (d *Document) ContextByID(id string) (*Context, bool) {
 	if d == nil {
@@ -207,6 +379,212 @@ func (d *Document) UnitOf(f *Fact) (*Unit, bool) {
 	return d.UnitByID(f.UnitRef())
 }
 
+// refreshHasDimensions recomputes the hasDimensions cache from the
+// document's contexts.
+func (d *Document) refreshHasDimensions() {
+	d.hasDimensions = false
+	for _, c := range d.contexts {
+		if c != nil && len(c.dimensions) > 0 {
+			d.hasDimensions = true
+			return
+		}
+	}
+}
+
+// FactsForEntity returns the facts whose context entity identifier has
+// the given scheme and value, in document order.
+//
+// Matching contexts are resolved once up front, so this is cheaper than
+// an equivalent FactFilter for large documents.
+func (d *Document) FactsForEntity(scheme, value string) []*Fact {
+	if d == nil {
+		return nil
+	}
+
+	ids := make(map[string]struct{})
+	for id, c := range d.contexts {
+		if c.EntityEquals(scheme, value) {
+			ids[id] = struct{}{}
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var out []*Fact
+	for _, f := range d.facts {
+		if f == nil {
+			continue
+		}
+		if _, ok := ids[f.contextRef]; ok {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// SiblingFacts returns the other facts that share f's contextRef, in
+// document order. f itself is not included. It returns nil if f is nil or
+// has no contextRef.
+func (d *Document) SiblingFacts(f *Fact) []*Fact {
+	if d == nil || f == nil || f.contextRef == "" {
+		return nil
+	}
+	var out []*Fact
+	for _, g := range d.facts {
+		if g == nil || g == f {
+			continue
+		}
+		if g.contextRef == f.contextRef {
+			out = append(out, g)
+		}
+	}
+	return out
+}
+
+// FactsAsOf returns the facts whose context period has the given
+// AlignmentKey, in document order: instants on date and durations ending
+// on date. The date is compared as-is with the period values.
+func (d *Document) FactsAsOf(date string) []*Fact {
+	if d == nil || date == "" {
+		return nil
+	}
+
+	ids := make(map[string]struct{})
+	for id, c := range d.contexts {
+		if c != nil && c.period.AlignmentKey() == date {
+			ids[id] = struct{}{}
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var out []*Fact
+	for _, f := range d.facts {
+		if f == nil {
+			continue
+		}
+		if _, ok := ids[f.contextRef]; ok {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// Matrix arranges fact values in a grid for rendering statement tables:
+// cell [i][j] is the value of concepts[i] in a context whose period has
+// the AlignmentKey periodKeys[j], or "" if there is no such fact.
+// Concepts are compared by namespace URI and local name.
+//
+// If several facts fall in the same cell, a fact in a context without
+// dimensions is preferred, and otherwise the first in document order is
+// used. xsi:nil facts are ignored.
+func (d *Document) Matrix(concepts []QName, periodKeys []string) [][]string {
+	if d == nil {
+		return nil
+	}
+
+	wanted := make(map[conceptKey]bool, len(concepts))
+	for _, q := range concepts {
+		wanted[conceptKey{q.uri, q.local}] = true
+	}
+
+	// best holds the fact chosen for each concept and period key.
+	type cell struct {
+		fact        *Fact
+		dimensioned bool
+	}
+	best := make(map[conceptKey]map[string]cell)
+	for _, f := range d.facts {
+		if f == nil || f.nil {
+			continue
+		}
+		k := conceptKey{f.name.uri, f.name.local}
+		c := d.contexts[f.contextRef]
+		if !wanted[k] || c == nil {
+			continue
+		}
+		key := c.period.AlignmentKey()
+		if key == "" {
+			continue
+		}
+		if best[k] == nil {
+			best[k] = make(map[string]cell)
+		}
+		dimensioned := len(c.dimensions) > 0
+		if prev, ok := best[k][key]; !ok || (prev.dimensioned && !dimensioned) {
+			best[k][key] = cell{f, dimensioned}
+		}
+	}
+
+	out := make([][]string, len(concepts))
+	for i, q := range concepts {
+		out[i] = make([]string, len(periodKeys))
+		for j, key := range periodKeys {
+			if c, ok := best[conceptKey{q.uri, q.local}][key]; ok {
+				out[i][j] = c.fact.value
+			}
+		}
+	}
+	return out
+}
+
+// FactsByFiscalYear groups the facts by fiscal year, given the month
+// (1-12) in which the fiscal year ends. A fiscal year is named after the
+// calendar year it ends in: with fyEndMonth 3, periods ending on
+// 2025-03-31 belong to 2025 and periods ending on 2025-04-30 to 2026.
+//
+// The date used is the instant of an instant period or the end date of a
+// duration. Facts whose context is missing, forever, or has an
+// unparseable date are grouped under year 0. Within a year facts are in
+// document order. It returns nil if fyEndMonth is out of range.
+func (d *Document) FactsByFiscalYear(fyEndMonth int) map[int][]*Fact {
+	if d == nil || fyEndMonth < 1 || fyEndMonth > 12 {
+		return nil
+	}
+
+	out := make(map[int][]*Fact)
+	for _, f := range d.facts {
+		if f == nil {
+			continue
+		}
+		year := 0
+		if c := d.contexts[f.contextRef]; c != nil {
+			t, ok := c.period.InstantTime()
+			if !ok {
+				t, ok = c.period.EndTime()
+			}
+			if ok {
+				year = t.Year()
+				if int(t.Month()) > fyEndMonth {
+					year++
+				}
+			}
+		}
+		out[year] = append(out[year], f)
+	}
+	return out
+}
+
+// FactsByUnit groups the facts by unitRef. Facts without a unitRef, such
+// as string and boolean facts, are grouped under the empty key. Within a
+// unit facts are in document order.
+func (d *Document) FactsByUnit() map[string][]*Fact {
+	if d == nil {
+		return nil
+	}
+
+	out := make(map[string][]*Fact)
+	for _, f := range d.facts {
+		if f == nil {
+			continue
+		}
+		out[f.unitRef] = append(out[f.unitRef], f)
+	}
+	return out
+}
+
 // Href returns the href of the schema reference.
 func (s SchemaRef) Href() string {
 	return s.href
@@ -261,6 +639,32 @@ func (c *Context) DimensionByQName(dim QName) (Dimension, bool) {
 	return Dimension{}, false
 }
 
+// HasDimension reports whether the context has a dimension whose QName
+// (URI+local) matches dim. Prefix is ignored for comparison.
+func (c *Context) HasDimension(dim QName) bool {
+	_, ok := c.DimensionByQName(dim)
+	return ok
+}
+
+// DimensionCount returns the number of dimensions of the context, from
+// both its segment and its scenario.
+func (c *Context) DimensionCount() int {
+	if c == nil {
+		return 0
+	}
+	return len(c.dimensions)
+}
+
+// EntityEquals reports whether the context's entity identifier has the
+// given scheme and value. Both are compared exactly.
+func (c *Context) EntityEquals(scheme, value string) bool {
+	if c == nil {
+		return false
+	}
+	id := c.entity.identifier
+	return id.scheme == scheme && id.value == value
+}
+
 // Identifier returns the identifier of the entity.
 func (e Entity) Identifier() ContextIdentifier {
 	return e.identifier
@@ -300,6 +704,32 @@ func (p Period) EndDate() (string, bool) {
 	return *p.endDate, true
 }
 
+// InstantTime parses the instant of the period as an xsd:date or
+// xsd:dateTime. Values without a time zone are interpreted as UTC, and a
+// date without a time is returned as midnight at the start of that day
+// (XBRL 2.1 reads a date-only instant as the end of the day). It returns
+// false if the period has no instant or the value cannot be parsed.
+func (p Period) InstantTime() (time.Time, bool) {
+	return periodTime(p.instant)
+}
+
+// StartTime parses the start date of a duration period like InstantTime.
+func (p Period) StartTime() (time.Time, bool) {
+	return periodTime(p.startDate)
+}
+
+// EndTime parses the end date of a duration period like InstantTime.
+func (p Period) EndTime() (time.Time, bool) {
+	return periodTime(p.endDate)
+}
+
+func periodTime(v *string) (time.Time, bool) {
+	if v == nil {
+		return time.Time{}, false
+	}
+	return parseXSDDate(strings.TrimSpace(*v))
+}
+
 // IsInstant reports whether the period represents an instant.
 func (p Period) IsInstant() bool {
 	return p.instant != nil && p.startDate == nil && p.endDate == nil && !p.forever
@@ -310,6 +740,23 @@ func (p Period) IsForever() bool {
 	return p.forever
 }
 
+// AlignmentKey returns the "as of" date of the period: the instant for
+// instant periods and the end date for durations. It lets a balance
+// sheet instant be matched with a duration ending on the same day.
+// Forever periods, and durations without an end date, return "".
+func (p Period) AlignmentKey() string {
+	switch {
+	case p.forever:
+		return ""
+	case p.instant != nil:
+		return *p.instant
+	case p.endDate != nil:
+		return *p.endDate
+	default:
+		return ""
+	}
+}
+
 // ID returns the unit ID.
 func (u *Unit) ID() string {
 	if u == nil {
@@ -359,6 +806,73 @@ func (u *Unit) DenominatorMeasures() []QName {
 	return out
 }
 
+// AllMeasures returns all measures involved in the unit: the simple
+// measures, or the numerator followed by the denominator measures for a
+// divide unit. The returned slice is a copy.
+func (u *Unit) AllMeasures() []QName {
+	if u == nil {
+		return nil
+	}
+	if u.divide {
+		return slices.Concat(u.numerator, u.denominator)
+	}
+	return u.Measures()
+}
+
+// CurrencyCode returns the ISO 4217 currency code of a simple unit with a
+// single iso4217 measure (e.g. "JPY" for iso4217:JPY).
+//
+// A measure is treated as a currency if its namespace is the XBRL iso4217
+// namespace, or if it is unresolved but uses the conventional "iso4217"
+// prefix. Divide units and units with several measures are not currencies.
+func (u *Unit) CurrencyCode() (string, bool) {
+	if u == nil || u.divide || len(u.measures) != 1 {
+		return "", false
+	}
+	m := u.measures[0]
+	if m.uri == nsISO4217 || (m.uri == "" && m.prefix == "iso4217") {
+		return m.local, true
+	}
+	return "", false
+}
+
+// String renders the unit expression using the local names of its
+// measures, e.g. "JPY", "m·m" or "JPY/(m·m)".
+//
+// Multiple measures are joined with "·". In a divide unit, a side with
+// more than one measure is parenthesized and an empty numerator is
+// rendered as "1". A nil unit renders as an empty string.
+func (u *Unit) String() string {
+	if u == nil {
+		return ""
+	}
+	if !u.divide {
+		return joinMeasures(u.measures)
+	}
+
+	num := joinMeasures(u.numerator)
+	switch {
+	case len(u.numerator) == 0:
+		num = "1"
+	case len(u.numerator) > 1:
+		num = "(" + num + ")"
+	}
+	den := joinMeasures(u.denominator)
+	if len(u.denominator) > 1 {
+		den = "(" + den + ")"
+	}
+	return num + "/" + den
+}
+
+// joinMeasures joins the local names of measures with "·".
+func joinMeasures(measures []QName) string {
+	names := make([]string, len(measures))
+	for i, m := range measures {
+		names[i] = m.local
+	}
+	return strings.Join(names, "·")
+}
+
 // Prefix returns the namespace prefix of the QName.
 func (q QName) Prefix() string {
 	return q.prefix
@@ -423,6 +937,19 @@ func (f *Fact) NormalizedValue() string {
 	return normalizeSpace(f.value)
 }
 
+// TrimmedValue returns the fact value with leading and trailing
+// whitespace removed.
+//
+// Unlike NormalizedValue, internal whitespace including newlines is
+// kept as-is, which preserves the paragraph structure of text block
+// facts.
+func (f *Fact) TrimmedValue() string {
+	if f == nil {
+		return ""
+	}
+	return strings.TrimSpace(f.value)
+}
+
 // ContextRef returns the ID of the context referenced by the fact.
 func (f *Fact) ContextRef() string {
 	if f == nil {
@@ -471,6 +998,90 @@ func (f *Fact) Lang() string {
 	return f.lang
 }
 
+// DocumentIndex returns the 0-based position of the fact in the order it
+// appeared in the instance document.
+//
+// The index is assigned at parse time and is kept when facts are filtered,
+// so subsets can be re-sorted into source order.
+func (f *Fact) DocumentIndex() int {
+	if f == nil {
+		return 0
+	}
+	return f.index
+}
+
+// SourceXML returns the raw outer XML of the fact exactly as it appeared
+// in the instance, from its start tag through its end tag. It is only
+// recorded when the document was parsed with ParseOptions.CaptureSource;
+// otherwise it returns "".
+func (f *Fact) SourceXML() string {
+	if f == nil {
+		return ""
+	}
+	return f.source
+}
+
+// CachedConcept returns the concept of the fact cached when a taxonomy
+// was attached to its document with Document.SetTaxonomy,
+// LoadTaxonomyFromSchemaRefs or ParseWithTaxonomy. It returns nil if no
+// taxonomy is attached or the taxonomy has no concept for the fact.
+//
+// The cache is invalidated when the concepts of the taxonomy change, e.g.
+// by Taxonomy.Merge, and CachedConcept then returns nil until the
+// taxonomy is attached again. Document.ConceptOf always returns the
+// current concept.
+func (f *Fact) CachedConcept() *Concept {
+	if f == nil {
+		return nil
+	}
+	c, _ := f.cachedConcept(f.conceptTax)
+	return c
+}
+
+// cachedConcept returns the cached concept of f if it was cached from t
+// and t has not changed since. ok is false if the cache cannot be used.
+func (f *Fact) cachedConcept(t *Taxonomy) (c *Concept, ok bool) {
+	if t == nil || f.conceptTax != t || f.conceptGen != t.gen {
+		return nil, false
+	}
+	return f.concept, true
+}
+
+// Context returns the context of the fact. It is only available when the
+// document was parsed with ParseOptions.ResolveReferences; otherwise, or
+// if the contextRef does not resolve, it returns nil. Use
+// Document.ContextOf to resolve it through the document instead.
+func (f *Fact) Context() *Context {
+	if f == nil {
+		return nil
+	}
+	return f.context
+}
+
+// Unit returns the unit of the fact. Like Context, it is only available
+// when the document was parsed with ParseOptions.ResolveReferences, and
+// returns nil for facts without a resolvable unitRef.
+func (f *Fact) Unit() *Unit {
+	if f == nil {
+		return nil
+	}
+	return f.unit
+}
+
+// resolveReferences attaches the context and unit of each fact to it.
+func (d *Document) resolveReferences() {
+	for _, f := range d.facts {
+		if f == nil {
+			continue
+		}
+		f.context = d.contexts[f.contextRef]
+		f.unit = nil
+		if f.unitRef != "" {
+			f.unit = d.units[f.unitRef]
+		}
+	}
+}
+
 // IsNil reports whether the fact is marked as xsi:nil="true".
 func (f *Fact) IsNil() bool {
 	if f == nil {
@@ -479,6 +1090,27 @@ func (f *Fact) IsNil() bool {
 	return f.nil
 }
 
+// Equal reports whether f and other describe the same fact: they have the
+// same kind, concept (compared by namespace URI and local name), value,
+// context and unit references, decimals, precision, language and nil
+// flag. The fact ID and the position in the document are ignored.
+//
+// Two nil facts are equal; a nil fact is not equal to a non-nil one.
+func (f *Fact) Equal(other *Fact) bool {
+	if f == nil || other == nil {
+		return f == other
+	}
+	return f.kind == other.kind &&
+		sameExpandedName(f.name, other.name) &&
+		f.value == other.value &&
+		f.contextRef == other.contextRef &&
+		f.unitRef == other.unitRef &&
+		f.decimals == other.decimals &&
+		f.precision == other.precision &&
+		f.lang == other.lang &&
+		f.nil == other.nil
+}
+
 // Concept represents a taxonomy concept (typically defined by xs:element
 // in an XBRL schema).
 type Concept struct {
@@ -488,6 +1120,7 @@ type Concept struct {
 
 	substitutionGroup QName
 	typeName          QName
+	anonymousType     bool // typeName is the restriction base of an inline type
 
 	abstract   bool
 	nillable   bool
@@ -520,14 +1153,38 @@ func (c *Concept) SubstitutionGroup() QName {
 	return c.substitutionGroup
 }
 
-// Type returns the @type of the concept.
+// Type returns the @type of the concept. It returns the zero QName for a
+// concept declared with an anonymous inline type; see EffectiveType.
 func (c *Concept) Type() QName {
+	if c == nil || c.anonymousType {
+		return QName{}
+	}
+	return c.typeName
+}
+
+// EffectiveType returns the type the concept's values are classified by.
+// This is the @type of the concept or, for a concept declared with an
+// anonymous inline xs:simpleType or xs:complexType, the base of its
+// xs:restriction.
+func (c *Concept) EffectiveType() QName {
 	if c == nil {
 		return QName{}
 	}
 	return c.typeName
 }
 
+// TypeName returns the @type of the concept for display, as "prefix:local"
+// when the prefix is known and as the local name otherwise.
+func (c *Concept) TypeName() string {
+	if c == nil || c.anonymousType {
+		return ""
+	}
+	if c.typeName.prefix == "" {
+		return c.typeName.local
+	}
+	return c.typeName.prefix + ":" + c.typeName.local
+}
+
 // Abstract reports whether the concept is abstract.
 func (c *Concept) Abstract() bool {
 	if c == nil {
@@ -579,6 +1236,15 @@ func (c *Concept) IsTuple() bool {
 // Taxonomy represents a collection of concepts from one or more schemas.
 type Taxonomy struct {
 	concepts map[QName]*Concept
+
+	// gen is incremented whenever concepts change, invalidating the
+	// concepts cached on facts.
+	gen uint64
+
+	linkbaseRefs  []LinkbaseRef
+	labels        map[conceptKey][]Label
+	references    map[conceptKey][]Reference
+	relationships []Relationship
 }
 
 // NewTaxonomy creates an empty taxonomy.
@@ -619,6 +1285,7 @@ func (t *Taxonomy) addConcept(c *Concept) {
 		t.concepts = make(map[QName]*Concept)
 	}
 	t.concepts[c.qname] = c
+	t.gen++
 }
 
 // Taxonomy returns the taxonomy attached to the document, if any.
@@ -629,18 +1296,50 @@ func (d *Document) Taxonomy() *Taxonomy {
 	return d.taxonomy
 }
 
-// SetTaxonomy attaches the given taxonomy to the document.
+// SetTaxonomy attaches the given taxonomy to the document, and caches
+// the concept of each fact (see Fact.CachedConcept). A taxonomy modified
+// after being attached should be attached again to refresh the cache;
+// until then concepts are looked up in the taxonomy.
 func (d *Document) SetTaxonomy(t *Taxonomy) {
 	if d == nil {
 		return
 	}
+	d.setTaxonomy(t)
+}
+
+// setTaxonomy attaches t and caches the concept of each fact, clearing
+// concepts cached from a previous taxonomy.
+func (d *Document) setTaxonomy(t *Taxonomy) {
 	d.taxonomy = t
+	for _, f := range d.facts {
+		if f == nil {
+			continue
+		}
+		f.concept, f.conceptTax, f.conceptGen = nil, nil, 0
+		if t != nil {
+			f.concept, f.conceptTax, f.conceptGen = t.concepts[f.name], t, t.gen
+		}
+	}
 }
 
 // LoadTaxonomyFromSchemaRefs builds a Taxonomy from this Document's
 // schemaRefs using the provided opener, and attaches it to the Document.
+//
+// If the document has no schemaRef, the schemas declared by the
+// xsi:schemaLocation attribute of the root element are loaded instead
+// (see SchemaLocations), except those of the XBRL specification
+// namespaces.
 func (d *Document) LoadTaxonomyFromSchemaRefs(
 	opener func(href string) (io.ReadCloser, error),
+) (*Taxonomy, error) {
+	return d.loadTaxonomyFromSchemaRefs(opener, nil)
+}
+
+// loadTaxonomyFromSchemaRefs is LoadTaxonomyFromSchemaRefs, loading only
+// the hrefs accepted by include (all of them if include is nil).
+func (d *Document) loadTaxonomyFromSchemaRefs(
+	opener func(href string) (io.ReadCloser, error),
+	include func(href string) bool,
 ) (*Taxonomy, error) {
 	if d == nil {
 		return nil, fmt.Errorf("xbrl: document is nil")
@@ -651,9 +1350,8 @@ func (d *Document) LoadTaxonomyFromSchemaRefs(
 
 	tax := NewTaxonomy()
 
-	for _, sr := range d.schemaRefs {
-		href := sr.Href()
-		if href == "" {
+	for _, href := range d.taxonomyHrefs() {
+		if href == "" || (include != nil && !include(href)) {
 			continue
 		}
 
@@ -668,18 +1366,37 @@ func (d *Document) LoadTaxonomyFromSchemaRefs(
 			return nil, fmt.Errorf("xbrl: parse schemaRef %q: %w", href, err)
 		}
 
+		// linkbaseRefs in the schema are relative to the schema itself.
+		for i := range t.linkbaseRefs {
+			t.linkbaseRefs[i].href = resolveHref(href, t.linkbaseRefs[i].href)
+		}
+
 		tax.Merge(t)
 	}
 
-	d.taxonomy = tax
+	d.setTaxonomy(tax)
 	return tax, nil
 }
 
 // ConceptOf returns the taxonomy concept corresponding to the fact's
-// QName, if a taxonomy is attached and the concept exists.
+// QName, if a taxonomy is attached and the concept exists. The concept
+// cached by SetTaxonomy is used while it is still valid.
 func (d *Document) ConceptOf(f *Fact) (*Concept, bool) {
 	if d == nil || f == nil || d.taxonomy == nil {
 		return nil, false
 	}
+	if c, ok := f.cachedConcept(d.taxonomy); ok {
+		return c, c != nil
+	}
 	return d.taxonomy.Concept(f.Name())
 }
+
+// MemberConcept returns the taxonomy concept of the member of an explicit
+// dimension, if a taxonomy is attached and the concept exists. Typed
+// dimensions have no member concept.
+func (d *Document) MemberConcept(dim Dimension) (*Concept, bool) {
+	if d == nil || d.taxonomy == nil || !dim.explicit {
+		return nil, false
+	}
+	return d.taxonomy.Concept(dim.member)
+}
@@ -0,0 +1,277 @@
+package xbrl
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+)
+
+// documentJSON is the form of a whole Document written by EncodeJSON.
+// Unlike the export DTOs, it keeps everything needed to rebuild the
+// schemaRefs, contexts, units and facts, including namespace prefixes.
+type documentJSON struct {
+	SchemaRefs []string         `json:"schemaRefs"`
+	Contexts   []docContextJSON `json:"contexts"`
+	Units      []docUnitJSON    `json:"units"`
+	Facts      []docFactJSON    `json:"facts"`
+}
+
+type qnameJSON struct {
+	Prefix string `json:"prefix,omitempty"`
+	URI    string `json:"uri,omitempty"`
+	Local  string `json:"local"`
+}
+
+type docContextJSON struct {
+	ID         string             `json:"id"`
+	Scheme     string             `json:"scheme"`
+	Identifier string             `json:"identifier"`
+	Instant    *string            `json:"instant,omitempty"`
+	StartDate  *string            `json:"startDate,omitempty"`
+	EndDate    *string            `json:"endDate,omitempty"`
+	Forever    bool               `json:"forever,omitempty"`
+	Dimensions []docDimensionJSON `json:"dimensions,omitempty"`
+}
+
+type docDimensionJSON struct {
+	Dimension  qnameJSON  `json:"dimension"`
+	Explicit   bool       `json:"explicit"`
+	Member     *qnameJSON `json:"member,omitempty"`
+	MemberText string     `json:"memberText,omitempty"`
+	TypedValue string     `json:"typedValue,omitempty"`
+	Source     string     `json:"source"`
+}
+
+type docUnitJSON struct {
+	ID          string      `json:"id"`
+	Measures    []qnameJSON `json:"measures,omitempty"`
+	Divide      bool        `json:"divide,omitempty"`
+	Numerator   []qnameJSON `json:"numerator,omitempty"`
+	Denominator []qnameJSON `json:"denominator,omitempty"`
+}
+
+type docFactJSON struct {
+	Kind       FactKind  `json:"kind"`
+	Name       qnameJSON `json:"name"`
+	Value      string    `json:"value"`
+	ContextRef string    `json:"contextRef,omitempty"`
+	UnitRef    string    `json:"unitRef,omitempty"`
+	Decimals   string    `json:"decimals,omitempty"`
+	Precision  string    `json:"precision,omitempty"`
+	ID         string    `json:"id,omitempty"`
+	Lang       string    `json:"lang,omitempty"`
+	Nil        bool      `json:"nil,omitempty"`
+}
+
+// EncodeJSON writes the whole Document to w as a single JSON object with
+// "schemaRefs", "contexts", "units" and "facts" arrays, so that a parsed
+// instance can be cached or transferred and read back with
+// DecodeDocumentJSON without parsing the XML again. Contexts and units
+// are ordered by ID and facts are in document order.
+//
+// Root attributes, linkbaseRefs, footnotes, warnings and the attached
+// taxonomy are not written.
+// - HTML escape is disabled
+// - If pretty is true, indented output is used
+func (d *Document) EncodeJSON(w io.Writer, pretty bool) error {
+	if d == nil {
+		return nil
+	}
+
+	out := documentJSON{
+		SchemaRefs: make([]string, 0, len(d.schemaRefs)),
+		Contexts:   make([]docContextJSON, 0, len(d.contexts)),
+		Units:      make([]docUnitJSON, 0, len(d.units)),
+		Facts:      make([]docFactJSON, 0, len(d.facts)),
+	}
+	for _, sr := range d.schemaRefs {
+		out.SchemaRefs = append(out.SchemaRefs, sr.href)
+	}
+	for _, id := range slices.Sorted(maps.Keys(d.contexts)) {
+		if c := d.contexts[id]; c != nil {
+			out.Contexts = append(out.Contexts, contextToJSON(c))
+		}
+	}
+	for _, id := range slices.Sorted(maps.Keys(d.units)) {
+		if u := d.units[id]; u != nil {
+			out.Units = append(out.Units, docUnitJSON{
+				ID:          u.id,
+				Measures:    qnamesToJSON(u.measures),
+				Divide:      u.divide,
+				Numerator:   qnamesToJSON(u.numerator),
+				Denominator: qnamesToJSON(u.denominator),
+			})
+		}
+	}
+	for _, f := range d.facts {
+		if f == nil {
+			continue
+		}
+		out.Facts = append(out.Facts, docFactJSON{
+			Kind:       f.kind,
+			Name:       qnameToJSON(f.name),
+			Value:      f.value,
+			ContextRef: f.contextRef,
+			UnitRef:    f.unitRef,
+			Decimals:   f.decimals,
+			Precision:  f.precision,
+			ID:         f.id,
+			Lang:       f.lang,
+			Nil:        f.nil,
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	if pretty {
+		enc.SetIndent("", "  ")
+	}
+	enc.SetEscapeHTML(false)
+
+	return enc.Encode(out)
+}
+
+// DecodeDocumentJSON reads a Document written by EncodeJSON from r.
+// Duplicate context or unit IDs are reported as errors.
+func DecodeDocumentJSON(r io.Reader) (*Document, error) {
+	var in documentJSON
+	if err := json.NewDecoder(r).Decode(&in); err != nil {
+		return nil, fmt.Errorf("xbrl: decode document JSON: %w", err)
+	}
+
+	doc := &Document{
+		contexts: make(map[string]*Context, len(in.Contexts)),
+		units:    make(map[string]*Unit, len(in.Units)),
+	}
+	for _, href := range in.SchemaRefs {
+		doc.schemaRefs = append(doc.schemaRefs, SchemaRef{href: href})
+	}
+	for _, cj := range in.Contexts {
+		if _, ok := doc.contexts[cj.ID]; ok {
+			return nil, fmt.Errorf("xbrl: decode document JSON: duplicate context ID %q", cj.ID)
+		}
+		doc.contexts[cj.ID] = contextFromJSON(cj)
+	}
+	for _, uj := range in.Units {
+		if _, ok := doc.units[uj.ID]; ok {
+			return nil, fmt.Errorf("xbrl: decode document JSON: duplicate unit ID %q", uj.ID)
+		}
+		doc.units[uj.ID] = &Unit{
+			id:          uj.ID,
+			measures:    qnamesFromJSON(uj.Measures),
+			divide:      uj.Divide,
+			numerator:   qnamesFromJSON(uj.Numerator),
+			denominator: qnamesFromJSON(uj.Denominator),
+		}
+	}
+	for i, fj := range in.Facts {
+		doc.facts = append(doc.facts, &Fact{
+			kind:       fj.Kind,
+			name:       qnameFromJSON(fj.Name),
+			value:      fj.Value,
+			contextRef: fj.ContextRef,
+			unitRef:    fj.UnitRef,
+			decimals:   fj.Decimals,
+			precision:  fj.Precision,
+			id:         fj.ID,
+			lang:       fj.Lang,
+			nil:        fj.Nil,
+			index:      i,
+		})
+	}
+	doc.refreshHasDimensions()
+	return doc, nil
+}
+
+func contextToJSON(c *Context) docContextJSON {
+	out := docContextJSON{
+		ID:         c.id,
+		Scheme:     c.entity.identifier.scheme,
+		Identifier: c.entity.identifier.value,
+		Instant:    c.period.instant,
+		StartDate:  c.period.startDate,
+		EndDate:    c.period.endDate,
+		Forever:    c.period.forever,
+	}
+	for _, dim := range c.dimensions {
+		dj := docDimensionJSON{
+			Dimension:  qnameToJSON(dim.dimension),
+			Explicit:   dim.explicit,
+			MemberText: dim.memberText,
+			TypedValue: dim.typedValue,
+			Source:     dim.source.String(),
+		}
+		if dim.explicit {
+			m := qnameToJSON(dim.member)
+			dj.Member = &m
+		}
+		out.Dimensions = append(out.Dimensions, dj)
+	}
+	return out
+}
+
+func contextFromJSON(cj docContextJSON) *Context {
+	c := &Context{
+		id: cj.ID,
+		entity: Entity{identifier: ContextIdentifier{
+			scheme: cj.Scheme,
+			value:  cj.Identifier,
+		}},
+		period: Period{
+			instant:   cj.Instant,
+			startDate: cj.StartDate,
+			endDate:   cj.EndDate,
+			forever:   cj.Forever,
+		},
+	}
+	for _, dj := range cj.Dimensions {
+		dim := Dimension{
+			dimension:  qnameFromJSON(dj.Dimension),
+			explicit:   dj.Explicit,
+			memberText: dj.MemberText,
+			typedValue: dj.TypedValue,
+		}
+		if dj.Member != nil {
+			dim.member = qnameFromJSON(*dj.Member)
+		}
+		switch dj.Source {
+		case DimensionSourceSegment.String():
+			dim.source = DimensionSourceSegment
+		case DimensionSourceScenario.String():
+			dim.source = DimensionSourceScenario
+		}
+		c.dimensions = append(c.dimensions, dim)
+	}
+	return c
+}
+
+func qnameToJSON(q QName) qnameJSON {
+	return qnameJSON{Prefix: q.prefix, URI: q.uri, Local: q.local}
+}
+
+func qnameFromJSON(q qnameJSON) QName {
+	return QName{prefix: q.Prefix, local: q.Local, uri: q.URI}
+}
+
+func qnamesToJSON(qs []QName) []qnameJSON {
+	if len(qs) == 0 {
+		return nil
+	}
+	out := make([]qnameJSON, len(qs))
+	for i, q := range qs {
+		out[i] = qnameToJSON(q)
+	}
+	return out
+}
+
+func qnamesFromJSON(qs []qnameJSON) []QName {
+	if len(qs) == 0 {
+		return nil
+	}
+	out := make([]QName, len(qs))
+	for i, q := range qs {
+		out[i] = qnameFromJSON(q)
+	}
+	return out
+}
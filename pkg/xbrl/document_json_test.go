@@ -0,0 +1,87 @@
+package xbrl_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/aethiopicuschan/xbrl-go/pkg/xbrl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocument_EncodeJSON_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, pretty := range []bool{false, true} {
+		orig, err := xbrl.Parse(strings.NewReader(extendedInstance))
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		require.NoError(t, orig.EncodeJSON(&buf, pretty))
+		assert.Equal(t, pretty, strings.Contains(buf.String(), "\n  "))
+
+		got, err := xbrl.DecodeDocumentJSON(&buf)
+		require.NoError(t, err)
+
+		assert.Equal(t, orig.SchemaRefs(), got.SchemaRefs())
+		assert.Len(t, got.Contexts(), 2)
+		assert.Equal(t, orig.Contexts(), got.Contexts())
+		assert.Len(t, got.Units(), 3)
+		assert.Equal(t, orig.Units(), got.Units())
+		require.Len(t, got.Facts(), 2)
+		assert.Equal(t, orig.Facts(), got.Facts())
+
+		revenue := got.Facts()[0]
+		assert.Equal(t, "ex", revenue.Name().Prefix())
+		assert.Equal(t, "12345", revenue.Value())
+		assert.Equal(t, "2", revenue.Precision())
+		assert.True(t, got.Facts()[1].IsNil())
+		assert.NotEmpty(t, got.DimensionPlacementReport(), "dimension index is rebuilt")
+	}
+}
+
+func TestDocument_EncodeJSON_Shape(t *testing.T) {
+	t.Parallel()
+
+	doc, err := xbrl.Parse(strings.NewReader(minimalInstance))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, doc.EncodeJSON(&buf, false))
+	for _, key := range []string{`"schemaRefs":[`, `"contexts":[`, `"units":[`, `"facts":[`} {
+		assert.Contains(t, buf.String(), key)
+	}
+
+	buf.Reset()
+	var nilDoc *xbrl.Document
+	require.NoError(t, nilDoc.EncodeJSON(&buf, false))
+	assert.Empty(t, buf.String())
+
+	empty, err := xbrl.DecodeDocumentJSON(strings.NewReader(`{}`))
+	require.NoError(t, err)
+	assert.Empty(t, empty.Contexts())
+	assert.Empty(t, empty.Facts())
+}
+
+func TestDecodeDocumentJSON_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		in      string
+		wantErr string
+	}{
+		{"malformed", `{"facts":`, "xbrl: decode document JSON"},
+		{"wrong type", `{"facts":{}}`, "xbrl: decode document JSON"},
+		{"duplicate context", `{"contexts":[{"id":"C1"},{"id":"C1"}]}`, `duplicate context ID "C1"`},
+		{"duplicate unit", `{"units":[{"id":"U1"},{"id":"U1"}]}`, `duplicate unit ID "U1"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := xbrl.DecodeDocumentJSON(strings.NewReader(tt.in))
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -5,9 +5,11 @@ import (
 	"io"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/aethiopicuschan/xbrl-go/pkg/xbrl"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSchemaRef_Href(t *testing.T) {
@@ -147,6 +149,7 @@ func TestDimension_Methods(t *testing.T) {
 		explicit   bool
 		memberWant xbrl.QName
 		typedWant  string
+		sourceWant xbrl.DimensionSource
 	}{
 		{
 			name:       "explicit dimension",
@@ -155,6 +158,7 @@ func TestDimension_Methods(t *testing.T) {
 			explicit:   true,
 			memberWant: member,
 			typedWant:  "",
+			sourceWant: xbrl.DimensionSourceUnknown,
 		},
 		{
 			name:       "typed dimension",
@@ -163,6 +167,16 @@ func TestDimension_Methods(t *testing.T) {
 			explicit:   false,
 			memberWant: xbrl.NewQNameForTest("", "", ""),
 			typedWant:  "<typed/>",
+			sourceWant: xbrl.DimensionSourceUnknown,
+		},
+		{
+			name:       "explicit dimension with source",
+			d:          xbrl.NewDimensionWithSourceForTest(q, true, member, "", xbrl.DimensionSourceScenario),
+			dimWant:    q,
+			explicit:   true,
+			memberWant: member,
+			typedWant:  "",
+			sourceWant: xbrl.DimensionSourceScenario,
 		},
 	}
 
@@ -174,6 +188,28 @@ func TestDimension_Methods(t *testing.T) {
 			assert.Equal(t, tt.explicit, tt.d.IsExplicit())
 			assert.Equal(t, tt.memberWant, tt.d.Member())
 			assert.Equal(t, tt.typedWant, tt.d.TypedValue())
+			assert.Equal(t, tt.sourceWant, tt.d.Source())
+		})
+	}
+}
+
+func TestDimensionSource_String(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		src  xbrl.DimensionSource
+		want string
+	}{
+		{"Unknown", xbrl.DimensionSourceUnknown, "unknown"},
+		{"Segment", xbrl.DimensionSourceSegment, "segment"},
+		{"Scenario", xbrl.DimensionSourceScenario, "scenario"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.src.String())
 		})
 	}
 }
@@ -287,6 +323,60 @@ func TestPeriod_Methods(t *testing.T) {
 	}
 }
 
+func TestPeriod_Times(t *testing.T) {
+	t.Parallel()
+
+	const src = `<xbrli:xbrl xmlns:xbrli="http://www.xbrl.org/2003/instance">
+  <xbrli:context id="D">
+    <xbrli:entity><xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier></xbrli:entity>
+    <xbrli:period>
+      <xbrli:startDate>2025-01-01T00:00:00</xbrli:startDate>
+      <xbrli:endDate>2025-12-31</xbrli:endDate>
+    </xbrli:period>
+  </xbrli:context>
+</xbrli:xbrl>`
+	doc, err := xbrl.Parse(strings.NewReader(src))
+	require.NoError(t, err)
+	ctx, ok := doc.ContextByID("D")
+	require.True(t, ok)
+
+	start, ok := ctx.Period().StartTime()
+	require.True(t, ok, "dateTime startDate")
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), start)
+	end, ok := ctx.Period().EndTime()
+	require.True(t, ok, "date endDate")
+	assert.Equal(t, time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC), end)
+	_, ok = ctx.Period().InstantTime()
+	assert.False(t, ok, "no instant")
+
+	date := func(s string) *string { return &s }
+	jst := time.FixedZone("", 9*60*60)
+
+	tests := []struct {
+		name   string
+		value  string
+		want   time.Time
+		wantOK bool
+	}{
+		{name: "date", value: "2025-03-31", want: time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC), wantOK: true},
+		{name: "date with zone", value: "2025-03-31+09:00", want: time.Date(2025, 3, 31, 0, 0, 0, 0, jst), wantOK: true},
+		{name: "dateTime", value: "2025-03-31T12:30:00", want: time.Date(2025, 3, 31, 12, 30, 0, 0, time.UTC), wantOK: true},
+		{name: "dateTime with zone", value: "2025-03-31T12:30:00Z", want: time.Date(2025, 3, 31, 12, 30, 0, 0, time.UTC), wantOK: true},
+		{name: "surrounding whitespace", value: " 2025-03-31 ", want: time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC), wantOK: true},
+		{name: "invalid", value: "2025/03/31", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := xbrl.NewPeriodForTest(date(tt.value), nil, nil, false).InstantTime()
+			assert.Equal(t, tt.wantOK, ok)
+			assert.True(t, tt.want.Equal(got), "got %v, want %v", got, tt.want)
+		})
+	}
+}
+
 func TestUnit_Methods(t *testing.T) {
 	t.Parallel()
 
@@ -343,6 +433,22 @@ func TestUnit_Methods(t *testing.T) {
 		assert.Equal(t, m2, againDen[0])
 	})
 
+	t.Run("AllMeasures", func(t *testing.T) {
+		t.Parallel()
+
+		jpy := xbrl.NewQNameForTest("iso4217", "JPY", "http://www.xbrl.org/2003/iso4217")
+		usd := xbrl.NewQNameForTest("iso4217", "USD", "http://www.xbrl.org/2003/iso4217")
+		rate := xbrl.NewUnitDivideForTest("JPYPerUSD", []xbrl.QName{jpy}, []xbrl.QName{usd})
+
+		assert.Equal(t, []xbrl.QName{jpy, usd}, rate.AllMeasures())
+		assert.Equal(t, []xbrl.QName{m1, m2}, unitSimple.AllMeasures())
+		assert.Nil(t, nilUnit.AllMeasures())
+
+		got := rate.AllMeasures()
+		got[0] = xbrl.QName{}
+		assert.Equal(t, []xbrl.QName{jpy}, rate.NumeratorMeasures())
+	})
+
 	t.Run("Nil unit collections", func(t *testing.T) {
 		t.Parallel()
 
@@ -352,6 +458,44 @@ func TestUnit_Methods(t *testing.T) {
 	})
 }
 
+func TestUnit_String(t *testing.T) {
+	t.Parallel()
+
+	jpy := xbrl.NewQNameForTest("iso4217", "JPY", "http://www.xbrl.org/2003/iso4217")
+	m := xbrl.NewQNameForTest("utr", "m", "http://www.xbrl.org/2009/utr")
+	s := xbrl.NewQNameForTest("utr", "s", "http://www.xbrl.org/2009/utr")
+	kg := xbrl.NewQNameForTest("utr", "kg", "http://www.xbrl.org/2009/utr")
+
+	tests := []struct {
+		name string
+		unit *xbrl.Unit
+		want string
+	}{
+		{name: "nil unit", unit: nil, want: ""},
+		{name: "single measure", unit: xbrl.NewUnitSimpleForTest("U", []xbrl.QName{jpy}), want: "JPY"},
+		{name: "simple multi-measure", unit: xbrl.NewUnitSimpleForTest("U", []xbrl.QName{m, m}), want: "m·m"},
+		{name: "divide", unit: xbrl.NewUnitDivideForTest("U", []xbrl.QName{jpy}, []xbrl.QName{m}), want: "JPY/m"},
+		{
+			name: "two-measure denominator",
+			unit: xbrl.NewUnitDivideForTest("U", []xbrl.QName{jpy}, []xbrl.QName{m, m}),
+			want: "JPY/(m·m)",
+		},
+		{
+			name: "two-measure numerator and denominator",
+			unit: xbrl.NewUnitDivideForTest("U", []xbrl.QName{kg, m}, []xbrl.QName{s, s}),
+			want: "(kg·m)/(s·s)",
+		},
+		{name: "empty numerator", unit: xbrl.NewUnitDivideForTest("U", nil, []xbrl.QName{s}), want: "1/s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.unit.String())
+		})
+	}
+}
+
 func TestQName_MethodsAndString(t *testing.T) {
 	t.Parallel()
 
@@ -452,6 +596,13 @@ func TestFact_Methods(t *testing.T) {
 		assert.Equal(t, "", nilFact.NormalizedValue())
 	})
 
+	t.Run("TrimmedValue", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, "foo\tbar\nbaz", f.TrimmedValue())
+		assert.Equal(t, "", nilFact.TrimmedValue())
+	})
+
 	t.Run("References and attributes", func(t *testing.T) {
 		t.Parallel()
 
@@ -533,6 +684,50 @@ func TestConcept_MethodsAndKinds(t *testing.T) {
 	})
 }
 
+func TestConcept_TypeName(t *testing.T) {
+	t.Parallel()
+
+	q := xbrl.NewQNameForTest("ex", "Revenue", "http://example.com")
+
+	tests := []struct {
+		name    string
+		concept *xbrl.Concept
+		want    string
+	}{
+		{
+			name:    "nil concept",
+			concept: nil,
+			want:    "",
+		},
+		{
+			name: "prefixed type",
+			concept: xbrl.NewConceptForTest(q, "", xbrl.QName{},
+				xbrl.NewQNameForTest("xbrli", "monetaryItemType", "http://www.xbrl.org/2003/instance"),
+				false, false, "", ""),
+			want: "xbrli:monetaryItemType",
+		},
+		{
+			name: "unprefixed type",
+			concept: xbrl.NewConceptForTest(q, "", xbrl.QName{},
+				xbrl.NewQNameForTest("", "customType", "http://example.com"),
+				false, false, "", ""),
+			want: "customType",
+		},
+		{
+			name:    "no type",
+			concept: xbrl.NewConceptForTest(q, "", xbrl.QName{}, xbrl.QName{}, false, false, "", ""),
+			want:    "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.concept.TypeName())
+		})
+	}
+}
+
 func TestTaxonomy_Methods(t *testing.T) {
 	t.Parallel()
 
@@ -829,6 +1024,126 @@ func TestDocument_TaxonomyAndConceptOf(t *testing.T) {
 	})
 }
 
+func TestDocument_MemberConcept(t *testing.T) {
+	t.Parallel()
+
+	emptyQName := xbrl.NewQNameForTest("", "", "")
+	axis := xbrl.NewQNameForTest("ex", "SegmentAxis", "http://example.com/xbrl")
+	member := xbrl.NewQNameForTest("ex", "RetailMember", "http://example.com/xbrl")
+	memberConcept := xbrl.NewConceptForTest(member, "ex_RetailMember", emptyQName, emptyQName, true, false, "duration", "")
+	tax := xbrl.NewTaxonomyForTest(map[xbrl.QName]*xbrl.Concept{member: memberConcept})
+	doc := xbrl.NewDocumentForTest(nil, nil, nil, nil, tax)
+
+	tests := []struct {
+		name   string
+		doc    *xbrl.Document
+		dim    xbrl.Dimension
+		want   *xbrl.Concept
+		wantOK bool
+	}{
+		{
+			name:   "explicit member",
+			doc:    doc,
+			dim:    xbrl.NewDimensionForTest(axis, true, member, ""),
+			want:   memberConcept,
+			wantOK: true,
+		},
+		{
+			name: "member not in taxonomy",
+			doc:  doc,
+			dim:  xbrl.NewDimensionForTest(axis, true, xbrl.NewQNameForTest("ex", "OtherMember", "http://example.com/xbrl"), ""),
+		},
+		{
+			name: "typed dimension",
+			doc:  doc,
+			dim:  xbrl.NewDimensionForTest(axis, false, emptyQName, "<ex:id>1</ex:id>"),
+		},
+		{
+			name: "no taxonomy",
+			doc:  new(xbrl.Document),
+			dim:  xbrl.NewDimensionForTest(axis, true, member, ""),
+		},
+		{
+			name: "nil document",
+			doc:  nil,
+			dim:  xbrl.NewDimensionForTest(axis, true, member, ""),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := tt.doc.MemberConcept(tt.dim)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDocument_SchemaLocations(t *testing.T) {
+	t.Parallel()
+
+	const src = `<xbrli:xbrl xmlns:xbrli="http://www.xbrl.org/2003/instance"
+    xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"
+    xsi:schemaLocation="http://www.xbrl.org/2003/instance http://www.xbrl.org/2003/xbrl-instance-2003-12-31.xsd
+      http://example.com/xbrl   ex-2025.xsd
+      http://example.com/dangling">
+</xbrli:xbrl>`
+
+	doc, err := xbrl.Parse(strings.NewReader(src))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"http://www.xbrl.org/2003/instance": "http://www.xbrl.org/2003/xbrl-instance-2003-12-31.xsd",
+		"http://example.com/xbrl":           "ex-2025.xsd",
+	}, doc.SchemaLocations())
+
+	t.Run("taxonomy loader falls back to schemaLocation", func(t *testing.T) {
+		t.Parallel()
+
+		doc, err := xbrl.Parse(strings.NewReader(src))
+		require.NoError(t, err)
+
+		var opened []string
+		tax, err := doc.LoadTaxonomyFromSchemaRefs(func(href string) (io.ReadCloser, error) {
+			opened = append(opened, href)
+			return io.NopCloser(strings.NewReader(`<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema"
+    xmlns:xbrli="http://www.xbrl.org/2003/instance" targetNamespace="http://example.com/xbrl">
+  <xs:element id="ex_Revenue" name="Revenue" type="xbrli:monetaryItemType" substitutionGroup="xbrli:item"/>
+</xs:schema>`)), nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ex-2025.xsd"}, opened, "specification schemas are not loaded")
+		assert.Len(t, tax.Concepts(), 1)
+	})
+
+	t.Run("schemaRef takes precedence", func(t *testing.T) {
+		t.Parallel()
+
+		withRef := strings.Replace(src, "\n</xbrli:xbrl>",
+			`<link:schemaRef xmlns:link="http://www.xbrl.org/2003/linkbase" xmlns:xlink="http://www.w3.org/1999/xlink" xlink:type="simple" xlink:href="ref.xsd"/></xbrli:xbrl>`, 1)
+		doc, err := xbrl.Parse(strings.NewReader(withRef))
+		require.NoError(t, err)
+
+		var opened []string
+		_, err = doc.LoadTaxonomyFromSchemaRefs(func(href string) (io.ReadCloser, error) {
+			opened = append(opened, href)
+			return io.NopCloser(strings.NewReader("")), nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ref.xsd"}, opened)
+	})
+
+	t.Run("no attribute and nil document", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Empty(t, new(xbrl.Document).SchemaLocations())
+
+		var nilDoc *xbrl.Document
+		assert.Nil(t, nilDoc.SchemaLocations())
+	})
+}
+
 func TestDocument_LoadTaxonomyFromSchemaRefs_ErrorsAndBasics(t *testing.T) {
 	t.Parallel()
 
@@ -906,3 +1221,479 @@ func TestDocument_LoadTaxonomyFromSchemaRefs_ErrorsAndBasics(t *testing.T) {
 		assert.Same(t, tax, docOK.Taxonomy())
 	})
 }
+
+func TestFact_TrimmedValue(t *testing.T) {
+	t.Parallel()
+
+	name := xbrl.NewQNameForTest("ex", "Notes", "http://example.com")
+
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{
+			name:  "internal newlines are preserved",
+			value: "\n\n  First paragraph.\n\n  Second paragraph.\n  ",
+			want:  "First paragraph.\n\n  Second paragraph.",
+		},
+		{
+			name:  "space-like runes around the value are removed",
+			value: "\u3000\u00A0text\u00A0",
+			want:  "text",
+		},
+		{
+			name:  "whitespace only",
+			value: " \r\n\t ",
+			want:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			f := xbrl.NewFactForTest(xbrl.FactKindItem, name, tt.value, "C1", "", "", "", "", "", false)
+			assert.Equal(t, tt.want, f.TrimmedValue())
+		})
+	}
+}
+
+func TestFact_Equal(t *testing.T) {
+	t.Parallel()
+
+	name := xbrl.NewQNameForTest("ex", "Revenue", "http://example.com")
+	fact := func(mutate func(*factArgs)) *xbrl.Fact {
+		a := factArgs{kind: xbrl.FactKindItem, name: name, value: "100", contextRef: "C1", unitRef: "JPY", decimals: "0", id: "f1", lang: "ja"}
+		if mutate != nil {
+			mutate(&a)
+		}
+		return xbrl.NewFactForTest(a.kind, a.name, a.value, a.contextRef, a.unitRef, a.decimals, a.precision, a.id, a.lang, a.isNil)
+	}
+	base := fact(nil)
+
+	tests := []struct {
+		name  string
+		a, b  *xbrl.Fact
+		equal bool
+	}{
+		{name: "same fields", a: base, b: fact(nil), equal: true},
+		{name: "different id", a: base, b: fact(func(a *factArgs) { a.id = "f2" }), equal: true},
+		{name: "different prefix", a: base, b: fact(func(a *factArgs) { a.name = xbrl.NewQNameForTest("other", "Revenue", "http://example.com") }), equal: true},
+		{name: "different namespace", a: base, b: fact(func(a *factArgs) { a.name = xbrl.NewQNameForTest("ex", "Revenue", "http://example.org") }), equal: false},
+		{name: "different value", a: base, b: fact(func(a *factArgs) { a.value = "200" }), equal: false},
+		{name: "different context", a: base, b: fact(func(a *factArgs) { a.contextRef = "C2" }), equal: false},
+		{name: "different unit", a: base, b: fact(func(a *factArgs) { a.unitRef = "USD" }), equal: false},
+		{name: "different decimals", a: base, b: fact(func(a *factArgs) { a.decimals = "-3" }), equal: false},
+		{name: "different precision", a: base, b: fact(func(a *factArgs) { a.precision = "4" }), equal: false},
+		{name: "different lang", a: base, b: fact(func(a *factArgs) { a.lang = "en" }), equal: false},
+		{name: "different nil", a: base, b: fact(func(a *factArgs) { a.isNil = true }), equal: false},
+		{name: "different kind", a: base, b: fact(func(a *factArgs) { a.kind = xbrl.FactKindUnknown }), equal: false},
+		{name: "both nil", a: nil, b: nil, equal: true},
+		{name: "nil and non-nil", a: nil, b: base, equal: false},
+		{name: "non-nil and nil", a: base, b: nil, equal: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.equal, tt.a.Equal(tt.b))
+		})
+	}
+}
+
+// factArgs holds the arguments of xbrl.NewFactForTest.
+type factArgs struct {
+	kind                                                      xbrl.FactKind
+	name                                                      xbrl.QName
+	value, contextRef, unitRef, decimals, precision, id, lang string
+	isNil                                                     bool
+}
+
+func TestContext_EntityEquals(t *testing.T) {
+	t.Parallel()
+
+	ctx := xbrl.NewContextForTest(
+		"C1",
+		xbrl.NewEntityForTest(xbrl.NewContextIdentifierForTest("http://example.com/entity", "ABC")),
+		xbrl.Period{},
+		nil,
+	)
+
+	tests := []struct {
+		name   string
+		ctx    *xbrl.Context
+		scheme string
+		value  string
+		want   bool
+	}{
+		{name: "nil context", ctx: nil, scheme: "http://example.com/entity", value: "ABC", want: false},
+		{name: "match", ctx: ctx, scheme: "http://example.com/entity", value: "ABC", want: true},
+		{name: "different value", ctx: ctx, scheme: "http://example.com/entity", value: "XYZ", want: false},
+		{name: "different scheme", ctx: ctx, scheme: "http://other.example.com", value: "ABC", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.ctx.EntityEquals(tt.scheme, tt.value))
+		})
+	}
+}
+
+func TestDocument_FactsForEntity(t *testing.T) {
+	t.Parallel()
+
+	// Add facts for the XYZ entity (context C2) to the extended fixture.
+	src := strings.Replace(extendedInstance, `<ex:NilFact`, `<ex:Assets contextRef="C2" unitRef="U1">100</ex:Assets>
+  <ex:Liabilities contextRef="C2" unitRef="U1">40</ex:Liabilities>
+  <ex:NilFact`, 1)
+	doc, err := xbrl.Parse(strings.NewReader(src))
+	require.NoError(t, err)
+
+	const scheme = "http://example.com/entity"
+
+	tests := []struct {
+		name   string
+		doc    *xbrl.Document
+		scheme string
+		value  string
+		want   []string
+	}{
+		{name: "nil document", doc: nil, scheme: scheme, value: "XYZ", want: nil},
+		{name: "XYZ facts only", doc: doc, scheme: scheme, value: "XYZ", want: []string{"Assets", "Liabilities"}},
+		{name: "ABC facts", doc: doc, scheme: scheme, value: "ABC", want: []string{"Revenue", "NilFact"}},
+		{name: "unknown entity", doc: doc, scheme: scheme, value: "NOPE", want: nil},
+		{name: "scheme mismatch", doc: doc, scheme: "http://other.example.com", value: "XYZ", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got []string
+			for _, f := range tt.doc.FactsForEntity(tt.scheme, tt.value) {
+				got = append(got, f.Name().Local())
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDocument_SiblingFacts(t *testing.T) {
+	t.Parallel()
+
+	src := strings.Replace(extendedInstance, `<ex:NilFact`, `<ex:Cost contextRef="C1" unitRef="U1">40</ex:Cost>
+  <ex:Assets contextRef="C2" unitRef="U1">100</ex:Assets>
+  <ex:NilFact`, 1)
+	doc, err := xbrl.Parse(strings.NewReader(src))
+	require.NoError(t, err)
+
+	facts := doc.Facts()
+	require.Len(t, facts, 4)
+	revenue, cost, assets := facts[0], facts[1], facts[2]
+	outside := xbrl.NewFactForTest(xbrl.FactKindItem, revenue.Name(), "1", "C1", "", "", "", "", "", false)
+	noContext := xbrl.NewFactForTest(xbrl.FactKindItem, revenue.Name(), "1", "", "", "", "", "", "", false)
+
+	tests := []struct {
+		name string
+		doc  *xbrl.Document
+		fact *xbrl.Fact
+		want []string
+	}{
+		{name: "siblings in C1", doc: doc, fact: revenue, want: []string{"Cost", "NilFact"}},
+		{name: "other fact in C1", doc: doc, fact: cost, want: []string{"Revenue", "NilFact"}},
+		{name: "only fact in C2", doc: doc, fact: assets, want: nil},
+		{name: "fact not in the document", doc: doc, fact: outside, want: []string{"Revenue", "Cost", "NilFact"}},
+		{name: "fact without context", doc: doc, fact: noContext, want: nil},
+		{name: "nil fact", doc: doc, fact: nil, want: nil},
+		{name: "nil document", doc: nil, fact: revenue, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got []string
+			for _, f := range tt.doc.SiblingFacts(tt.fact) {
+				got = append(got, f.Name().Local())
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPeriod_AlignmentKey(t *testing.T) {
+	t.Parallel()
+
+	date := func(s string) *string { return &s }
+
+	tests := []struct {
+		name   string
+		period xbrl.Period
+		want   string
+	}{
+		{name: "instant", period: xbrl.NewPeriodForTest(date("2025-12-31"), nil, nil, false), want: "2025-12-31"},
+		{name: "duration", period: xbrl.NewPeriodForTest(nil, date("2025-01-01"), date("2025-12-31"), false), want: "2025-12-31"},
+		{name: "duration without end", period: xbrl.NewPeriodForTest(nil, date("2025-01-01"), nil, false), want: ""},
+		{name: "forever", period: xbrl.NewPeriodForTest(nil, nil, nil, true), want: ""},
+		{name: "zero", period: xbrl.Period{}, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.period.AlignmentKey())
+		})
+	}
+}
+
+func TestDocument_FactsAsOf(t *testing.T) {
+	t.Parallel()
+
+	const src = `<xbrli:xbrl xmlns:xbrli="http://www.xbrl.org/2003/instance" xmlns:ex="http://example.com/xbrl">
+  <xbrli:context id="I2025">
+    <xbrli:entity><xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:instant>2025-12-31</xbrli:instant></xbrli:period>
+  </xbrli:context>
+  <xbrli:context id="D2025">
+    <xbrli:entity><xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:startDate>2025-01-01</xbrli:startDate><xbrli:endDate>2025-12-31</xbrli:endDate></xbrli:period>
+  </xbrli:context>
+  <xbrli:context id="I2024">
+    <xbrli:entity><xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:instant>2024-12-31</xbrli:instant></xbrli:period>
+  </xbrli:context>
+  <ex:Revenue contextRef="D2025">300</ex:Revenue>
+  <ex:Assets contextRef="I2024">900</ex:Assets>
+  <ex:Assets contextRef="I2025">1000</ex:Assets>
+</xbrli:xbrl>`
+	doc, err := xbrl.Parse(strings.NewReader(src))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		doc  *xbrl.Document
+		date string
+		want []string
+	}{
+		{name: "nil document", doc: nil, date: "2025-12-31", want: nil},
+		{name: "instant and duration end", doc: doc, date: "2025-12-31", want: []string{"Revenue@D2025", "Assets@I2025"}},
+		{name: "prior instant", doc: doc, date: "2024-12-31", want: []string{"Assets@I2024"}},
+		{name: "duration start does not match", doc: doc, date: "2025-01-01", want: nil},
+		{name: "empty date", doc: doc, date: "", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got []string
+			for _, f := range tt.doc.FactsAsOf(tt.date) {
+				got = append(got, f.Name().Local()+"@"+f.ContextRef())
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDocument_FactsByFiscalYear(t *testing.T) {
+	t.Parallel()
+
+	const src = `<xbrli:xbrl xmlns:xbrli="http://www.xbrl.org/2003/instance" xmlns:ex="http://example.com/xbrl">
+  <xbrli:context id="FY2024">
+    <xbrli:entity><xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:startDate>2023-04-01</xbrli:startDate><xbrli:endDate>2024-03-31</xbrli:endDate></xbrli:period>
+  </xbrli:context>
+  <xbrli:context id="FY2025">
+    <xbrli:entity><xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:startDate>2024-04-01</xbrli:startDate><xbrli:endDate>2025-03-31</xbrli:endDate></xbrli:period>
+  </xbrli:context>
+  <xbrli:context id="Q1FY2026">
+    <xbrli:entity><xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:instant>2025-06-30</xbrli:instant></xbrli:period>
+  </xbrli:context>
+  <xbrli:context id="Forever">
+    <xbrli:entity><xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:forever/></xbrli:period>
+  </xbrli:context>
+  <xbrli:context id="Bad">
+    <xbrli:entity><xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:instant>end of year</xbrli:instant></xbrli:period>
+  </xbrli:context>
+  <ex:Revenue contextRef="FY2025">300</ex:Revenue>
+  <ex:Revenue contextRef="FY2024">250</ex:Revenue>
+  <ex:Assets contextRef="Q1FY2026">900</ex:Assets>
+  <ex:Name contextRef="Forever">ACME</ex:Name>
+  <ex:Other contextRef="Bad">1</ex:Other>
+  <ex:Orphan contextRef="Missing">2</ex:Orphan>
+</xbrli:xbrl>`
+	doc, err := xbrl.Parse(strings.NewReader(src))
+	require.NoError(t, err)
+
+	names := func(byYear map[int][]*xbrl.Fact) map[int][]string {
+		out := make(map[int][]string)
+		for year, facts := range byYear {
+			for _, f := range facts {
+				out[year] = append(out[year], f.Name().Local()+"@"+f.ContextRef())
+			}
+		}
+		return out
+	}
+
+	assert.Equal(t, map[int][]string{
+		2024: {"Revenue@FY2024"},
+		2025: {"Revenue@FY2025"},
+		2026: {"Assets@Q1FY2026"},
+		0:    {"Name@Forever", "Other@Bad", "Orphan@Missing"},
+	}, names(doc.FactsByFiscalYear(3)))
+
+	assert.Equal(t, map[int][]string{
+		2024: {"Revenue@FY2024"},
+		2025: {"Revenue@FY2025", "Assets@Q1FY2026"},
+		0:    {"Name@Forever", "Other@Bad", "Orphan@Missing"},
+	}, names(doc.FactsByFiscalYear(12)), "calendar fiscal year")
+
+	assert.Nil(t, doc.FactsByFiscalYear(0))
+	assert.Nil(t, doc.FactsByFiscalYear(13))
+
+	var nilDoc *xbrl.Document
+	assert.Nil(t, nilDoc.FactsByFiscalYear(3))
+}
+
+func TestDocument_FactsByUnit(t *testing.T) {
+	t.Parallel()
+
+	src := strings.Replace(extendedInstance, `<ex:NilFact`, `<ex:Cost contextRef="C2" unitRef="U1">40</ex:Cost>
+  <ex:Rate contextRef="C1" unitRef="Udiv">150</ex:Rate>
+  <ex:NilFact`, 1)
+	doc, err := xbrl.Parse(strings.NewReader(src))
+	require.NoError(t, err)
+
+	got := make(map[string][]string)
+	for unit, facts := range doc.FactsByUnit() {
+		for _, f := range facts {
+			got[unit] = append(got[unit], f.Name().Local())
+		}
+	}
+	assert.Equal(t, map[string][]string{
+		"U1":   {"Revenue", "Cost"},
+		"Udiv": {"Rate"},
+		"":     {"NilFact"},
+	}, got)
+
+	var nilDoc *xbrl.Document
+	assert.Nil(t, nilDoc.FactsByUnit())
+}
+
+func TestDocument_Matrix(t *testing.T) {
+	t.Parallel()
+
+	const contexts = `<xbrli:context id="C3">
+    <xbrli:entity><xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:instant>2024-12-31</xbrli:instant></xbrli:period>
+  </xbrli:context>
+  <xbrli:context id="C4">
+    <xbrli:entity><xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:startDate>2025-01-01</xbrli:startDate><xbrli:endDate>2025-12-31</xbrli:endDate></xbrli:period>
+  </xbrli:context>
+  <ex:Revenue contextRef="C4" unitRef="U1">20000</ex:Revenue>
+  <ex:Cost contextRef="C1" unitRef="U1">500</ex:Cost>
+  <ex:Cost contextRef="C3" unitRef="U1" xsi:nil="true"/>
+  <ex:Cost contextRef="C3" unitRef="U1">40</ex:Cost>
+  <ex:NilFact`
+	doc, err := xbrl.Parse(strings.NewReader(strings.Replace(extendedInstance, `<ex:NilFact`, contexts, 1)))
+	require.NoError(t, err)
+
+	const ns = "http://example.com/xbrl"
+	revenue := xbrl.NewQNameForTest("ex", "Revenue", ns)
+	cost := xbrl.NewQNameForTest("other", "Cost", ns)
+
+	// Revenue in 2025 is reported both in the dimensioned context C1 and
+	// in C4; the undimensioned fact wins. Cost in 2025 is only reported
+	// in C1.
+	assert.Equal(t, [][]string{
+		{"20000", ""},
+		{"500", "40"},
+	}, doc.Matrix([]xbrl.QName{revenue, cost}, []string{"2025-12-31", "2024-12-31"}))
+
+	assert.Equal(t, [][]string{
+		{"", ""},
+		{"", "40"},
+	}, doc.Matrix(
+		[]xbrl.QName{xbrl.NewQNameForTest("ex", "Unknown", ns), cost},
+		[]string{"", "2024-12-31"},
+	), "unknown concepts and empty period keys give empty cells")
+
+	assert.Equal(t, [][]string{}, doc.Matrix(nil, []string{"2025-12-31"}))
+
+	var nilDoc *xbrl.Document
+	assert.Nil(t, nilDoc.Matrix([]xbrl.QName{revenue}, []string{"2025-12-31"}))
+}
+
+func TestFact_CachedConcept(t *testing.T) {
+	t.Parallel()
+
+	doc, err := xbrl.Parse(strings.NewReader(extendedInstance))
+	require.NoError(t, err)
+	revenue, nilFact := doc.Facts()[0], doc.Facts()[1]
+	assert.Nil(t, revenue.CachedConcept(), "no taxonomy attached")
+
+	q := revenue.Name()
+	first := xbrl.NewConceptForTest(q, "ex_Revenue", xbrl.QName{}, xbrl.QName{}, false, false, "duration", "credit")
+	doc.SetTaxonomy(xbrl.NewTaxonomyForTest(map[xbrl.QName]*xbrl.Concept{q: first}))
+
+	assert.Same(t, first, revenue.CachedConcept())
+	c, ok := doc.ConceptOf(revenue)
+	require.True(t, ok)
+	assert.Same(t, revenue.CachedConcept(), c)
+	assert.Nil(t, nilFact.CachedConcept(), "concept not in the taxonomy")
+
+	second := xbrl.NewConceptForTest(q, "ex_Revenue2", xbrl.QName{}, xbrl.QName{}, false, false, "duration", "debit")
+	doc.SetTaxonomy(xbrl.NewTaxonomyForTest(map[xbrl.QName]*xbrl.Concept{q: second}))
+	assert.Same(t, second, revenue.CachedConcept(), "replaced by the new taxonomy")
+	c, _ = doc.ConceptOf(revenue)
+	assert.Same(t, second, c)
+
+	doc.SetTaxonomy(xbrl.NewTaxonomyForTest(map[xbrl.QName]*xbrl.Concept{}))
+	assert.Nil(t, revenue.CachedConcept(), "cleared by a taxonomy without the concept")
+
+	doc.SetTaxonomy(nil)
+	assert.Nil(t, revenue.CachedConcept())
+	_, ok = doc.ConceptOf(revenue)
+	assert.False(t, ok)
+
+	var nilFactPtr *xbrl.Fact
+	assert.Nil(t, nilFactPtr.CachedConcept())
+}
+
+func TestDocument_ConceptOf_AfterMerge(t *testing.T) {
+	t.Parallel()
+
+	doc, err := xbrl.Parse(strings.NewReader(extendedInstance))
+	require.NoError(t, err)
+	revenue := doc.Facts()[0]
+
+	q := revenue.Name()
+	base := xbrl.NewConceptForTest(q, "ex_Revenue", xbrl.QName{}, xbrl.QName{}, false, false, "duration", "credit")
+	doc.SetTaxonomy(xbrl.NewTaxonomyForTest(map[xbrl.QName]*xbrl.Concept{q: base}))
+
+	ext := xbrl.NewConceptForTest(q, "ex_Revenue", xbrl.QName{}, xbrl.QName{}, false, false, "duration", "debit")
+	doc.Taxonomy().Merge(xbrl.NewTaxonomyForTest(map[xbrl.QName]*xbrl.Concept{q: ext}))
+
+	c, ok := doc.ConceptOf(revenue)
+	require.True(t, ok)
+	assert.Same(t, ext, c, "ConceptOf sees the merged concept")
+	assert.Nil(t, revenue.CachedConcept(), "the cache is invalidated by Merge")
+
+	doc.SetTaxonomy(doc.Taxonomy())
+	assert.Same(t, ext, revenue.CachedConcept(), "refreshed by SetTaxonomy")
+
+	// A fact from another document is looked up in this document's taxonomy.
+	other, err := xbrl.Parse(strings.NewReader(extendedInstance))
+	require.NoError(t, err)
+	otherBase := xbrl.NewConceptForTest(q, "ex_Revenue", xbrl.QName{}, xbrl.QName{}, false, false, "duration", "credit")
+	other.SetTaxonomy(xbrl.NewTaxonomyForTest(map[xbrl.QName]*xbrl.Concept{q: otherBase}))
+	c, ok = doc.ConceptOf(other.Facts()[0])
+	require.True(t, ok)
+	assert.Same(t, ext, c)
+}
@@ -0,0 +1,112 @@
+package xbrl
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// DuplicateGroup is a set of two or more duplicate facts: facts with the
+// same concept, equivalent contexts and units, and the same xml:lang.
+type DuplicateGroup struct {
+	// Facts holds the duplicate facts in document order.
+	Facts []*Fact
+}
+
+// DecimalsConsistent reports whether all non-nil facts in the group
+// declare the same decimals value. Values are compared as parsed by
+// ParseDecimals, so "2" and "+2" are the same. Duplicates reported with
+// different decimals must be compared at the lowest accuracy, so such
+// groups need care when checking consistency.
+func (g DuplicateGroup) DecimalsConsistent() bool {
+	first, seen := "", false
+	for _, f := range g.Facts {
+		if f == nil || f.nil {
+			continue
+		}
+		dec := strings.TrimSpace(f.decimals)
+		if n, inf, ok, err := ParseDecimals(dec); ok && err == nil && !inf {
+			dec = strconv.Itoa(n)
+		}
+		if !seen {
+			first, seen = dec, true
+			continue
+		}
+		if dec != first {
+			return false
+		}
+	}
+	return true
+}
+
+// DuplicateFacts returns the groups of duplicate facts in the document,
+// ordered by the position of their first fact.
+//
+// Concepts are compared by namespace URI and local name, contexts by
+// Signature and units by their measures, so duplicates are found even if
+// they refer to different but equivalent contexts or units.
+func (d *Document) DuplicateFacts() []DuplicateGroup {
+	if d == nil {
+		return nil
+	}
+
+	type duplicateKey struct {
+		reconcileKey
+		unit string
+		lang string
+	}
+
+	groups := make(map[duplicateKey][]*Fact)
+	var order []duplicateKey
+	for _, f := range d.facts {
+		if f == nil {
+			continue
+		}
+		k := duplicateKey{
+			reconcileKey: d.reconcileKey(f),
+			unit:         d.unitKey(f),
+			lang:         strings.ToLower(f.lang),
+		}
+		if _, ok := groups[k]; !ok {
+			order = append(order, k)
+		}
+		groups[k] = append(groups[k], f)
+	}
+
+	var out []DuplicateGroup
+	for _, k := range order {
+		if facts := groups[k]; len(facts) > 1 {
+			out = append(out, DuplicateGroup{Facts: facts})
+		}
+	}
+	return out
+}
+
+// unitKey returns the signature of the fact's unit, the unit ID prefixed
+// with "#" if it cannot be resolved, or "" if the fact has no unit.
+func (d *Document) unitKey(f *Fact) string {
+	if f.unitRef == "" {
+		return ""
+	}
+	if u, ok := d.units[f.unitRef]; ok {
+		return u.signature()
+	}
+	return "#" + f.unitRef
+}
+
+// signature returns a canonical string describing the measures of the
+// unit, independent of its ID, prefixes and measure order.
+func (u *Unit) signature() string {
+	measures := func(qs []QName) string {
+		s := make([]string, len(qs))
+		for i, q := range qs {
+			s[i] = QName{local: q.local, uri: q.uri}.String()
+		}
+		slices.Sort(s)
+		return strings.Join(s, "*")
+	}
+	if u.divide {
+		return measures(u.numerator) + "/" + measures(u.denominator)
+	}
+	return measures(u.measures)
+}
@@ -0,0 +1,89 @@
+package xbrl_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aethiopicuschan/xbrl-go/pkg/xbrl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const duplicateInstance = `<xbrli:xbrl xmlns:xbrli="http://www.xbrl.org/2003/instance"
+    xmlns:iso4217="http://www.xbrl.org/2003/iso4217" xmlns:ex="http://example.com/xbrl">
+  <xbrli:context id="C1">
+    <xbrli:entity><xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:instant>2025-03-31</xbrli:instant></xbrli:period>
+  </xbrli:context>
+  <xbrli:context id="C1copy">
+    <xbrli:entity><xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:instant>2025-03-31</xbrli:instant></xbrli:period>
+  </xbrli:context>
+  <xbrli:unit id="JPY"><xbrli:measure>iso4217:JPY</xbrli:measure></xbrli:unit>
+  <xbrli:unit id="Yen"><xbrli:measure>iso4217:JPY</xbrli:measure></xbrli:unit>
+  <xbrli:unit id="USD"><xbrli:measure>iso4217:USD</xbrli:measure></xbrli:unit>
+  <ex:Revenue contextRef="C1" unitRef="JPY" decimals="0">1234567</ex:Revenue>
+  <ex:Revenue contextRef="C1copy" unitRef="Yen" decimals="-3">1235000</ex:Revenue>
+  <ex:Revenue contextRef="C1" unitRef="USD" decimals="0">10</ex:Revenue>
+  <ex:Assets contextRef="C1" unitRef="JPY" decimals="0">5</ex:Assets>
+  <ex:Assets contextRef="C1copy" unitRef="JPY" decimals=" 0 ">5</ex:Assets>
+  <ex:Note contextRef="C1" xml:lang="en">a</ex:Note>
+  <ex:Note contextRef="C1" xml:lang="ja">b</ex:Note>
+</xbrli:xbrl>`
+
+func TestDocument_DuplicateFacts(t *testing.T) {
+	t.Parallel()
+
+	doc, err := xbrl.Parse(strings.NewReader(duplicateInstance))
+	require.NoError(t, err)
+
+	groups := doc.DuplicateFacts()
+	require.Len(t, groups, 2, "USD revenue and notes in other languages are not duplicates")
+
+	revenue := groups[0]
+	require.Len(t, revenue.Facts, 2)
+	assert.Equal(t, "1234567", revenue.Facts[0].Value())
+	assert.Equal(t, "1235000", revenue.Facts[1].Value())
+	assert.False(t, revenue.DecimalsConsistent(), `decimals="0" and decimals="-3"`)
+
+	assets := groups[1]
+	require.Len(t, assets.Facts, 2)
+	assert.Equal(t, "Assets", assets.Facts[0].Name().Local())
+	assert.True(t, assets.DecimalsConsistent())
+
+	var nilDoc *xbrl.Document
+	assert.Nil(t, nilDoc.DuplicateFacts())
+
+	plain, err := xbrl.Parse(strings.NewReader(minimalInstance))
+	require.NoError(t, err)
+	assert.Empty(t, plain.DuplicateFacts())
+}
+
+func TestDuplicateGroup_DecimalsConsistent(t *testing.T) {
+	t.Parallel()
+
+	q := xbrl.NewQNameForTest("ex", "Revenue", "http://example.com/xbrl")
+	fact := func(decimals string, isNil bool) *xbrl.Fact {
+		return xbrl.NewFactForTest(xbrl.FactKindItem, q, "1", "C1", "U1", decimals, "", "", "", isNil)
+	}
+
+	tests := []struct {
+		name  string
+		group xbrl.DuplicateGroup
+		want  bool
+	}{
+		{name: "empty group", group: xbrl.DuplicateGroup{}, want: true},
+		{name: "same decimals", group: xbrl.DuplicateGroup{Facts: []*xbrl.Fact{fact("2", false), fact("2", false)}}, want: true},
+		{name: "different decimals", group: xbrl.DuplicateGroup{Facts: []*xbrl.Fact{fact("0", false), fact("-3", false)}}, want: false},
+		{name: "equivalent lexical forms", group: xbrl.DuplicateGroup{Facts: []*xbrl.Fact{fact("2", false), fact(" +2 ", false), fact("02", false)}}, want: true},
+		{name: "INF and finite", group: xbrl.DuplicateGroup{Facts: []*xbrl.Fact{fact("INF", false), fact("0", false)}}, want: false},
+		{name: "nil facts are ignored", group: xbrl.DuplicateGroup{Facts: []*xbrl.Fact{fact("0", false), fact("", true), nil}}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.group.DecimalsConsistent())
+		})
+	}
+}
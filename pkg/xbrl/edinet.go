@@ -0,0 +1,106 @@
+package xbrl
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// ErrNoInstance is returned by ParseEDINET when a submission directory
+// contains no XBRL instance that can be parsed.
+var ErrNoInstance = errors.New("xbrl: no XBRL instance found")
+
+// ParseEDINET parses an unpacked EDINET submission.
+//
+// dir is searched recursively for an instance document (*.xbrl). EDINET
+// places the main instance under XBRL/PublicDoc and audit reports under
+// XBRL/AuditDoc, so instances in a PublicDoc directory are preferred; ties
+// are broken by path. Inline XBRL (*_ixbrl.htm) is not supported: if only
+// inline documents are found, an error wrapping ErrNoInstance is returned.
+//
+// The taxonomy schemas and linkbases that the instance references by
+// relative href are loaded from the instance's directory and attached to
+// the document, so labels of the filer's extension concepts are available.
+// References to the published EDINET taxonomy (absolute URLs) are
+// skipped, since they are not part of the submission.
+func ParseEDINET(dir string) (*Document, error) {
+	var instances, inline []string
+	err := filepath.WalkDir(dir, func(path string, e fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if e.IsDir() {
+			return nil
+		}
+		name := strings.ToLower(e.Name())
+		switch {
+		case strings.HasSuffix(name, ".xbrl"):
+			instances = append(instances, path)
+		case strings.HasSuffix(name, "_ixbrl.htm"), strings.HasSuffix(name, "_ixbrl.html"):
+			inline = append(inline, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("xbrl: scan EDINET submission: %w", err)
+	}
+
+	if len(instances) == 0 {
+		if len(inline) > 0 {
+			return nil, fmt.Errorf("%w in %s: inline XBRL (%s) is not supported",
+				ErrNoInstance, dir, filepath.Base(inline[0]))
+		}
+		return nil, fmt.Errorf("%w in %s", ErrNoInstance, dir)
+	}
+
+	slices.SortFunc(instances, func(a, b string) int {
+		if pa, pb := inPublicDoc(a), inPublicDoc(b); pa != pb {
+			if pa {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a, b)
+	})
+	path := instances[0]
+
+	doc, err := ParseFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	opener := localOpener(filepath.Dir(path))
+	if _, err := doc.loadTaxonomyFromSchemaRefs(opener, isLocalHref); err != nil {
+		return nil, err
+	}
+	if err := doc.loadLinkbases(opener, isLocalHref); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// inPublicDoc reports whether path is inside a PublicDoc directory.
+func inPublicDoc(path string) bool {
+	return slices.Contains(strings.Split(filepath.ToSlash(path), "/"), "PublicDoc")
+}
+
+// isLocalHref reports whether href is a relative reference, i.e. a file
+// shipped alongside the document rather than a published taxonomy.
+func isLocalHref(href string) bool {
+	u, err := url.Parse(href)
+	return err == nil && !u.IsAbs() && !strings.HasPrefix(href, "/")
+}
+
+// localOpener returns an opener for hrefs relative to dir. Hrefs that
+// resolve outside dir, such as "../x.xsd", are rejected.
+func localOpener(dir string) func(href string) (io.ReadCloser, error) {
+	return func(href string) (io.ReadCloser, error) {
+		return os.OpenInRoot(dir, filepath.FromSlash(href))
+	}
+}
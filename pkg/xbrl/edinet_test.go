@@ -0,0 +1,162 @@
+package xbrl_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aethiopicuschan/xbrl-go/pkg/xbrl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	edinetNS     = "http://disclosure.edinet-fsa.go.jp/jpcrp030000/asr/001/E99999-000/2025-03-31/01/2025-06-20"
+	edinetPrefix = "jpcrp030000-asr-001_E99999-000_2025-03-31_01_2025-06-20"
+)
+
+const edinetInstance = `<?xml version="1.0" encoding="UTF-8"?>
+<xbrli:xbrl
+    xmlns:xbrli="http://www.xbrl.org/2003/instance"
+    xmlns:link="http://www.xbrl.org/2003/linkbase"
+    xmlns:xlink="http://www.w3.org/1999/xlink"
+    xmlns:iso4217="http://www.xbrl.org/2003/iso4217"
+    xmlns:jpcrp030000-asr_E99999-000="` + edinetNS + `">
+  <link:schemaRef xlink:type="simple" xlink:href="` + edinetPrefix + `.xsd"/>
+  <xbrli:context id="CurrentYearInstant">
+    <xbrli:entity><xbrli:identifier scheme="http://disclosure.edinet-fsa.go.jp">E99999-000</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:instant>2025-03-31</xbrli:instant></xbrli:period>
+  </xbrli:context>
+  <xbrli:unit id="JPY"><xbrli:measure>iso4217:JPY</xbrli:measure></xbrli:unit>
+  <jpcrp030000-asr_E99999-000:ProvisionForWidgets contextRef="CurrentYearInstant" unitRef="JPY" decimals="-6">12000000</jpcrp030000-asr_E99999-000:ProvisionForWidgets>
+</xbrli:xbrl>
+`
+
+const edinetSchema = `<?xml version="1.0" encoding="UTF-8"?>
+<xsd:schema
+    xmlns:xsd="http://www.w3.org/2001/XMLSchema"
+    xmlns:xbrli="http://www.xbrl.org/2003/instance"
+    xmlns:link="http://www.xbrl.org/2003/linkbase"
+    xmlns:xlink="http://www.w3.org/1999/xlink"
+    xmlns:jpcrp030000-asr_E99999-000="` + edinetNS + `"
+    targetNamespace="` + edinetNS + `">
+  <xsd:annotation>
+    <xsd:appinfo>
+      <link:linkbaseRef xlink:type="simple" xlink:href="` + edinetPrefix + `_lab.xml"
+          xlink:role="http://www.xbrl.org/2003/role/labelLinkbaseRef"
+          xlink:arcrole="http://www.w3.org/1999/xlink/properties/linkbase"/>
+      <link:linkbaseRef xlink:type="simple" xlink:href="http://disclosure.edinet-fsa.go.jp/taxonomy/jppfs/2024-11-01/label/jppfs_2024-11-01_lab.xml"
+          xlink:role="http://www.xbrl.org/2003/role/labelLinkbaseRef"
+          xlink:arcrole="http://www.w3.org/1999/xlink/properties/linkbase"/>
+    </xsd:appinfo>
+  </xsd:annotation>
+  <xsd:import namespace="http://disclosure.edinet-fsa.go.jp/taxonomy/jppfs/2024-11-01/jppfs_cor"
+      schemaLocation="http://disclosure.edinet-fsa.go.jp/taxonomy/jppfs/2024-11-01/jppfs_cor_2024-11-01.xsd"/>
+  <xsd:element id="jpcrp030000-asr_E99999-000_ProvisionForWidgets" name="ProvisionForWidgets"
+      substitutionGroup="xbrli:item" type="xbrli:monetaryItemType" xbrli:periodType="instant" xbrli:balance="credit" nillable="true"/>
+</xsd:schema>
+`
+
+const edinetLabels = `<?xml version="1.0" encoding="UTF-8"?>
+<link:linkbase xmlns:link="http://www.xbrl.org/2003/linkbase" xmlns:xlink="http://www.w3.org/1999/xlink">
+  <link:labelLink xlink:type="extended" xlink:role="http://www.xbrl.org/2003/role/link">
+    <link:loc xlink:type="locator" xlink:href="` + edinetPrefix + `.xsd#jpcrp030000-asr_E99999-000_ProvisionForWidgets" xlink:label="ProvisionForWidgets"/>
+    <link:label xlink:type="resource" xlink:label="label_ProvisionForWidgets" xlink:role="http://www.xbrl.org/2003/role/label" xml:lang="ja">製品保証引当金</link:label>
+    <link:labelArc xlink:type="arc" xlink:arcrole="http://www.xbrl.org/2003/arcrole/concept-label" xlink:from="ProvisionForWidgets" xlink:to="label_ProvisionForWidgets"/>
+  </link:labelLink>
+</link:linkbase>
+`
+
+// writeFiles writes files (relative path -> content) under dir.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+}
+
+func TestParseEDINET(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"XBRL/PublicDoc/" + edinetPrefix + ".xbrl":       edinetInstance,
+		"XBRL/PublicDoc/" + edinetPrefix + ".xsd":        edinetSchema,
+		"XBRL/PublicDoc/" + edinetPrefix + "_lab.xml":    edinetLabels,
+		"XBRL/PublicDoc/0101010_honbun_ixbrl.htm":        "<html/>",
+		"XBRL/AuditDoc/jpaud-aar-cn-001_E99999-000.xbrl": `<xbrli:xbrl xmlns:xbrli="http://www.xbrl.org/2003/instance"/>`,
+	})
+
+	doc, err := xbrl.ParseEDINET(dir)
+	require.NoError(t, err)
+
+	facts := doc.Facts()
+	require.Len(t, facts, 1, "the PublicDoc instance is preferred over AuditDoc")
+	f := facts[0]
+	assert.Equal(t, "ProvisionForWidgets", f.Name().Local())
+
+	c, ok := doc.ConceptOf(f)
+	require.True(t, ok, "the local taxonomy is attached")
+	assert.Equal(t, "credit", c.Balance())
+
+	label, ok := doc.Taxonomy().Label(f.Name(), "", "ja")
+	require.True(t, ok, "local label linkbases are loaded")
+	assert.Equal(t, "製品保証引当金", label)
+
+	v, err := doc.AsInt64(f)
+	require.NoError(t, err)
+	assert.Equal(t, int64(12000000), v)
+}
+
+func TestParseEDINET_Errors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty directory", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := xbrl.ParseEDINET(t.TempDir())
+		assert.ErrorIs(t, err, xbrl.ErrNoInstance)
+	})
+
+	t.Run("inline XBRL only", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		writeFiles(t, dir, map[string]string{"XBRL/PublicDoc/0101010_honbun_ixbrl.htm": "<html/>"})
+		_, err := xbrl.ParseEDINET(dir)
+		assert.ErrorIs(t, err, xbrl.ErrNoInstance)
+		assert.ErrorContains(t, err, "inline XBRL")
+	})
+
+	t.Run("missing directory", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := xbrl.ParseEDINET(filepath.Join(t.TempDir(), "missing"))
+		assert.ErrorContains(t, err, "scan EDINET submission")
+	})
+
+	t.Run("missing local schema", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		writeFiles(t, dir, map[string]string{"XBRL/PublicDoc/instance.xbrl": edinetInstance})
+		_, err := xbrl.ParseEDINET(dir)
+		assert.ErrorContains(t, err, "open schemaRef")
+	})
+
+	t.Run("schema outside the instance directory", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		instance := strings.Replace(edinetInstance, `xlink:href="`+edinetPrefix+`.xsd"`, `xlink:href="../`+edinetPrefix+`.xsd"`, 1)
+		writeFiles(t, dir, map[string]string{
+			"XBRL/PublicDoc/instance.xbrl":  instance,
+			"XBRL/" + edinetPrefix + ".xsd": edinetSchema,
+		})
+		_, err := xbrl.ParseEDINET(dir)
+		assert.ErrorContains(t, err, "open schemaRef")
+	})
+}
@@ -3,6 +3,11 @@ package xbrl
 import (
 	"encoding/json"
 	"io"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
 )
 
 // FactJSON is a simple DTO for exporting facts as JSON.
@@ -25,25 +30,228 @@ func (d *Document) FactsAsJSONDTOs() []FactJSON {
 		if f == nil {
 			continue
 		}
-		value := f.Value()
-		if f.IsNil() {
-			value = ""
+		out = append(out, factJSON(f))
+	}
+	return out
+}
+
+// factJSON converts a non-nil fact into its DTO. Nil facts have an empty
+// value.
+func factJSON(f *Fact) FactJSON {
+	value := f.Value()
+	if f.IsNil() {
+		value = ""
+	}
+	return FactJSON{
+		Name:       f.Name().String(),
+		Value:      value,
+		ContextRef: f.ContextRef(),
+		UnitRef:    f.UnitRef(),
+		Nil:        f.IsNil(),
+	}
+}
+
+// EncodeFactsJSON writes all facts in the Document as JSON array to w.
+// - HTML escape is disabled
+// - If pretty is true, indented output is used
+func (d *Document) EncodeFactsJSON(w io.Writer, pretty bool) error {
+	if d == nil {
+		return nil
+	}
+
+	enc := json.NewEncoder(w)
+	if pretty {
+		enc.SetIndent("", "  ")
+	}
+	enc.SetEscapeHTML(false)
+
+	dtos := d.FactsAsJSONDTOs()
+	return enc.Encode(dtos)
+}
+
+// JSONExportOptions configures EncodeFactsJSONWith. The zero value gives
+// the output of EncodeFactsJSON with pretty set to false.
+type JSONExportOptions struct {
+	// Pretty enables indented output.
+	Pretty bool
+
+	// NilAsNull writes the value of xsi:nil facts as JSON null instead of
+	// an empty string. It takes precedence over KeepNilValue.
+	NilAsNull bool
+
+	// KeepNilValue writes the raw content of xsi:nil facts instead of
+	// clearing it.
+	KeepNilValue bool
+}
+
+// factJSONWithNull is FactJSON with a nullable value.
+type factJSONWithNull struct {
+	Name       string  `json:"name"`
+	Value      *string `json:"value"`
+	ContextRef string  `json:"context"`
+	UnitRef    string  `json:"unit"`
+	Nil        bool    `json:"nil"`
+}
+
+// EncodeFactsJSONWith writes all facts in the Document as a JSON array to
+// w like EncodeFactsJSON, with the handling of nil fact values controlled
+// by opts. HTML escape is disabled.
+func (d *Document) EncodeFactsJSONWith(w io.Writer, opts JSONExportOptions) error {
+	if d == nil {
+		return nil
+	}
+
+	enc := json.NewEncoder(w)
+	if opts.Pretty {
+		enc.SetIndent("", "  ")
+	}
+	enc.SetEscapeHTML(false)
+
+	out := make([]factJSONWithNull, 0, len(d.facts))
+	for _, f := range d.facts {
+		if f == nil {
+			continue
 		}
-		out = append(out, FactJSON{
-			Name:       f.Name().String(),
+		dto := factJSON(f)
+		if f.IsNil() && opts.KeepNilValue {
+			dto.Value = f.Value()
+		}
+		value := &dto.Value
+		if f.IsNil() && opts.NilAsNull {
+			value = nil
+		}
+		out = append(out, factJSONWithNull{
+			Name:       dto.Name,
 			Value:      value,
+			ContextRef: dto.ContextRef,
+			UnitRef:    dto.UnitRef,
+			Nil:        dto.Nil,
+		})
+	}
+	return enc.Encode(out)
+}
+
+// EncodeFactsNDJSON writes the facts in the Document to w as
+// newline-delimited JSON: one FactJSON object per line, without an
+// enclosing array. Facts are encoded one at a time, so the output can be
+// streamed into log-ingestion and big-data pipelines.
+// - HTML escape is disabled
+// - Nil facts are skipped; a nil Document writes nothing
+func (d *Document) EncodeFactsNDJSON(w io.Writer) error {
+	if d == nil {
+		return nil
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+
+	for _, f := range d.facts {
+		if f == nil {
+			continue
+		}
+		if err := enc.Encode(factJSON(f)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TypedFactJSON is like FactJSON, but Value holds the typed value of the
+// fact: a number, a bool, a string, or nil for nil facts.
+type TypedFactJSON struct {
+	Name       string `json:"name"`
+	Value      any    `json:"value"`
+	ContextRef string `json:"context"`
+	UnitRef    string `json:"unit"`
+	Nil        bool   `json:"nil"`
+}
+
+// TypedFactsAsJSONDTOs converts all facts in a Document into a slice of
+// TypedFactJSON DTOs, using the attached taxonomy to type their values:
+//   - numeric and monetary: a JSON number with the reported digits
+//   - boolean: bool
+//   - date/dateTime: RFC 3339 string (see AsTime for the meaning of loc)
+//   - anything else: the raw string value
+//
+// Facts whose concept cannot be resolved, or whose value is not a valid
+// lexical form for its type, keep their raw string value. Without a
+// taxonomy all values are strings.
+func (d *Document) TypedFactsAsJSONDTOs(loc *time.Location) []TypedFactJSON {
+	if d == nil {
+		return nil
+	}
+	out := make([]TypedFactJSON, 0, len(d.facts))
+	for _, f := range d.facts {
+		if f == nil {
+			continue
+		}
+		dto := TypedFactJSON{
+			Name:       f.Name().String(),
 			ContextRef: f.ContextRef(),
 			UnitRef:    f.UnitRef(),
 			Nil:        f.IsNil(),
-		})
+		}
+		if !f.IsNil() {
+			dto.Value = d.typedJSONValue(f, loc)
+		}
+		out = append(out, dto)
 	}
 	return out
 }
 
-// EncodeFactsJSON writes all facts in the Document as JSON array to w.
+// typedJSONValue returns the value of f as a JSON-encodable value.
+func (d *Document) typedJSONValue(f *Fact, loc *time.Location) any {
+	v, err := d.TypedValue(f, loc)
+	if err != nil {
+		return f.Value()
+	}
+	switch v := v.(type) {
+	case int64, float64:
+		// TypedValue has validated the lexical form, so the reported digits
+		// are kept instead of being rounded through float64.
+		return jsonNumber(f.Value())
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case *url.URL:
+		return v.String()
+	default:
+		return v
+	}
+}
+
+// jsonNumber rewrites a valid xs:decimal or xs:double value such as
+// "+012.50" or "1.E3" into the JSON number grammar ("12.50", "1E3"),
+// keeping its digits.
+func jsonNumber(v string) json.Number {
+	v = strings.TrimSpace(v)
+	var sign, exp string
+	switch {
+	case strings.HasPrefix(v, "-"):
+		sign, v = "-", v[1:]
+	case strings.HasPrefix(v, "+"):
+		v = v[1:]
+	}
+	if i := strings.IndexAny(v, "eE"); i >= 0 {
+		v, exp = v[:i], v[i:]
+	}
+	intPart, frac, _ := strings.Cut(v, ".")
+	intPart = strings.TrimLeft(intPart, "0")
+	if intPart == "" {
+		intPart = "0"
+	}
+	out := sign + intPart
+	if frac != "" {
+		out += "." + frac
+	}
+	return json.Number(out + exp)
+}
+
+// EncodeTypedFactsJSON writes all facts in the Document as a JSON array
+// of TypedFactJSON to w. See TypedFactsAsJSONDTOs for how values are
+// typed.
 // - HTML escape is disabled
 // - If pretty is true, indented output is used
-func (d *Document) EncodeFactsJSON(w io.Writer, pretty bool) error {
+func (d *Document) EncodeTypedFactsJSON(w io.Writer, loc *time.Location, pretty bool) error {
 	if d == nil {
 		return nil
 	}
@@ -54,6 +262,117 @@ func (d *Document) EncodeFactsJSON(w io.Writer, pretty bool) error {
 	}
 	enc.SetEscapeHTML(false)
 
-	dtos := d.FactsAsJSONDTOs()
-	return enc.Encode(dtos)
+	return enc.Encode(d.TypedFactsAsJSONDTOs(loc))
+}
+
+// ContextJSON is a DTO for exporting contexts as JSON.
+type ContextJSON struct {
+	ID                 string                  `json:"id"`
+	Entity             EntityJSON              `json:"entity"`
+	Period             PeriodJSON              `json:"period"`
+	ExplicitDimensions []ExplicitDimensionJSON `json:"explicitDimensions"`
+	TypedDimensions    []TypedDimensionJSON    `json:"typedDimensions"`
+}
+
+// EntityJSON is a DTO for the entity identifier of a context.
+type EntityJSON struct {
+	Scheme string `json:"scheme"`
+	Value  string `json:"value"`
+}
+
+// PeriodJSON is a DTO for the period of a context.
+//
+// Type is one of "instant", "duration" or "forever" (empty if unknown).
+type PeriodJSON struct {
+	Type      string `json:"type"`
+	Instant   string `json:"instant,omitempty"`
+	StartDate string `json:"startDate,omitempty"`
+	EndDate   string `json:"endDate,omitempty"`
+}
+
+// ExplicitDimensionJSON is a DTO for an explicit dimension.
+type ExplicitDimensionJSON struct {
+	Axis   string `json:"axis"`
+	Member string `json:"member"`
+}
+
+// TypedDimensionJSON is a DTO for a typed dimension.
+// Value is the raw inner XML of the typed member.
+type TypedDimensionJSON struct {
+	Axis  string `json:"axis"`
+	Value string `json:"value"`
+}
+
+// ContextsAsJSONDTOs converts all contexts in a Document into a slice of
+// ContextJSON DTOs, ordered by context ID.
+func (d *Document) ContextsAsJSONDTOs() []ContextJSON {
+	if d == nil {
+		return nil
+	}
+	out := make([]ContextJSON, 0, len(d.contexts))
+	for _, id := range slices.Sorted(maps.Keys(d.contexts)) {
+		c := d.contexts[id]
+		if c == nil {
+			continue
+		}
+
+		dto := ContextJSON{
+			ID: c.id,
+			Entity: EntityJSON{
+				Scheme: c.entity.identifier.scheme,
+				Value:  c.entity.identifier.value,
+			},
+			Period:             periodJSON(c.period),
+			ExplicitDimensions: []ExplicitDimensionJSON{},
+			TypedDimensions:    []TypedDimensionJSON{},
+		}
+		for _, dim := range c.dimensions {
+			if dim.explicit {
+				dto.ExplicitDimensions = append(dto.ExplicitDimensions, ExplicitDimensionJSON{
+					Axis:   dim.dimension.String(),
+					Member: dim.member.String(),
+				})
+			} else {
+				dto.TypedDimensions = append(dto.TypedDimensions, TypedDimensionJSON{
+					Axis:  dim.dimension.String(),
+					Value: dim.typedValue,
+				})
+			}
+		}
+		out = append(out, dto)
+	}
+	return out
+}
+
+// EncodeContextsJSON writes all contexts in the Document as JSON array to w.
+// - HTML escape is disabled
+// - If pretty is true, indented output is used
+func (d *Document) EncodeContextsJSON(w io.Writer, pretty bool) error {
+	if d == nil {
+		return nil
+	}
+
+	enc := json.NewEncoder(w)
+	if pretty {
+		enc.SetIndent("", "  ")
+	}
+	enc.SetEscapeHTML(false)
+
+	return enc.Encode(d.ContextsAsJSONDTOs())
+}
+
+func periodJSON(p Period) PeriodJSON {
+	var out PeriodJSON
+	switch {
+	case p.IsForever():
+		out.Type = "forever"
+	case p.IsInstant():
+		out.Type = "instant"
+	case p.startDate != nil || p.endDate != nil:
+		out.Type = "duration"
+	}
+	out.Instant, _ = p.Instant()
+	out.StartDate, _ = p.StartDate()
+	out.EndDate, _ = p.EndDate()
+	return out
 }
@@ -3,10 +3,13 @@ package xbrl_test
 import (
 	"bytes"
 	"encoding/json"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/aethiopicuschan/xbrl-go/pkg/xbrl"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestFactsAsJSONDTOs_NilDocument verifies that a nil *Document returns nil.
@@ -215,3 +218,262 @@ func TestEncodeFactsJSON_CompactAndPretty(t *testing.T) {
 		}
 	})
 }
+
+// TestEncodeFactsJSONWith verifies the handling of nil fact values.
+func TestEncodeFactsJSONWith(t *testing.T) {
+	t.Parallel()
+
+	q := xbrl.NewQNameForTest("ex", "Revenue", "http://example.com")
+	f1 := xbrl.NewFactForTest(xbrl.FactKindItem, q, "100", "C1", "JPY", "0", "", "F1", "", false)
+	f2 := xbrl.NewFactForTest(xbrl.FactKindItem, q, "raw", "C2", "JPY", "", "", "F2", "", true)
+	doc := xbrl.NewDocumentForTest(nil, nil, nil, []*xbrl.Fact{f1, nil, f2}, nil)
+
+	tests := []struct {
+		name string
+		opts xbrl.JSONExportOptions
+		want string
+	}{
+		{
+			name: "default matches EncodeFactsJSON",
+			opts: xbrl.JSONExportOptions{},
+			want: `[{"name":"{http://example.com}Revenue","value":"100","context":"C1","unit":"JPY","nil":false},` +
+				`{"name":"{http://example.com}Revenue","value":"","context":"C2","unit":"JPY","nil":true}]` + "\n",
+		},
+		{
+			name: "nil as null",
+			opts: xbrl.JSONExportOptions{NilAsNull: true},
+			want: `[{"name":"{http://example.com}Revenue","value":"100","context":"C1","unit":"JPY","nil":false},` +
+				`{"name":"{http://example.com}Revenue","value":null,"context":"C2","unit":"JPY","nil":true}]` + "\n",
+		},
+		{
+			name: "keep nil value",
+			opts: xbrl.JSONExportOptions{KeepNilValue: true},
+			want: `[{"name":"{http://example.com}Revenue","value":"100","context":"C1","unit":"JPY","nil":false},` +
+				`{"name":"{http://example.com}Revenue","value":"raw","context":"C2","unit":"JPY","nil":true}]` + "\n",
+		},
+		{
+			name: "null takes precedence",
+			opts: xbrl.JSONExportOptions{NilAsNull: true, KeepNilValue: true},
+			want: `[{"name":"{http://example.com}Revenue","value":"100","context":"C1","unit":"JPY","nil":false},` +
+				`{"name":"{http://example.com}Revenue","value":null,"context":"C2","unit":"JPY","nil":true}]` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			require.NoError(t, doc.EncodeFactsJSONWith(&buf, tt.opts))
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+
+	t.Run("default equals EncodeFactsJSON", func(t *testing.T) {
+		t.Parallel()
+
+		var with, plain bytes.Buffer
+		require.NoError(t, doc.EncodeFactsJSONWith(&with, xbrl.JSONExportOptions{Pretty: true}))
+		require.NoError(t, doc.EncodeFactsJSON(&plain, true))
+		assert.Equal(t, plain.String(), with.String())
+	})
+
+	t.Run("nil document", func(t *testing.T) {
+		t.Parallel()
+
+		var nilDoc *xbrl.Document
+		var buf bytes.Buffer
+		assert.NoError(t, nilDoc.EncodeFactsJSONWith(&buf, xbrl.JSONExportOptions{NilAsNull: true}))
+		assert.Equal(t, "", buf.String())
+	})
+}
+
+// TestEncodeFactsNDJSON verifies that each output line is a standalone
+// JSON object and that nil facts are skipped.
+func TestEncodeFactsNDJSON(t *testing.T) {
+	t.Parallel()
+
+	q := xbrl.NewQNameForTest("ex", "Revenue", "http://example.com")
+	f1 := xbrl.NewFactForTest(xbrl.FactKindItem, q, "<100>", "C1", "JPY", "0", "", "F1", "", false)
+	f2 := xbrl.NewFactForTest(xbrl.FactKindItem, q, "ignored when nil", "C2", "JPY", "", "", "F2", "", true)
+	f3 := xbrl.NewFactForTest(xbrl.FactKindItem, q, "300", "C3", "JPY", "0", "", "F3", "", false)
+	doc := xbrl.NewDocumentForTest(nil, nil, nil, []*xbrl.Fact{f1, nil, f2, f3}, nil)
+
+	var buf bytes.Buffer
+	require.NoError(t, doc.EncodeFactsNDJSON(&buf))
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 3)
+
+	var got []xbrl.FactJSON
+	for _, line := range lines {
+		var fj xbrl.FactJSON
+		require.NoError(t, json.Unmarshal([]byte(line), &fj), "line %q", line)
+		got = append(got, fj)
+	}
+	assert.Equal(t, doc.FactsAsJSONDTOs(), got)
+	assert.Contains(t, lines[0], `"<100>"`, "HTML escape is disabled")
+
+	t.Run("nil document", func(t *testing.T) {
+		t.Parallel()
+
+		var nilDoc *xbrl.Document
+		var buf bytes.Buffer
+		assert.NoError(t, nilDoc.EncodeFactsNDJSON(&buf))
+		assert.Equal(t, "", buf.String())
+	})
+}
+
+func TestEncodeContextsJSON_NilDocumentIsNoop(t *testing.T) {
+	t.Parallel()
+
+	var d *xbrl.Document
+	var buf bytes.Buffer
+	assert.NoError(t, d.EncodeContextsJSON(&buf, false))
+	assert.Equal(t, 0, buf.Len())
+	assert.Nil(t, d.ContextsAsJSONDTOs())
+}
+
+func TestEncodeContextsJSON_ExtendedInstance(t *testing.T) {
+	t.Parallel()
+
+	doc, err := xbrl.Parse(strings.NewReader(extendedInstance))
+	require.NoError(t, err)
+
+	for _, pretty := range []bool{false, true} {
+		var buf bytes.Buffer
+		require.NoError(t, doc.EncodeContextsJSON(&buf, pretty))
+
+		var got []xbrl.ContextJSON
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+		require.Len(t, got, 2)
+
+		assert.Equal(t, xbrl.ContextJSON{
+			ID:     "C1",
+			Entity: xbrl.EntityJSON{Scheme: "http://example.com/entity", Value: "ABC"},
+			Period: xbrl.PeriodJSON{Type: "duration", StartDate: "2025-01-01", EndDate: "2025-12-31"},
+			ExplicitDimensions: []xbrl.ExplicitDimensionJSON{
+				{Axis: "{http://example.com/xbrl}Region", Member: "{http://example.com/xbrl}Japan"},
+			},
+			TypedDimensions: []xbrl.TypedDimensionJSON{
+				{Axis: "{http://example.com/xbrl}Scenario", Value: "<ex:ScenarioType> Base </ex:ScenarioType>"},
+			},
+		}, got[0])
+
+		assert.Equal(t, xbrl.ContextJSON{
+			ID:                 "C2",
+			Entity:             xbrl.EntityJSON{Scheme: "http://example.com/entity", Value: "XYZ"},
+			Period:             xbrl.PeriodJSON{Type: "forever"},
+			ExplicitDimensions: []xbrl.ExplicitDimensionJSON{},
+			TypedDimensions:    []xbrl.TypedDimensionJSON{},
+		}, got[1])
+
+		// Typed member XML must not be HTML-escaped.
+		assert.NotContains(t, buf.String(), `\u003c`)
+	}
+}
+
+func TestContextsAsJSONDTOs_InstantPeriod(t *testing.T) {
+	t.Parallel()
+
+	doc, err := xbrl.Parse(strings.NewReader(minimalInstance))
+	require.NoError(t, err)
+
+	got := doc.ContextsAsJSONDTOs()
+	require.Len(t, got, 1)
+	assert.Equal(t, xbrl.PeriodJSON{Type: "instant", Instant: "2025-01-01"}, got[0].Period)
+}
+
+func TestEncodeTypedFactsJSON(t *testing.T) {
+	t.Parallel()
+
+	const ns = "http://example.com/ex"
+	concept := func(local, typ string) (xbrl.QName, *xbrl.Concept) {
+		q := xbrl.NewQNameForTest("ex", local, ns)
+		return q, xbrl.NewConceptForTest(q, "", xbrl.QName{}, xbrl.NewQNameForTest("xbrli", typ, nsXBRLI), false, true, "instant", "")
+	}
+	revenue, revenueC := concept("Revenue", "monetaryItemType")
+	ratio, ratioC := concept("Ratio", "decimalItemType")
+	flag, flagC := concept("Flag", "booleanItemType")
+	date, dateC := concept("FiscalYearEnd", "dateItemType")
+	note, noteC := concept("Note", "stringItemType")
+	unknown := xbrl.NewQNameForTest("ex", "Unknown", ns)
+
+	fact := func(q xbrl.QName, value string, isNil bool) *xbrl.Fact {
+		return xbrl.NewFactForTest(xbrl.FactKindItem, q, value, "C1", "", "", "", "", "", isNil)
+	}
+	facts := []*xbrl.Fact{
+		fact(revenue, "12345", false),
+		fact(ratio, "0.25", false),
+		fact(flag, "true", false),
+		fact(date, "2025-03-31", false),
+		fact(note, "<b>text</b>", false),
+		fact(unknown, "42", false),
+		fact(revenue, "not a number", false),
+		fact(revenue, "", true),
+		fact(ratio, " +012.50 ", false),
+		fact(ratio, "1.E3", false),
+	}
+	tax := xbrl.NewTaxonomyForTest(map[xbrl.QName]*xbrl.Concept{
+		revenue: revenueC, ratio: ratioC, flag: flagC, date: dateC, note: noteC,
+	})
+	doc := xbrl.NewDocumentForTest(nil, nil, nil, facts, tax)
+
+	var buf bytes.Buffer
+	require.NoError(t, doc.EncodeTypedFactsJSON(&buf, time.UTC, false))
+	assert.Contains(t, buf.String(), `"value":12345,`, "monetary facts are JSON numbers")
+	assert.Contains(t, buf.String(), `"value":12.50,`, "the reported digits are kept")
+	assert.Contains(t, buf.String(), `"value":1E3,`)
+	assert.Contains(t, buf.String(), `"value":true,`, "booleans are JSON booleans")
+	assert.Contains(t, buf.String(), `"<b>text</b>"`, "HTML is not escaped")
+
+	var got []map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	require.Len(t, got, len(facts))
+
+	values := make([]any, len(got))
+	for i, g := range got {
+		values[i] = g["value"]
+	}
+	assert.Equal(t, []any{
+		12345.0,
+		0.25,
+		true,
+		"2025-03-31T00:00:00Z",
+		"<b>text</b>",
+		"42",           // no concept: raw string
+		"not a number", // invalid lexical form: raw string
+		nil,
+		12.5,
+		1000.0,
+	}, values)
+	assert.Equal(t, "{http://example.com/ex}Revenue", got[0]["name"])
+	assert.Equal(t, true, got[7]["nil"])
+
+	t.Run("pretty", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		require.NoError(t, doc.EncodeTypedFactsJSON(&buf, time.UTC, true))
+		assert.Contains(t, buf.String(), "\n  {")
+	})
+
+	t.Run("without taxonomy", func(t *testing.T) {
+		t.Parallel()
+
+		plain := xbrl.NewDocumentForTest(nil, nil, nil, facts[:1], nil)
+		dtos := plain.TypedFactsAsJSONDTOs(time.UTC)
+		require.Len(t, dtos, 1)
+		assert.Equal(t, "12345", dtos[0].Value)
+	})
+
+	t.Run("nil document", func(t *testing.T) {
+		t.Parallel()
+
+		var nilDoc *xbrl.Document
+		var buf bytes.Buffer
+		require.NoError(t, nilDoc.EncodeTypedFactsJSON(&buf, time.UTC, false))
+		assert.Empty(t, buf.String())
+		assert.Nil(t, nilDoc.TypedFactsAsJSONDTOs(time.UTC))
+	})
+}
@@ -46,6 +46,12 @@ func NewDimensionForTest(dim QName, explicit bool, member QName, typedValue stri
 	}
 }
 
+func NewDimensionWithSourceForTest(dim QName, explicit bool, member QName, typedValue string, source DimensionSource) Dimension {
+	d := NewDimensionForTest(dim, explicit, member, typedValue)
+	d.source = source
+	return d
+}
+
 func NewContextForTest(id string, entity Entity, period Period, dims []Dimension) *Context {
 	return &Context{
 		id:         id,
@@ -132,13 +138,21 @@ func NewDocumentForTest(
 	facts []*Fact,
 	tax *Taxonomy,
 ) *Document {
-	return &Document{
+	d := &Document{
 		schemaRefs: schemaRefs,
 		contexts:   contexts,
 		units:      units,
 		facts:      facts,
 		taxonomy:   tax,
 	}
+	d.refreshHasDimensions()
+	return d
 }
 
 var NormalizeSpace = normalizeSpace
+
+var ParseXSDBool = parseXSDBool
+
+func SetFactValueForTest(f *Fact, value string) {
+	f.value = value
+}
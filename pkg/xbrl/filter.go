@@ -1,19 +1,43 @@
 package xbrl
 
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
 // FactFilter describes criteria to filter facts.
 //
 // All fields are unexported and should be configured via the builder-style
-// methods (ConceptURI, ConceptLocal, ContextID, UnitID, OnlyNil, ExcludeNil, Dimension).
+// methods (ConceptURI, ConceptLocal, ConceptLocalRegexp, ContextID, UnitID,
+// HasUnit, NoUnit, OnlyNil, ExcludeNil, DecimalsAtLeast, Dimension,
+// DimensionWithDefault, ValueMatches, ValueEquals, ValueContains).
 type FactFilter struct {
 	conceptURI   string
 	conceptLocal string
+	conceptRe    *regexp.Regexp
 	contextID    string
 	unitID       string
+	unitFilter   *bool
 	nilFilter    *bool
 
+	// decimalsMin is the minimum decimals value a fact must report.
+	// nil means no decimals requirement.
+	decimalsMin *int
+
+	// valuePreds are predicates on the raw fact value.
+	// A fact matches only if all of them return true.
+	valuePreds []func(raw string) bool
+
+	// err records the first error from a builder method (e.g. an invalid
+	// regular expression).
+	err error
+
 	// dims holds required explicit dimensions.
 	// A fact matches only if its context has *all* of these
-	// dimension/member pairs as explicit dimensions.
+	// dimension/member pairs as explicit dimensions (or omits the
+	// dimension, for requirements on a default member).
 	dims []dimensionFilter
 }
 
@@ -21,6 +45,10 @@ type FactFilter struct {
 type dimensionFilter struct {
 	dimURI, dimLocal string
 	memURI, memLocal string
+
+	// isDefault reports whether the member is the default member of the
+	// dimension, so that contexts omitting the dimension also match.
+	isDefault bool
 }
 
 // NewFactFilter creates an empty fact filter.
@@ -46,6 +74,34 @@ func (f *FactFilter) ConceptLocal(local string) *FactFilter {
 	return f
 }
 
+// ConceptLocalRegexp sets a regular expression that the fact concept's
+// local name must match.
+//
+// If pattern does not compile, the error is recorded and can be retrieved
+// with Err; FilterFacts then matches no facts.
+func (f *FactFilter) ConceptLocalRegexp(pattern string) *FactFilter {
+	if f == nil {
+		return nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		if f.err == nil {
+			f.err = fmt.Errorf("xbrl: invalid concept pattern %q: %w", pattern, err)
+		}
+		return f
+	}
+	f.conceptRe = re
+	return f
+}
+
+// Err returns the first error recorded while building the filter, if any.
+func (f *FactFilter) Err() error {
+	if f == nil {
+		return nil
+	}
+	return f.err
+}
+
 // ContextID sets the expected context ID for the fact.
 func (f *FactFilter) ContextID(id string) *FactFilter {
 	if f == nil {
@@ -64,6 +120,26 @@ func (f *FactFilter) UnitID(id string) *FactFilter {
 	return f
 }
 
+// HasUnit filters for facts that have a unitRef.
+func (f *FactFilter) HasUnit() *FactFilter {
+	if f == nil {
+		return nil
+	}
+	v := true
+	f.unitFilter = &v
+	return f
+}
+
+// NoUnit filters for facts without a unitRef.
+func (f *FactFilter) NoUnit() *FactFilter {
+	if f == nil {
+		return nil
+	}
+	v := false
+	f.unitFilter = &v
+	return f
+}
+
 // OnlyNil filters for xsi:nil="true".
 func (f *FactFilter) OnlyNil() *FactFilter {
 	if f == nil {
@@ -84,6 +160,17 @@ func (f *FactFilter) ExcludeNil() *FactFilter {
 	return f
 }
 
+// DecimalsAtLeast filters for facts whose decimals attribute is at least n.
+// decimals="INF" is treated as greater than any n. Facts without a
+// decimals attribute, or with an invalid one, do not match.
+func (f *FactFilter) DecimalsAtLeast(n int) *FactFilter {
+	if f == nil {
+		return nil
+	}
+	f.decimalsMin = &n
+	return f
+}
+
 // Dimension adds an explicit dimension requirement to the filter.
 //
 // A fact matches the filter only if its context contains an explicit
@@ -92,19 +179,54 @@ func (f *FactFilter) ExcludeNil() *FactFilter {
 //
 // Prefixes of the given QNames are ignored for comparison.
 func (f *FactFilter) Dimension(dim, member QName) *FactFilter {
+	return f.DimensionWithDefault(dim, member, false)
+}
+
+// DimensionWithDefault is like Dimension, but if isDefault is true the
+// member is treated as the default member of dim: in XBRL Dimensions an
+// omitted dimension implies its default member, so facts whose context
+// has no explicit member for dim match as well.
+//
+// Whether a member is the default can be looked up with
+// Taxonomy.DimensionDefault once a definition linkbase is loaded.
+func (f *FactFilter) DimensionWithDefault(dim, member QName, isDefault bool) *FactFilter {
 	if f == nil {
 		return nil
 	}
 	df := dimensionFilter{
-		dimURI:   dim.URI(),
-		dimLocal: dim.Local(),
-		memURI:   member.URI(),
-		memLocal: member.Local(),
+		dimURI:    dim.URI(),
+		dimLocal:  dim.Local(),
+		memURI:    member.URI(),
+		memLocal:  member.Local(),
+		isDefault: isDefault,
 	}
 	f.dims = append(f.dims, df)
 	return f
 }
 
+// ValueMatches adds a predicate on the raw fact value (Value()).
+//
+// Multiple value predicates are combined with AND. A nil predicate is ignored.
+func (f *FactFilter) ValueMatches(pred func(raw string) bool) *FactFilter {
+	if f == nil {
+		return nil
+	}
+	if pred != nil {
+		f.valuePreds = append(f.valuePreds, pred)
+	}
+	return f
+}
+
+// ValueEquals requires the raw fact value to equal s.
+func (f *FactFilter) ValueEquals(s string) *FactFilter {
+	return f.ValueMatches(func(raw string) bool { return raw == s })
+}
+
+// ValueContains requires the raw fact value to contain substr.
+func (f *FactFilter) ValueContains(substr string) *FactFilter {
+	return f.ValueMatches(func(raw string) bool { return strings.Contains(raw, substr) })
+}
+
 // FilterFacts returns a slice of facts that match the given filter.
 //
 // The returned slice is a shallow copy and can be modified by the caller
@@ -113,80 +235,120 @@ func (f *FactFilter) Dimension(dim, member QName) *FactFilter {
 // Note: dimension filters (added via Dimension) are evaluated against
 // explicit dimensions on the fact's context. Typed dimensions are
 // currently ignored for filtering.
+//
+// If the filter has a recorded error (see FactFilter.Err), nil is returned.
 func (d *Document) FilterFacts(f *FactFilter) []*Fact {
-	if d == nil || f == nil {
+	if d == nil || f == nil || f.err != nil {
 		return nil
 	}
+	// Without dimensional contexts no fact can satisfy a dimension filter,
+	// unless every requirement is on a default member.
+	if !d.hasDimensions && slices.ContainsFunc(f.dims, func(df dimensionFilter) bool { return !df.isDefault }) {
+		return []*Fact{}
+	}
 	var result []*Fact
 	for _, fact := range d.facts {
-		if fact == nil {
+		if fact == nil || !d.matchFact(f, fact) {
 			continue
 		}
+		result = append(result, fact)
+	}
 
-		// Concept filter
-		if f.conceptLocal != "" || f.conceptURI != "" {
-			q := fact.Name()
-			if f.conceptLocal != "" && q.Local() != f.conceptLocal {
-				continue
-			}
-			if f.conceptURI != "" && q.URI() != f.conceptURI {
-				continue
-			}
-		}
+	out := make([]*Fact, len(result))
+	copy(out, result)
+	return out
+}
 
-		// Context filter (by ID)
-		if f.contextID != "" && fact.ContextRef() != f.contextID {
-			continue
+// matchFact reports whether fact satisfies all criteria of f.
+func (d *Document) matchFact(f *FactFilter, fact *Fact) bool {
+	// Concept filter
+	if f.conceptLocal != "" || f.conceptURI != "" {
+		q := fact.Name()
+		if f.conceptLocal != "" && q.Local() != f.conceptLocal {
+			return false
+		}
+		if f.conceptURI != "" && q.URI() != f.conceptURI {
+			return false
 		}
+	}
+	if f.conceptRe != nil && !f.conceptRe.MatchString(fact.Name().Local()) {
+		return false
+	}
 
-		// Unit filter
-		if f.unitID != "" && fact.UnitRef() != f.unitID {
-			continue
+	// Context filter (by ID)
+	if f.contextID != "" && fact.ContextRef() != f.contextID {
+		return false
+	}
+
+	// Unit filter
+	if f.unitID != "" && fact.UnitRef() != f.unitID {
+		return false
+	}
+
+	// Unit presence filter
+	if f.unitFilter != nil && (fact.UnitRef() != "") != *f.unitFilter {
+		return false
+	}
+
+	// Nil filter
+	if f.nilFilter != nil && fact.IsNil() != *f.nilFilter {
+		return false
+	}
+
+	// Decimals filter
+	if f.decimalsMin != nil {
+		v, inf, present, err := ParseDecimals(fact.Decimals())
+		if err != nil || !present || (!inf && v < *f.decimalsMin) {
+			return false
 		}
+	}
 
-		// Nil filter
-		if f.nilFilter != nil && fact.IsNil() != *f.nilFilter {
-			continue
+	// Value predicates
+	if !matchValue(f.valuePreds, fact.Value()) {
+		return false
+	}
+
+	// Dimension filters (explicit-only for now)
+	if len(f.dims) > 0 {
+		ctx, ok := d.contexts[fact.ContextRef()]
+		if !ok || ctx == nil {
+			return false
 		}
+		// We can use ctx.dimensions directly here since we're in the same package.
+		ctxDims := ctx.dimensions
 
-		// Dimension filters (explicit-only for now)
-		if len(f.dims) > 0 {
-			ctx, ok := d.contexts[fact.ContextRef()]
-			if !ok || ctx == nil {
-				continue
-			}
-			// We can use ctx.dimensions directly here since we're in the same package.
-			ctxDims := ctx.dimensions
-
-			matchAll := true
-			for _, df := range f.dims {
-				found := false
-				for _, cd := range ctxDims {
-					if !cd.explicit {
-						continue
-					}
-					dq := cd.dimension
-					mq := cd.member
-					if dq.uri == df.dimURI && dq.local == df.dimLocal &&
-						mq.uri == df.memURI && mq.local == df.memLocal {
-						found = true
-						break
-					}
+		for _, df := range f.dims {
+			found, present := false, false
+			for _, cd := range ctxDims {
+				if !cd.explicit {
+					continue
+				}
+				dq := cd.dimension
+				mq := cd.member
+				if dq.uri != df.dimURI || dq.local != df.dimLocal {
+					continue
 				}
-				if !found {
-					matchAll = false
+				present = true
+				if mq.uri == df.memURI && mq.local == df.memLocal {
+					found = true
 					break
 				}
 			}
-			if !matchAll {
-				continue
+			if !found && (present || !df.isDefault) {
+				return false
 			}
 		}
-
-		result = append(result, fact)
 	}
 
-	out := make([]*Fact, len(result))
-	copy(out, result)
-	return out
+	return true
+}
+
+// matchValue reports whether raw satisfies all predicates.
+func matchValue(preds []func(raw string) bool, raw string) bool {
+	for _, p := range preds {
+		if !p(raw) {
+			return false
+		}
+	}
+	return true
 }
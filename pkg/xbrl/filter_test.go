@@ -1,10 +1,12 @@
 package xbrl_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/aethiopicuschan/xbrl-go/pkg/xbrl"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // Test that builder-style methods are safe on nil receiver and return nil.
@@ -43,10 +45,42 @@ func TestFactFilter_NilReceiver(t *testing.T) {
 			name: "ExcludeNil on nil",
 			call: func() *xbrl.FactFilter { return f.ExcludeNil() },
 		},
+		{
+			name: "HasUnit on nil",
+			call: func() *xbrl.FactFilter { return f.HasUnit() },
+		},
+		{
+			name: "NoUnit on nil",
+			call: func() *xbrl.FactFilter { return f.NoUnit() },
+		},
+		{
+			name: "DecimalsAtLeast on nil",
+			call: func() *xbrl.FactFilter { return f.DecimalsAtLeast(0) },
+		},
 		{
 			name: "Dimension on nil",
 			call: func() *xbrl.FactFilter { return f.Dimension(dim, mem) },
 		},
+		{
+			name: "DimensionWithDefault on nil",
+			call: func() *xbrl.FactFilter { return f.DimensionWithDefault(dim, mem, true) },
+		},
+		{
+			name: "ConceptLocalRegexp on nil",
+			call: func() *xbrl.FactFilter { return f.ConceptLocalRegexp("^Rev") },
+		},
+		{
+			name: "ValueMatches on nil",
+			call: func() *xbrl.FactFilter { return f.ValueMatches(func(string) bool { return true }) },
+		},
+		{
+			name: "ValueEquals on nil",
+			call: func() *xbrl.FactFilter { return f.ValueEquals("v") },
+		},
+		{
+			name: "ValueContains on nil",
+			call: func() *xbrl.FactFilter { return f.ValueContains("v") },
+		},
 	}
 
 	for _, tt := range tests {
@@ -362,3 +396,379 @@ func TestDocument_FilterFacts_ReturnsCopy(t *testing.T) {
 	assert.Equal(t, f1, second[0])
 	assert.Equal(t, f2, second[1])
 }
+
+func TestDocument_FilterFacts_DimensionlessFastPath(t *testing.T) {
+	t.Parallel()
+
+	concept := xbrl.NewQNameForTest("ex", "Revenue", "http://example.com/ex")
+	region := xbrl.NewQNameForTest("ex", "Region", "http://example.com/ex")
+	japan := xbrl.NewQNameForTest("ex", "Japan", "http://example.com/ex")
+
+	plainDoc, err := xbrl.Parse(strings.NewReader(minimalInstance))
+	require.NoError(t, err)
+	dimDoc, err := xbrl.Parse(strings.NewReader(extendedInstance))
+	require.NoError(t, err)
+
+	// Documents built without contexts behave like dimensionless ones.
+	emptyCtxDoc := xbrl.NewDocumentForTest(nil, nil, nil, []*xbrl.Fact{
+		xbrl.NewFactForTest(xbrl.FactKindItem, concept, "1", "C1", "", "", "", "", "", false),
+	}, nil)
+
+	tests := []struct {
+		name   string
+		doc    *xbrl.Document
+		filter *xbrl.FactFilter
+		want   []string
+	}{
+		{
+			name:   "dimensionless document without dimension filter",
+			doc:    plainDoc,
+			filter: xbrl.NewFactFilter().ConceptLocal("Revenue"),
+			want:   []string{"12345"},
+		},
+		{
+			name:   "dimensionless document with dimension filter",
+			doc:    plainDoc,
+			filter: xbrl.NewFactFilter().ConceptLocal("Revenue").Dimension(region, japan),
+			want:   []string{},
+		},
+		{
+			name:   "document without contexts with dimension filter",
+			doc:    emptyCtxDoc,
+			filter: xbrl.NewFactFilter().Dimension(region, japan),
+			want:   []string{},
+		},
+		{
+			name:   "dimensional document without dimension filter",
+			doc:    dimDoc,
+			filter: xbrl.NewFactFilter().ConceptLocal("Revenue"),
+			want:   []string{"12345"},
+		},
+		{
+			name: "dimensional document with dimension filter",
+			doc:  dimDoc,
+			filter: xbrl.NewFactFilter().ConceptLocal("Revenue").Dimension(
+				xbrl.NewQNameForTest("ex", "Region", "http://example.com/xbrl"),
+				xbrl.NewQNameForTest("ex", "Japan", "http://example.com/xbrl"),
+			),
+			want: []string{"12345"},
+		},
+		{
+			name:   "cloned dimensional document keeps dimensions",
+			doc:    dimDoc.Clone(),
+			filter: xbrl.NewFactFilter().Dimension(xbrl.NewQNameForTest("ex", "Region", "http://example.com/xbrl"), xbrl.NewQNameForTest("ex", "Japan", "http://example.com/xbrl")),
+			want:   []string{"12345", ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := tt.doc.FilterFacts(tt.filter)
+			require.NotNil(t, got)
+			values := make([]string, 0, len(got))
+			for _, f := range got {
+				values = append(values, f.Value())
+			}
+			assert.Equal(t, tt.want, values)
+		})
+	}
+}
+
+func TestDocument_FilterFacts_ValuePredicates(t *testing.T) {
+	t.Parallel()
+
+	q := xbrl.NewQNameForTest("p", "x", "urn:a")
+	f1 := xbrl.NewFactForTest(xbrl.FactKindItem, q, "Tokyo Head Office", "C1", "", "", "", "F1", "", false)
+	f2 := xbrl.NewFactForTest(xbrl.FactKindItem, q, "Osaka Branch Office", "C1", "", "", "", "F2", "", false)
+	f3 := xbrl.NewFactForTest(xbrl.FactKindItem, q, "Tokyo", "C2", "", "", "", "F3", "", false)
+
+	doc := xbrl.NewDocumentForTest(nil, nil, nil, []*xbrl.Fact{f1, f2, f3}, nil)
+
+	tests := []struct {
+		name   string
+		filter *xbrl.FactFilter
+		want   []*xbrl.Fact
+	}{
+		{
+			name:   "ValueContains",
+			filter: xbrl.NewFactFilter().ValueContains("Office"),
+			want:   []*xbrl.Fact{f1, f2},
+		},
+		{
+			name:   "ValueEquals",
+			filter: xbrl.NewFactFilter().ValueEquals("Tokyo"),
+			want:   []*xbrl.Fact{f3},
+		},
+		{
+			name:   "multiple predicates are ANDed",
+			filter: xbrl.NewFactFilter().ValueContains("Tokyo").ValueContains("Office"),
+			want:   []*xbrl.Fact{f1},
+		},
+		{
+			name: "ValueMatches with custom predicate and context",
+			filter: xbrl.NewFactFilter().
+				ContextID("C1").
+				ValueMatches(func(raw string) bool { return len(raw) > 17 }),
+			want: []*xbrl.Fact{f2},
+		},
+		{
+			name:   "nil predicate is ignored",
+			filter: xbrl.NewFactFilter().ValueMatches(nil),
+			want:   []*xbrl.Fact{f1, f2, f3},
+		},
+		{
+			name:   "no match",
+			filter: xbrl.NewFactFilter().ValueContains("Nagoya"),
+			want:   []*xbrl.Fact{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, doc.FilterFacts(tt.filter))
+		})
+	}
+}
+
+func TestDocument_FilterFacts_ConceptLocalRegexp(t *testing.T) {
+	t.Parallel()
+
+	mk := func(local string) *xbrl.Fact {
+		q := xbrl.NewQNameForTest("p", local, "urn:a")
+		return xbrl.NewFactForTest(xbrl.FactKindItem, q, "1", "C1", "", "", "", "", "", false)
+	}
+	revenue := mk("Revenue")
+	revenueJP := mk("RevenueJapan")
+	netRevenue := mk("NetRevenue")
+	cost := mk("Cost")
+
+	doc := xbrl.NewDocumentForTest(nil, nil, nil, []*xbrl.Fact{revenue, revenueJP, netRevenue, cost}, nil)
+
+	tests := []struct {
+		name    string
+		filter  *xbrl.FactFilter
+		want    []*xbrl.Fact
+		wantErr bool
+	}{
+		{
+			name:   "prefix match",
+			filter: xbrl.NewFactFilter().ConceptLocalRegexp("^Rev"),
+			want:   []*xbrl.Fact{revenue, revenueJP},
+		},
+		{
+			name:   "unanchored match",
+			filter: xbrl.NewFactFilter().ConceptLocalRegexp("Revenue"),
+			want:   []*xbrl.Fact{revenue, revenueJP, netRevenue},
+		},
+		{
+			name:   "combined with ConceptLocal",
+			filter: xbrl.NewFactFilter().ConceptLocalRegexp("^Rev").ConceptLocal("Revenue"),
+			want:   []*xbrl.Fact{revenue},
+		},
+		{
+			name:    "invalid pattern is surfaced via Err",
+			filter:  xbrl.NewFactFilter().ConceptLocalRegexp("(Rev"),
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if tt.wantErr {
+				assert.ErrorContains(t, tt.filter.Err(), "xbrl: invalid concept pattern")
+			} else {
+				assert.NoError(t, tt.filter.Err())
+			}
+			assert.Equal(t, tt.want, doc.FilterFacts(tt.filter))
+		})
+	}
+
+	var nilFilter *xbrl.FactFilter
+	assert.NoError(t, nilFilter.Err())
+}
+
+func TestDocument_FilterFacts_DecimalsAndUnit(t *testing.T) {
+	t.Parallel()
+
+	mk := func(unitRef, decimals string) *xbrl.Fact {
+		q := xbrl.NewQNameForTest("p", "x", "urn:a")
+		return xbrl.NewFactForTest(xbrl.FactKindItem, q, "1", "C1", unitRef, decimals, "", "", "", false)
+	}
+	millions := mk("U1", "-6")
+	units := mk("U1", "0")
+	cents := mk("U1", "2")
+	exact := mk("U1", "INF")
+	noDecimals := mk("U1", "")
+	invalid := mk("U1", "abc")
+	text := mk("", "")
+
+	doc := xbrl.NewDocumentForTest(nil, nil, nil,
+		[]*xbrl.Fact{millions, units, cents, exact, noDecimals, invalid, text}, nil)
+
+	tests := []struct {
+		name   string
+		filter *xbrl.FactFilter
+		want   []*xbrl.Fact
+	}{
+		{
+			name:   "decimals at least zero",
+			filter: xbrl.NewFactFilter().DecimalsAtLeast(0),
+			want:   []*xbrl.Fact{units, cents, exact},
+		},
+		{
+			name:   "negative threshold",
+			filter: xbrl.NewFactFilter().DecimalsAtLeast(-6),
+			want:   []*xbrl.Fact{millions, units, cents, exact},
+		},
+		{
+			name:   "INF exceeds any threshold",
+			filter: xbrl.NewFactFilter().DecimalsAtLeast(100),
+			want:   []*xbrl.Fact{exact},
+		},
+		{
+			name:   "has unit",
+			filter: xbrl.NewFactFilter().HasUnit(),
+			want:   []*xbrl.Fact{millions, units, cents, exact, noDecimals, invalid},
+		},
+		{
+			name:   "no unit",
+			filter: xbrl.NewFactFilter().NoUnit(),
+			want:   []*xbrl.Fact{text},
+		},
+		{
+			name:   "NoUnit overrides HasUnit when chained last",
+			filter: xbrl.NewFactFilter().HasUnit().NoUnit(),
+			want:   []*xbrl.Fact{text},
+		},
+		{
+			name:   "decimals combined with unit",
+			filter: xbrl.NewFactFilter().HasUnit().DecimalsAtLeast(2),
+			want:   []*xbrl.Fact{cents, exact},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, doc.FilterFacts(tt.filter))
+		})
+	}
+}
+
+func TestDocument_FilterFacts_DimensionDefault(t *testing.T) {
+	t.Parallel()
+
+	const ns = "http://example.com/ex"
+	region := xbrl.NewQNameForTest("ex", "Region", ns)
+	allRegions := xbrl.NewQNameForTest("ex", "AllRegions", ns)
+	japan := xbrl.NewQNameForTest("ex", "Japan", ns)
+	product := xbrl.NewQNameForTest("ex", "Product", ns)
+	widget := xbrl.NewQNameForTest("ex", "Widget", ns)
+
+	const src = `<xbrli:xbrl xmlns:xbrli="http://www.xbrl.org/2003/instance"
+    xmlns:xbrldi="http://xbrl.org/2006/xbrldi" xmlns:ex="http://example.com/ex">
+  <xbrli:context id="Total">
+    <xbrli:entity><xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:instant>2025-12-31</xbrli:instant></xbrli:period>
+  </xbrli:context>
+  <xbrli:context id="Japan">
+    <xbrli:entity><xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:instant>2025-12-31</xbrli:instant></xbrli:period>
+    <xbrli:scenario><xbrldi:explicitMember dimension="ex:Region">ex:Japan</xbrldi:explicitMember></xbrli:scenario>
+  </xbrli:context>
+  <xbrli:context id="Widget">
+    <xbrli:entity><xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:instant>2025-12-31</xbrli:instant></xbrli:period>
+    <xbrli:scenario><xbrldi:explicitMember dimension="ex:Product">ex:Widget</xbrldi:explicitMember></xbrli:scenario>
+  </xbrli:context>
+  <ex:Sales contextRef="Total">100</ex:Sales>
+  <ex:Sales contextRef="Japan">60</ex:Sales>
+  <ex:Sales contextRef="Widget">30</ex:Sales>
+</xbrli:xbrl>`
+
+	const definition = `<link:linkbase xmlns:link="http://www.xbrl.org/2003/linkbase" xmlns:xlink="http://www.w3.org/1999/xlink">
+  <link:definitionLink xlink:type="extended" xlink:role="http://www.xbrl.org/2003/role/link">
+    <link:loc xlink:type="locator" xlink:href="ex.xsd#ex_Region" xlink:label="Region"/>
+    <link:loc xlink:type="locator" xlink:href="ex.xsd#ex_AllRegions" xlink:label="AllRegions"/>
+    <link:definitionArc xlink:type="arc" xlink:arcrole="http://xbrl.org/int/dim/arcrole/dimension-default"
+        xlink:from="Region" xlink:to="AllRegions"/>
+  </link:definitionLink>
+</link:linkbase>`
+
+	doc, err := xbrl.Parse(strings.NewReader(src))
+	require.NoError(t, err)
+	tax := xbrl.NewTaxonomyForTest(map[xbrl.QName]*xbrl.Concept{
+		region:     xbrl.NewConceptForTest(region, "ex_Region", xbrl.QName{}, xbrl.QName{}, false, false, "", ""),
+		allRegions: xbrl.NewConceptForTest(allRegions, "ex_AllRegions", xbrl.QName{}, xbrl.QName{}, false, false, "", ""),
+	})
+	require.NoError(t, tax.ParseLinkbase(strings.NewReader(definition), xbrl.LinkbaseDefinition))
+
+	def, ok := tax.DimensionDefault(region)
+	require.True(t, ok)
+	assert.Equal(t, "AllRegions", def.Local())
+	_, ok = tax.DimensionDefault(product)
+	assert.False(t, ok)
+	var nilTax *xbrl.Taxonomy
+	_, ok = nilTax.DimensionDefault(region)
+	assert.False(t, ok)
+
+	isDefault := func(dim, member xbrl.QName) bool {
+		d, ok := tax.DimensionDefault(dim)
+		return ok && d.URI() == member.URI() && d.Local() == member.Local()
+	}
+
+	tests := []struct {
+		name   string
+		filter *xbrl.FactFilter
+		want   []string
+	}{
+		{
+			name:   "default member matches contexts omitting the axis",
+			filter: xbrl.NewFactFilter().DimensionWithDefault(region, allRegions, isDefault(region, allRegions)),
+			want:   []string{"100", "30"},
+		},
+		{
+			name:   "default member without default awareness",
+			filter: xbrl.NewFactFilter().Dimension(region, allRegions),
+			want:   nil,
+		},
+		{
+			name:   "non-default member requires the axis",
+			filter: xbrl.NewFactFilter().DimensionWithDefault(region, japan, isDefault(region, japan)),
+			want:   []string{"60"},
+		},
+		{
+			name: "combined with another dimension",
+			filter: xbrl.NewFactFilter().
+				DimensionWithDefault(region, allRegions, true).
+				Dimension(product, widget),
+			want: []string{"30"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got []string
+			for _, f := range doc.FilterFacts(tt.filter) {
+				got = append(got, f.Value())
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("dimensionless document", func(t *testing.T) {
+		t.Parallel()
+
+		plain, err := xbrl.Parse(strings.NewReader(minimalInstance))
+		require.NoError(t, err)
+		assert.Len(t, plain.FilterFacts(xbrl.NewFactFilter().DimensionWithDefault(region, allRegions, true)), 1)
+		assert.Empty(t, plain.FilterFacts(xbrl.NewFactFilter().DimensionWithDefault(region, japan, false)))
+	})
+}
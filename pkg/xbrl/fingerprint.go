@@ -0,0 +1,55 @@
+package xbrl
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Fingerprint returns a stable SHA-256 digest of the content of the
+// document, as a hex string, for caching and change detection.
+//
+// The digest covers the schemaRef hrefs, the linkbaseRef hrefs and roles,
+// the contexts (by Signature), the units (by their measures) and the facts
+// (by concept namespace URI and local name, context and unit content,
+// value, decimals, precision, xml:lang and nil flag). Footnotes are not
+// covered. IDs, namespace prefixes and the order of elements do not affect
+// it, so documents that differ only in those have the same fingerprint. A
+// nil document has an empty fingerprint.
+func (d *Document) Fingerprint() string {
+	if d == nil {
+		return ""
+	}
+
+	var lines []string
+	for _, sr := range d.schemaRefs {
+		lines = append(lines, fmt.Sprintf("schemaRef %q", sr.href))
+	}
+	for _, lr := range d.linkbaseRefs {
+		lines = append(lines, fmt.Sprintf("linkbaseRef %q %q", lr.href, lr.role))
+	}
+	for _, c := range d.contexts {
+		if c != nil {
+			lines = append(lines, fmt.Sprintf("context %q", c.Signature()))
+		}
+	}
+	for _, u := range d.units {
+		if u != nil {
+			lines = append(lines, fmt.Sprintf("unit %q", u.signature()))
+		}
+	}
+	for _, f := range d.facts {
+		if f == nil {
+			continue
+		}
+		k := d.reconcileKey(f)
+		lines = append(lines, fmt.Sprintf("fact %q %q %q %q %q %q %q %q %t",
+			k.uri, k.local, k.signature, d.unitKey(f), f.value, f.decimals, f.precision, f.lang, f.nil))
+	}
+	slices.Sort(lines)
+
+	sum := sha256.Sum256([]byte(strings.Join(lines, "\n")))
+	return hex.EncodeToString(sum[:])
+}
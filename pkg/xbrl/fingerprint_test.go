@@ -0,0 +1,94 @@
+package xbrl_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aethiopicuschan/xbrl-go/pkg/xbrl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocument_Fingerprint(t *testing.T) {
+	t.Parallel()
+
+	parse := func(src string) *xbrl.Document {
+		t.Helper()
+		doc, err := xbrl.Parse(strings.NewReader(src))
+		require.NoError(t, err)
+		return doc
+	}
+
+	const costFact = `<ex:Cost contextRef="US" unitRef="JPY" decimals="0">1</ex:Cost>`
+	require.Contains(t, regionInstance, costFact)
+
+	base := parse(regionInstance)
+	fp := base.Fingerprint()
+	assert.Len(t, fp, 64)
+
+	tests := []struct {
+		name  string
+		src   string
+		equal bool
+	}{
+		{name: "same input", src: regionInstance, equal: true},
+		{
+			name:  "renamed context ID",
+			src:   strings.ReplaceAll(regionInstance, `"Total"`, `"C0"`),
+			equal: true,
+		},
+		{
+			name:  "renamed prefix",
+			src:   strings.ReplaceAll(strings.ReplaceAll(regionInstance, "ex:", "e:"), "xmlns:ex=", "xmlns:e="),
+			equal: true,
+		},
+		{
+			name: "reordered facts",
+			src: strings.Replace(
+				strings.Replace(regionInstance, costFact, "", 1),
+				`<ex:Revenue contextRef="Total"`, costFact+`<ex:Revenue contextRef="Total"`, 1),
+			equal: true,
+		},
+		{
+			name:  "added fact",
+			src:   strings.Replace(regionInstance, costFact, costFact+costFact, 1),
+			equal: false,
+		},
+		{
+			name:  "changed fact value",
+			src:   strings.Replace(regionInstance, ">600<", ">601<", 1),
+			equal: false,
+		},
+		{
+			name:  "changed decimals",
+			src:   strings.Replace(regionInstance, `decimals="0">600<`, `decimals="-3">600<`, 1),
+			equal: false,
+		},
+		{
+			name:  "decimals replaced by precision",
+			src:   strings.Replace(regionInstance, `decimals="0">600<`, `precision="3">600<`, 1),
+			equal: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := parse(tt.src).Fingerprint()
+			if tt.equal {
+				assert.Equal(t, fp, got)
+			} else {
+				assert.NotEqual(t, fp, got)
+			}
+		})
+	}
+
+	t.Run("clone and nil", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, fp, base.Clone().Fingerprint())
+
+		var nilDoc *xbrl.Document
+		assert.Equal(t, "", nilDoc.Fingerprint())
+	})
+}
@@ -0,0 +1,98 @@
+package xbrl
+
+// FlattenOptions configures Document.FlattenFacts.
+type FlattenOptions struct {
+	// NormalizeValues uses Fact.NormalizedValue instead of the raw value.
+	NormalizeValues bool
+
+	// Dimensions lists the axes whose columns are included, compared by
+	// namespace URI and local name. If nil, all axes are included; an
+	// empty non-nil slice includes none.
+	Dimensions []QName
+}
+
+// FlattenFacts converts the facts of the document into flat rows for
+// generic tabular sinks such as databases or spreadsheets.
+//
+// Each row has the keys "concept", "value", "context", "unit",
+// "period_start", "period_end", "entity" and "entity_scheme", plus one
+// key per dimension axis of the fact's context, named by the axis
+// QName's String(). Explicit members are rendered with QName.String()
+// and typed members with their raw value. Instant periods set both
+// period_start and period_end to the instant; forever periods leave them
+// empty. Nil facts have an empty value, and facts whose context is
+// missing have empty context-derived columns.
+//
+// Rows are in document order; nil facts are skipped.
+func (d *Document) FlattenFacts(opts FlattenOptions) []map[string]string {
+	if d == nil {
+		return nil
+	}
+
+	var include map[conceptKey]struct{}
+	if opts.Dimensions != nil {
+		include = make(map[conceptKey]struct{}, len(opts.Dimensions))
+		for _, q := range opts.Dimensions {
+			include[conceptKey{q.uri, q.local}] = struct{}{}
+		}
+	}
+
+	out := make([]map[string]string, 0, len(d.facts))
+	for _, f := range d.facts {
+		if f == nil {
+			continue
+		}
+
+		value := f.value
+		switch {
+		case f.nil:
+			value = ""
+		case opts.NormalizeValues:
+			value = f.NormalizedValue()
+		}
+
+		row := map[string]string{
+			"concept":       f.name.String(),
+			"value":         value,
+			"context":       f.contextRef,
+			"unit":          f.unitRef,
+			"period_start":  "",
+			"period_end":    "",
+			"entity":        "",
+			"entity_scheme": "",
+		}
+
+		if ctx, ok := d.contexts[f.contextRef]; ok && ctx != nil {
+			row["entity"] = ctx.entity.identifier.value
+			row["entity_scheme"] = ctx.entity.identifier.scheme
+
+			p := ctx.period
+			if p.instant != nil {
+				row["period_start"] = *p.instant
+				row["period_end"] = *p.instant
+			}
+			if p.startDate != nil {
+				row["period_start"] = *p.startDate
+			}
+			if p.endDate != nil {
+				row["period_end"] = *p.endDate
+			}
+
+			for _, dim := range ctx.dimensions {
+				if include != nil {
+					if _, ok := include[conceptKey{dim.dimension.uri, dim.dimension.local}]; !ok {
+						continue
+					}
+				}
+				if dim.explicit {
+					row[dim.dimension.String()] = dim.member.String()
+				} else {
+					row[dim.dimension.String()] = dim.typedValue
+				}
+			}
+		}
+
+		out = append(out, row)
+	}
+	return out
+}
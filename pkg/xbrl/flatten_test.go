@@ -0,0 +1,126 @@
+package xbrl_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aethiopicuschan/xbrl-go/pkg/xbrl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocument_FlattenFacts(t *testing.T) {
+	t.Parallel()
+
+	doc, err := xbrl.Parse(strings.NewReader(extendedInstance))
+	require.NoError(t, err)
+
+	const (
+		ns       = "http://example.com/xbrl"
+		region   = "{" + ns + "}Region"
+		scenario = "{" + ns + "}Scenario"
+	)
+
+	base := map[string]string{
+		"concept":       "{" + ns + "}Revenue",
+		"value":         "12345",
+		"context":       "C1",
+		"unit":          "U1",
+		"period_start":  "2025-01-01",
+		"period_end":    "2025-12-31",
+		"entity":        "ABC",
+		"entity_scheme": "http://example.com/entity",
+	}
+	with := func(extra map[string]string) map[string]string {
+		out := make(map[string]string, len(base)+len(extra))
+		for k, v := range base {
+			out[k] = v
+		}
+		for k, v := range extra {
+			out[k] = v
+		}
+		return out
+	}
+
+	tests := []struct {
+		name    string
+		opts    xbrl.FlattenOptions
+		wantRow map[string]string
+	}{
+		{
+			name: "all dimensions",
+			opts: xbrl.FlattenOptions{},
+			wantRow: with(map[string]string{
+				region:   "{" + ns + "}Japan",
+				scenario: "<ex:ScenarioType> Base </ex:ScenarioType>",
+			}),
+		},
+		{
+			name: "selected dimension only",
+			opts: xbrl.FlattenOptions{
+				Dimensions: []xbrl.QName{xbrl.NewQNameForTest("other", "Region", ns)},
+			},
+			wantRow: with(map[string]string{region: "{" + ns + "}Japan"}),
+		},
+		{
+			name:    "no dimensions",
+			opts:    xbrl.FlattenOptions{Dimensions: []xbrl.QName{}},
+			wantRow: base,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rows := doc.FlattenFacts(tt.opts)
+			require.Len(t, rows, 2)
+			assert.Equal(t, tt.wantRow, rows[0])
+
+			// The nil fact has an empty value.
+			assert.Equal(t, "", rows[1]["value"])
+			assert.Equal(t, "{"+ns+"}NilFact", rows[1]["concept"])
+		})
+	}
+}
+
+func TestDocument_FlattenFacts_ValuesAndPeriods(t *testing.T) {
+	t.Parallel()
+
+	concept := xbrl.NewQNameForTest("ex", "Note", "http://example.com")
+	instant := "2025-03-31"
+	doc := xbrl.NewDocumentForTest(
+		nil,
+		map[string]*xbrl.Context{
+			"I": xbrl.NewContextForTest("I", xbrl.Entity{}, xbrl.NewPeriodForTest(&instant, nil, nil, false), nil),
+			"F": xbrl.NewContextForTest("F", xbrl.Entity{}, xbrl.NewPeriodForTest(nil, nil, nil, true), nil),
+		},
+		nil,
+		[]*xbrl.Fact{
+			xbrl.NewFactForTest(xbrl.FactKindItem, concept, "  a \n b  ", "I", "", "", "", "", "", false),
+			nil,
+			xbrl.NewFactForTest(xbrl.FactKindItem, concept, "x", "F", "", "", "", "", "", false),
+			xbrl.NewFactForTest(xbrl.FactKindItem, concept, "y", "MISSING", "", "", "", "", "", false),
+		},
+		nil,
+	)
+
+	var nilDoc *xbrl.Document
+	assert.Nil(t, nilDoc.FlattenFacts(xbrl.FlattenOptions{}))
+
+	rows := doc.FlattenFacts(xbrl.FlattenOptions{NormalizeValues: true})
+	require.Len(t, rows, 3)
+
+	assert.Equal(t, "a b", rows[0]["value"])
+	assert.Equal(t, instant, rows[0]["period_start"])
+	assert.Equal(t, instant, rows[0]["period_end"])
+
+	assert.Equal(t, "", rows[1]["period_start"])
+	assert.Equal(t, "", rows[1]["period_end"])
+
+	assert.Equal(t, "MISSING", rows[2]["context"])
+	assert.Equal(t, "", rows[2]["entity"])
+
+	raw := doc.FlattenFacts(xbrl.FlattenOptions{})
+	assert.Equal(t, "  a \n b  ", raw[0]["value"])
+}
@@ -0,0 +1,137 @@
+package xbrl
+
+import (
+	"encoding/xml"
+	"slices"
+	"strings"
+)
+
+// Footnote is a footnote resource linked to facts by a footnoteLink in
+// the instance.
+type Footnote struct {
+	role string
+	lang string
+	text string
+}
+
+// Role returns the footnote role.
+func (fn Footnote) Role() string {
+	return fn.role
+}
+
+// Lang returns the xml:lang of the footnote.
+func (fn Footnote) Lang() string {
+	return fn.lang
+}
+
+// Text returns the text content of the footnote, including the text of
+// nested XHTML markup, with surrounding whitespace removed.
+func (fn Footnote) Text() string {
+	return fn.text
+}
+
+// Footnotes returns the footnotes linked to the fact, in document order.
+//
+// Footnote arcs are matched to facts by ID. The xlink:from of an arc may
+// name a loc element, whose xlink:href fragment is the fact ID, or it may
+// name the fact ID directly, as some filers do. A fact without an ID has
+// no footnotes.
+func (d *Document) Footnotes(f *Fact) []Footnote {
+	if d == nil || f == nil || f.id == "" {
+		return nil
+	}
+	return slices.Clone(d.footnotes[f.id])
+}
+
+// parseFootnoteLink consumes a link:footnoteLink element and records its
+// footnotes by the IDs of the facts they are linked to.
+func (d *Document) parseFootnoteLink(dec *xml.Decoder, start xml.StartElement) error {
+	locs := make(map[string][]string) // label -> fact IDs
+	notes := make(map[string][]Footnote)
+	var arcs []linkArc
+
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return positionError(dec, "parse footnoteLink", err)
+		}
+
+		switch el := tok.(type) {
+		case xml.StartElement:
+			label := xlinkAttr(el.Attr, "label")
+			switch xlinkAttr(el.Attr, "type") {
+			case "locator":
+				if id := hrefFragment(xlinkAttr(el.Attr, "href")); id != "" {
+					locs[label] = append(locs[label], id)
+				}
+			case "resource":
+				text, err := readAllText(dec)
+				if err != nil {
+					return positionError(dec, "parse footnote", err)
+				}
+				notes[label] = append(notes[label], Footnote{
+					role: xlinkAttr(el.Attr, "role"),
+					lang: xmlLangAttr(el.Attr),
+					text: strings.TrimSpace(text),
+				})
+				continue
+			case "arc":
+				if attrValue(el.Attr, "use") != "prohibited" {
+					arcs = append(arcs, parseLinkArc(el))
+				}
+			}
+			if err := dec.Skip(); err != nil {
+				return positionError(dec, "parse footnoteLink", err)
+			}
+
+		case xml.EndElement:
+			if el.Name == start.Name {
+				d.addFootnotes(arcs, locs, notes)
+				return nil
+			}
+		}
+	}
+}
+
+// addFootnotes resolves the arcs of one footnoteLink. An arc endpoint
+// that is not the label of a loc is taken to be a fact ID.
+func (d *Document) addFootnotes(arcs []linkArc, locs map[string][]string, notes map[string][]Footnote) {
+	for _, a := range arcs {
+		if len(notes[a.to]) == 0 {
+			continue
+		}
+		ids, ok := locs[a.from]
+		if !ok {
+			ids = []string{a.from}
+		}
+		for _, id := range ids {
+			if d.footnotes == nil {
+				d.footnotes = make(map[string][]Footnote)
+			}
+			d.footnotes[id] = append(d.footnotes[id], notes[a.to]...)
+		}
+	}
+}
+
+// readAllText consumes the element whose start tag was just read and
+// returns all of its character data, including that of nested elements.
+func readAllText(dec *xml.Decoder) (string, error) {
+	var sb strings.Builder
+	depth := 1
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return "", err
+		}
+		switch t := tok.(type) {
+		case xml.CharData:
+			sb.Write(t)
+		case xml.StartElement:
+			depth++
+		case xml.EndElement:
+			if depth--; depth == 0 {
+				return sb.String(), nil
+			}
+		}
+	}
+}
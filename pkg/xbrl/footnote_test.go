@@ -0,0 +1,98 @@
+package xbrl_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/aethiopicuschan/xbrl-go/pkg/xbrl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocument_Footnotes(t *testing.T) {
+	t.Parallel()
+
+	const src = `<xbrli:xbrl xmlns:xbrli="http://www.xbrl.org/2003/instance"
+    xmlns:link="http://www.xbrl.org/2003/linkbase" xmlns:xlink="http://www.w3.org/1999/xlink"
+    xmlns:xhtml="http://www.w3.org/1999/xhtml" xmlns:ex="http://example.com/xbrl">
+  <xbrli:context id="C1">
+    <xbrli:entity><xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:instant>2025-03-31</xbrli:instant></xbrli:period>
+  </xbrli:context>
+  <ex:Revenue contextRef="C1" id="f1">100</ex:Revenue>
+  <ex:Cost contextRef="C1" id="f2">60</ex:Cost>
+  <ex:Profit contextRef="C1" id="f3">40</ex:Profit>
+  <ex:Note contextRef="C1">no id</ex:Note>
+  <link:footnoteLink xlink:type="extended" xlink:role="http://www.xbrl.org/2003/role/link">
+    <link:loc xlink:type="locator" xlink:href="#f1" xlink:label="revenue"/>
+    <link:footnote xlink:type="resource" xlink:label="fn1" xlink:role="http://www.xbrl.org/2003/role/footnote" xml:lang="en">
+      <xhtml:p>Includes <xhtml:b>one-off</xhtml:b> items.</xhtml:p>
+    </link:footnote>
+    <link:footnoteArc xlink:type="arc" xlink:arcrole="http://www.xbrl.org/2003/arcrole/fact-footnote" xlink:from="revenue" xlink:to="fn1"/>
+    <link:footnote xlink:type="resource" xlink:label="fn2" xml:lang="en">Restated.</link:footnote>
+    <link:footnoteArc xlink:type="arc" xlink:arcrole="http://www.xbrl.org/2003/arcrole/fact-footnote" xlink:from="f2" xlink:to="fn2"/>
+    <link:footnoteArc xlink:type="arc" xlink:arcrole="http://www.xbrl.org/2003/arcrole/fact-footnote" xlink:from="revenue" xlink:to="fn2"/>
+  </link:footnoteLink>
+</xbrli:xbrl>`
+
+	doc, err := xbrl.Parse(strings.NewReader(src))
+	require.NoError(t, err)
+	facts := doc.Facts()
+	require.Len(t, facts, 4)
+
+	texts := func(f *xbrl.Fact) []string {
+		var out []string
+		for _, fn := range doc.Footnotes(f) {
+			out = append(out, fn.Text())
+		}
+		return out
+	}
+
+	t.Run("linked through a loc href fragment", func(t *testing.T) {
+		t.Parallel()
+
+		got := doc.Footnotes(facts[0])
+		require.Len(t, got, 2)
+		assert.Equal(t, "Includes one-off items.", got[0].Text())
+		assert.Equal(t, "http://www.xbrl.org/2003/role/footnote", got[0].Role())
+		assert.Equal(t, "en", got[0].Lang())
+		assert.Equal(t, "Restated.", got[1].Text())
+	})
+
+	t.Run("linked directly by fact ID", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t, []string{"Restated."}, texts(facts[1]))
+	})
+
+	t.Run("facts without footnotes", func(t *testing.T) {
+		t.Parallel()
+		assert.Nil(t, texts(facts[2]))
+		assert.Nil(t, texts(facts[3]), "facts without an ID have no footnotes")
+		assert.Nil(t, doc.Footnotes(nil))
+
+		var nilDoc *xbrl.Document
+		assert.Nil(t, nilDoc.Footnotes(facts[0]))
+	})
+
+	t.Run("kept by Clone", func(t *testing.T) {
+		t.Parallel()
+		clone := doc.Clone()
+		assert.Len(t, clone.Footnotes(clone.Facts()[0]), 2)
+	})
+
+	t.Run("kept by WriteXML", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		require.NoError(t, doc.WriteXML(&buf))
+		again, err := xbrl.Parse(&buf)
+		require.NoError(t, err)
+
+		got := again.Facts()
+		require.Len(t, got, len(facts))
+		for i, f := range facts {
+			assert.Equal(t, doc.Footnotes(f), again.Footnotes(got[i]), f.ID())
+		}
+	})
+}
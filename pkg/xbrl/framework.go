@@ -0,0 +1,70 @@
+package xbrl
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// frameworks maps namespace URI prefixes to taxonomy framework labels.
+var (
+	frameworksMu sync.RWMutex
+	frameworks   = map[string]string{
+		"http://disclosure.edinet-fsa.go.jp/taxonomy/jppfs/": "EDINET",
+		"http://disclosure.edinet-fsa.go.jp/taxonomy/jpcrp/": "EDINET",
+		"http://disclosure.edinet-fsa.go.jp/taxonomy/jpdei/": "EDINET",
+		"http://xbrl.ifrs.org/taxonomy/":                     "IFRS",
+		"https://xbrl.ifrs.org/taxonomy/":                    "IFRS",
+		"http://fasb.org/us-gaap/":                           "US-GAAP",
+	}
+)
+
+// RegisterFramework makes Frameworks report label for taxonomies with a
+// namespace URI starting with namespacePrefix. Empty arguments are ignored.
+func RegisterFramework(namespacePrefix, label string) {
+	if namespacePrefix == "" || label == "" {
+		return
+	}
+	frameworksMu.Lock()
+	defer frameworksMu.Unlock()
+	frameworks[namespacePrefix] = label
+}
+
+// Frameworks returns the labels of the frameworks the taxonomy is built
+// on, such as "EDINET", "IFRS" or "US-GAAP", sorted alphabetically. The
+// namespaces of concepts, their types and substitution groups are
+// matched against known prefixes; nil means none matched.
+func (t *Taxonomy) Frameworks() []string {
+	if t == nil {
+		return nil
+	}
+
+	uris := make(map[string]struct{})
+	for _, c := range t.concepts {
+		if c == nil {
+			continue
+		}
+		for _, q := range []QName{c.qname, c.typeName, c.substitutionGroup} {
+			if q.uri != "" {
+				uris[q.uri] = struct{}{}
+			}
+		}
+	}
+
+	frameworksMu.RLock()
+	defer frameworksMu.RUnlock()
+
+	found := make(map[string]struct{})
+	for uri := range uris {
+		for prefix, label := range frameworks {
+			if strings.HasPrefix(uri, prefix) {
+				found[label] = struct{}{}
+			}
+		}
+	}
+	if len(found) == 0 {
+		return nil
+	}
+	return slices.Sorted(maps.Keys(found))
+}
@@ -0,0 +1,91 @@
+package xbrl_test
+
+import (
+	"testing"
+
+	"github.com/aethiopicuschan/xbrl-go/pkg/xbrl"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaxonomy_Frameworks(t *testing.T) {
+	t.Parallel()
+
+	xbrli := "http://www.xbrl.org/2003/instance"
+	item := xbrl.NewQNameForTest("xbrli", "item", xbrli)
+	monetary := xbrl.NewQNameForTest("xbrli", "monetaryItemType", xbrli)
+
+	// A namespace only known after registration; empty arguments are not
+	// registered.
+	const customNS = "http://example.com/taxonomy/custom-gaap/2025"
+	xbrl.RegisterFramework("http://example.com/taxonomy/custom-gaap/", "CUSTOM-GAAP")
+	xbrl.RegisterFramework("http://example.com/taxonomy/empty-label/", "")
+	xbrl.RegisterFramework("", "EMPTY")
+
+	concept := func(uri, local string, typ, subst xbrl.QName) *xbrl.Concept {
+		q := xbrl.NewQNameForTest("p", local, uri)
+		return xbrl.NewConceptForTest(q, "", subst, typ, false, false, "", "")
+	}
+	taxonomyOf := func(concepts ...*xbrl.Concept) *xbrl.Taxonomy {
+		m := make(map[xbrl.QName]*xbrl.Concept, len(concepts))
+		for _, c := range concepts {
+			m[c.QName()] = c
+		}
+		return xbrl.NewTaxonomyForTest(m)
+	}
+
+	tests := []struct {
+		name string
+		tax  *xbrl.Taxonomy
+		want []string
+	}{
+		{
+			name: "nil taxonomy",
+			tax:  nil,
+			want: nil,
+		},
+		{
+			name: "no known namespaces",
+			tax:  taxonomyOf(concept("http://example.com/ex", "Revenue", monetary, item)),
+			want: nil,
+		},
+		{
+			name: "EDINET concept namespace",
+			tax: taxonomyOf(
+				concept("http://disclosure.edinet-fsa.go.jp/taxonomy/jppfs/2024-11-01/jppfs_cor", "NetSales", monetary, item),
+			),
+			want: []string{"EDINET"},
+		},
+		{
+			name: "IFRS and US-GAAP",
+			tax: taxonomyOf(
+				concept("https://xbrl.ifrs.org/taxonomy/2024-03-27/ifrs-full", "Revenue", monetary, item),
+				concept("http://fasb.org/us-gaap/2024", "Revenues", monetary, item),
+			),
+			want: []string{"IFRS", "US-GAAP"},
+		},
+		{
+			name: "detected through the type namespace",
+			tax: taxonomyOf(
+				concept("http://example.com/ex", "Custom", xbrl.NewQNameForTest("cg", "amountItemType", customNS), item),
+			),
+			want: []string{"CUSTOM-GAAP"},
+		},
+		{
+			name: "registered concept namespace",
+			tax:  taxonomyOf(concept(customNS, "Sales", monetary, item)),
+			want: []string{"CUSTOM-GAAP"},
+		},
+		{
+			name: "registered with an empty label",
+			tax:  taxonomyOf(concept("http://example.com/taxonomy/empty-label/2025", "Sales", monetary, item)),
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.tax.Frameworks())
+		})
+	}
+}
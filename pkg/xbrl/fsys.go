@@ -0,0 +1,33 @@
+package xbrl
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// ParseFS parses the XBRL instance document name from fsys.
+func ParseFS(fsys fs.FS, name string) (*Document, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("xbrl: open file: %w", err)
+	}
+	defer f.Close()
+
+	return Parse(f)
+}
+
+// FSOpener returns an opener for LoadTaxonomyFromSchemaRefs and
+// LoadLinkbases that reads hrefs from fsys. Hrefs are resolved from the
+// root of fsys; use fs.Sub to resolve them relative to the directory of
+// the instance. Absolute URLs cannot be opened.
+func FSOpener(fsys fs.FS) func(href string) (io.ReadCloser, error) {
+	return func(href string) (io.ReadCloser, error) {
+		if !isLocalHref(href) {
+			return nil, fmt.Errorf("xbrl: %q is not a path in the file system", href)
+		}
+		return fsys.Open(strings.TrimPrefix(path.Clean(href), "./"))
+	}
+}
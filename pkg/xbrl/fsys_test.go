@@ -0,0 +1,60 @@
+package xbrl_test
+
+import (
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/aethiopicuschan/xbrl-go/pkg/xbrl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFS(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		"filing/instance.xbrl": {Data: []byte(`<xbrli:xbrl xmlns:xbrli="http://www.xbrl.org/2003/instance"
+    xmlns:link="http://www.xbrl.org/2003/linkbase" xmlns:xlink="http://www.w3.org/1999/xlink"
+    xmlns:ex="http://example.com/xbrl">
+  <link:schemaRef xlink:type="simple" xlink:href="schema/ex.xsd"/>
+  <xbrli:context id="C1">
+    <xbrli:entity><xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:instant>2025-03-31</xbrli:instant></xbrli:period>
+  </xbrli:context>
+  <ex:Revenue contextRef="C1">100</ex:Revenue>
+</xbrli:xbrl>`)},
+		"filing/schema/ex.xsd": {Data: []byte(`<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema"
+    xmlns:xbrli="http://www.xbrl.org/2003/instance" xmlns:ex="http://example.com/xbrl"
+    targetNamespace="http://example.com/xbrl">
+  <xs:element id="ex_Revenue" name="Revenue" type="xbrli:monetaryItemType" substitutionGroup="xbrli:item"/>
+</xs:schema>`)},
+	}
+
+	doc, err := xbrl.ParseFS(fsys, "filing/instance.xbrl")
+	require.NoError(t, err)
+	require.Len(t, doc.Facts(), 1)
+
+	sub, err := fs.Sub(fsys, "filing")
+	require.NoError(t, err)
+	_, err = doc.LoadTaxonomyFromSchemaRefs(xbrl.FSOpener(sub))
+	require.NoError(t, err)
+
+	c, ok := doc.ConceptOf(doc.Facts()[0])
+	require.True(t, ok)
+	assert.Equal(t, "ex_Revenue", c.ID())
+
+	t.Run("errors", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := xbrl.ParseFS(fsys, "missing.xbrl")
+		assert.ErrorIs(t, err, fs.ErrNotExist)
+
+		open := xbrl.FSOpener(fsys)
+		_, err = open("http://example.com/ex.xsd")
+		assert.EqualError(t, err, `xbrl: "http://example.com/ex.xsd" is not a path in the file system`)
+
+		_, err = open("./filing/schema/ex.xsd")
+		assert.NoError(t, err)
+	})
+}
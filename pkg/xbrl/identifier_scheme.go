@@ -0,0 +1,47 @@
+package xbrl
+
+import "sync"
+
+// schemeLEI is the identifier scheme of ISO 17442 Legal Entity
+// Identifiers, as used by GLEIF and ESEF filings.
+const schemeLEI = "http://standards.iso.org/iso/17442"
+
+// identifierSchemes maps entity identifier scheme URIs to labels.
+var (
+	identifierSchemesMu sync.RWMutex
+	identifierSchemes   = map[string]string{
+		"http://disclosure.edinet-fsa.go.jp": "EDINET",
+		"http://www.sec.gov/CIK":             "SEC CIK",
+		schemeLEI:                            "LEI",
+	}
+)
+
+// RegisterIdentifierScheme makes IsKnownScheme report label for entity
+// identifiers whose scheme is uri, e.g. a national company register not
+// known by default. A scheme can be relabelled by registering it again;
+// an empty uri or label is ignored.
+func RegisterIdentifierScheme(uri, label string) {
+	if uri == "" || label == "" {
+		return
+	}
+	identifierSchemesMu.Lock()
+	defer identifierSchemesMu.Unlock()
+	identifierSchemes[uri] = label
+}
+
+// IsLEI reports whether the identifier is a Legal Entity Identifier,
+// i.e. its scheme is the ISO 17442 URI.
+func (ci ContextIdentifier) IsLEI() bool {
+	return ci.scheme == schemeLEI
+}
+
+// IsKnownScheme returns the label of the identifier scheme, such as
+// "EDINET", "SEC CIK" or "LEI", and reports whether the scheme is known
+// by default or was added with RegisterIdentifierScheme. Schemes are
+// compared exactly.
+func (ci ContextIdentifier) IsKnownScheme() (string, bool) {
+	identifierSchemesMu.RLock()
+	defer identifierSchemesMu.RUnlock()
+	label, ok := identifierSchemes[ci.scheme]
+	return label, ok
+}
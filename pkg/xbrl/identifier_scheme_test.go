@@ -0,0 +1,49 @@
+package xbrl_test
+
+import (
+	"testing"
+
+	"github.com/aethiopicuschan/xbrl-go/pkg/xbrl"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContextIdentifier_IsKnownScheme(t *testing.T) {
+	t.Parallel()
+
+	// A scheme only known after registration; empty arguments are not
+	// registered.
+	const customScheme = "http://example.com/registry/companies"
+	const emptyLabelScheme = "http://example.com/registry/empty-label"
+	xbrl.RegisterIdentifierScheme(customScheme, "Example Registry")
+	xbrl.RegisterIdentifierScheme(emptyLabelScheme, "")
+	xbrl.RegisterIdentifierScheme("", "Empty")
+
+	tests := []struct {
+		name      string
+		scheme    string
+		wantLabel string
+		wantOK    bool
+		wantLEI   bool
+	}{
+		{name: "EDINET", scheme: "http://disclosure.edinet-fsa.go.jp", wantLabel: "EDINET", wantOK: true},
+		{name: "SEC CIK", scheme: "http://www.sec.gov/CIK", wantLabel: "SEC CIK", wantOK: true},
+		{name: "LEI", scheme: "http://standards.iso.org/iso/17442", wantLabel: "LEI", wantOK: true, wantLEI: true},
+		{name: "registered", scheme: customScheme, wantLabel: "Example Registry", wantOK: true},
+		{name: "unknown", scheme: "http://example.com/unknown", wantOK: false},
+		{name: "trailing slash is not the same scheme", scheme: "http://www.sec.gov/CIK/", wantOK: false},
+		{name: "registered with an empty label", scheme: emptyLabelScheme, wantOK: false},
+		{name: "empty", scheme: "", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			id := xbrl.NewContextIdentifierForTest(tt.scheme, "X123")
+			label, ok := id.IsKnownScheme()
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantLabel, label)
+			assert.Equal(t, tt.wantLEI, id.IsLEI())
+		})
+	}
+}
@@ -0,0 +1,306 @@
+package xbrl
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// IndexedDocument gives random access to the facts, contexts and units of
+// an XBRL instance without keeping them in memory.
+//
+// OpenIndexed scans the input once and records the byte range of each
+// element; the elements are decoded from the underlying io.ReaderAt each
+// time they are requested. This trades CPU for memory when querying very
+// large instances.
+type IndexedDocument struct {
+	r        io.ReaderAt
+	facts    []indexEntry
+	contexts map[string]indexEntry
+	units    map[string]indexEntry
+}
+
+// indexEntry is the location of an element in the input together with
+// the namespace scope it must be decoded in.
+type indexEntry struct {
+	start, end int64
+	namespaces map[string]string // prefix -> URI in scope, shared
+	lang       string
+}
+
+// OpenIndexed scans the XBRL instance in r, which is size bytes long, and
+// returns an index over its facts, contexts and units.
+//
+// Facts, contexts and units are detected as in Parse with the default
+// ParseOptions. r must stay readable and unchanged while the
+// IndexedDocument is used.
+//
+// Unlike Parse, OpenIndexed reports duplicate context or unit IDs as
+// errors instead of keeping the last one. Because elements are read back
+// by byte offset, only UTF-8 (or ASCII) input is supported; an XML
+// declaration naming another encoding, such as Shift_JIS, is reported as
+// an error.
+func OpenIndexed(r io.ReaderAt, size int64) (*IndexedDocument, error) {
+	if r == nil {
+		return nil, fmt.Errorf("xbrl: reader is nil")
+	}
+
+	// Skip a UTF-8 BOM so that decoder offsets are relative to base.
+	var base int64
+	bom := make([]byte, len(utf8BOM))
+	if n, _ := r.ReadAt(bom, 0); n == len(bom) && bytes.Equal(bom, utf8BOM) {
+		base = int64(len(utf8BOM))
+	}
+
+	dec := xml.NewDecoder(bufio.NewReader(io.NewSectionReader(r, base, size-base)))
+	dec.CharsetReader = charsetReader
+
+	idx := &IndexedDocument{
+		r:        r,
+		contexts: make(map[string]indexEntry),
+		units:    make(map[string]indexEntry),
+	}
+	ns := newNamespaceStack()
+
+	// rootDepth is the namespace stack depth of the xbrl element, so that
+	// elements of an enclosing envelope are skipped as in Parse.
+	rootDepth, rootClosed := 0, false
+	isFact := factDetector(ParseOptions{})
+
+	for {
+		tokStart := dec.InputOffset()
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, positionError(dec, "decode token", err)
+		}
+
+		switch t := tok.(type) {
+		case xml.ProcInst:
+			if enc := xmlDeclEncoding(t.Inst); t.Target == "xml" && !isUTF8Compatible(enc) {
+				return nil, fmt.Errorf("xbrl: indexed access supports only UTF-8 input, got encoding %q", enc)
+			}
+
+		case xml.StartElement:
+			ns.Push(t)
+
+			var target map[string]indexEntry
+			var kind string
+			switch {
+			case isXbrlRoot(t):
+				if rootDepth == 0 {
+					rootDepth = len(ns.stack)
+				}
+				continue
+			case rootDepth == 0 || rootClosed:
+				continue
+			case t.Name.Local == "context":
+				target, kind = idx.contexts, "context"
+			case t.Name.Local == "unit":
+				target, kind = idx.units, "unit"
+			case isFact(t):
+			default:
+				continue
+			}
+
+			// Entries are decoded in the scope of the parent element.
+			ns.Pop(xml.EndElement{Name: t.Name})
+			e := indexEntry{
+				start:      base + tokStart,
+				namespaces: ns.stack[len(ns.stack)-1],
+				lang:       ns.Lang(),
+			}
+			if err := dec.Skip(); err != nil {
+				return nil, positionError(dec, "skip "+t.Name.Local, err)
+			}
+			e.end = base + dec.InputOffset()
+
+			if target != nil {
+				id := attrValue(t.Attr, "id")
+				if _, ok := target[id]; ok {
+					return nil, fmt.Errorf("xbrl: duplicate %s ID %q", kind, id)
+				}
+				target[id] = e
+			} else {
+				idx.facts = append(idx.facts, e)
+			}
+
+		case xml.EndElement:
+			if len(ns.stack) == rootDepth {
+				rootClosed = true
+			}
+			ns.Pop(t)
+		}
+	}
+
+	return idx, nil
+}
+
+// xmlDeclEncoding returns the encoding pseudo-attribute of an XML
+// declaration, or "" if it has none.
+func xmlDeclEncoding(inst []byte) string {
+	s := string(inst)
+	i := strings.Index(s, "encoding")
+	if i < 0 {
+		return ""
+	}
+	s = strings.TrimLeft(s[i+len("encoding"):], " \t\r\n")
+	s, ok := strings.CutPrefix(s, "=")
+	if !ok {
+		return ""
+	}
+	s = strings.TrimLeft(s, " \t\r\n")
+	if s == "" || (s[0] != '"' && s[0] != '\'') {
+		return ""
+	}
+	q := s[0]
+	s = s[1:]
+	if j := strings.IndexByte(s, q); j >= 0 {
+		return s[:j]
+	}
+	return ""
+}
+
+// isUTF8Compatible reports whether text in the named encoding can be
+// read as UTF-8. An empty name means the XML default, UTF-8.
+func isUTF8Compatible(enc string) bool {
+	switch strings.ToLower(enc) {
+	case "", "utf-8", "utf8", "us-ascii", "ascii":
+		return true
+	default:
+		return false
+	}
+}
+
+// FactCount returns the number of indexed facts.
+func (x *IndexedDocument) FactCount() int {
+	if x == nil {
+		return 0
+	}
+	return len(x.facts)
+}
+
+// Fact decodes the i-th fact of the document (0-based, in document order).
+func (x *IndexedDocument) Fact(i int) (*Fact, error) {
+	if i < 0 || i >= x.FactCount() {
+		return nil, fmt.Errorf("xbrl: fact index %d out of range [0, %d)", i, x.FactCount())
+	}
+	var f *Fact
+	err := x.decode(x.facts[i], func(dec *xml.Decoder, se xml.StartElement, ns *namespaceStack) error {
+		var err error
+		f, err = parseItemFact(dec, se, ns)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	f.index = i
+	return f, nil
+}
+
+// ContextIDs returns the IDs of the indexed contexts, sorted.
+func (x *IndexedDocument) ContextIDs() []string {
+	if x == nil {
+		return nil
+	}
+	return slices.Sorted(maps.Keys(x.contexts))
+}
+
+// Context decodes the context with the given ID.
+func (x *IndexedDocument) Context(id string) (*Context, error) {
+	if x == nil {
+		return nil, fmt.Errorf("xbrl: context %q not found", id)
+	}
+	e, ok := x.contexts[id]
+	if !ok {
+		return nil, fmt.Errorf("xbrl: context %q not found", id)
+	}
+	var ctx *Context
+	err := x.decode(e, func(dec *xml.Decoder, se xml.StartElement, ns *namespaceStack) error {
+		var err error
+		ctx, err = parseContext(dec, se, ns, ParseOptions{})
+		return err
+	})
+	return ctx, err
+}
+
+// UnitIDs returns the IDs of the indexed units, sorted.
+func (x *IndexedDocument) UnitIDs() []string {
+	if x == nil {
+		return nil
+	}
+	return slices.Sorted(maps.Keys(x.units))
+}
+
+// Unit decodes the unit with the given ID.
+func (x *IndexedDocument) Unit(id string) (*Unit, error) {
+	if x == nil {
+		return nil, fmt.Errorf("xbrl: unit %q not found", id)
+	}
+	e, ok := x.units[id]
+	if !ok {
+		return nil, fmt.Errorf("xbrl: unit %q not found", id)
+	}
+	var u *Unit
+	err := x.decode(e, func(dec *xml.Decoder, se xml.StartElement, ns *namespaceStack) error {
+		var err error
+		u, err = parseUnit(dec, se, ns)
+		return err
+	})
+	return u, err
+}
+
+// decode reads the element of e and passes its start element to parse.
+//
+// The element is wrapped in an element declaring the namespaces and
+// xml:lang in scope at its original position, so that prefixes declared
+// on ancestors still resolve.
+func (x *IndexedDocument) decode(e indexEntry, parse func(*xml.Decoder, xml.StartElement, *namespaceStack) error) error {
+	raw := make([]byte, e.end-e.start)
+	if _, err := x.r.ReadAt(raw, e.start); err != nil && err != io.EOF {
+		return fmt.Errorf("xbrl: read indexed element: %w", err)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("<scope")
+	for _, prefix := range slices.Sorted(maps.Keys(e.namespaces)) {
+		if prefix == "" {
+			buf.WriteString(` xmlns="`)
+		} else {
+			buf.WriteString(" xmlns:" + prefix + `="`)
+		}
+		buf.WriteString(escapeXML(e.namespaces[prefix]) + `"`)
+	}
+	if e.lang != "" {
+		buf.WriteString(` xml:lang="` + escapeXML(e.lang) + `"`)
+	}
+	buf.WriteString(">")
+	buf.Write(raw)
+	buf.WriteString("</scope>")
+
+	dec := xml.NewDecoder(&buf)
+	dec.CharsetReader = charsetReader
+	ns := newNamespaceStack()
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return positionError(dec, "decode indexed element", err)
+		}
+		se, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		ns.Push(se)
+		if se.Name.Local == "scope" && len(ns.stack) == 2 {
+			continue
+		}
+		return parse(dec, se, ns)
+	}
+}
@@ -0,0 +1,170 @@
+package xbrl_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/aethiopicuschan/xbrl-go/pkg/xbrl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenIndexed(t *testing.T) {
+	t.Parallel()
+
+	r := strings.NewReader(minimalInstance)
+	idx, err := xbrl.OpenIndexed(r, r.Size())
+	require.NoError(t, err)
+
+	require.Equal(t, 1, idx.FactCount())
+	assert.Equal(t, []string{"C1"}, idx.ContextIDs())
+	assert.Equal(t, []string{"U1"}, idx.UnitIDs())
+
+	f, err := idx.Fact(0)
+	require.NoError(t, err)
+	assert.Equal(t, "Revenue", f.Name().Local())
+	assert.Equal(t, "http://example.com/xbrl", f.Name().URI(), "prefix declared on the root resolves")
+	assert.Equal(t, "ex", f.Name().Prefix())
+	assert.Equal(t, "12345", f.Value())
+	assert.Equal(t, "C1", f.ContextRef())
+	assert.Equal(t, "U1", f.UnitRef())
+	assert.Equal(t, "0", f.Decimals())
+
+	ctx, err := idx.Context("C1")
+	require.NoError(t, err)
+	instant, ok := ctx.Period().Instant()
+	require.True(t, ok)
+	assert.Equal(t, "2025-01-01", instant)
+
+	u, err := idx.Unit("U1")
+	require.NoError(t, err)
+	assert.Equal(t, "JPY", u.String())
+
+	// Elements decode the same as with a full parse.
+	doc, err := xbrl.Parse(strings.NewReader(minimalInstance))
+	require.NoError(t, err)
+	assert.Equal(t, doc.Facts()[0], f)
+	want, _ := doc.ContextByID("C1")
+	assert.Equal(t, want, ctx)
+}
+
+func TestOpenIndexed_ExtendedFixture(t *testing.T) {
+	t.Parallel()
+
+	src := "\ufeff" + strings.Replace(extendedInstance, "<xbrli:xbrl", `<xbrli:xbrl xml:lang="en"`, 1)
+	r := strings.NewReader(src)
+	idx, err := xbrl.OpenIndexed(r, r.Size())
+	require.NoError(t, err)
+
+	doc, err := xbrl.Parse(strings.NewReader(src))
+	require.NoError(t, err)
+
+	require.Equal(t, len(doc.Facts()), idx.FactCount())
+	for i, want := range doc.Facts() {
+		got, err := idx.Fact(i)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+	for id, want := range doc.Contexts() {
+		got, err := idx.Context(id)
+		require.NoError(t, err)
+		assert.Equal(t, want, got, id)
+	}
+	for id, want := range doc.Units() {
+		got, err := idx.Unit(id)
+		require.NoError(t, err)
+		assert.Equal(t, want, got, id)
+	}
+}
+
+func TestOpenIndexed_Envelope(t *testing.T) {
+	t.Parallel()
+
+	const src = `<env:Envelope xmlns:env="http://example.com/envelope" xmlns:ex="http://example.com/xbrl">
+  <ex:Before contextRef="C1">1</ex:Before>
+  <context id="Outside"/>
+  <xbrli:xbrl xmlns:xbrli="http://www.xbrl.org/2003/instance">
+    <xbrli:context id="C1">
+      <xbrli:entity><xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier></xbrli:entity>
+      <xbrli:period><xbrli:instant>2025-03-31</xbrli:instant></xbrli:period>
+    </xbrli:context>
+    <ex:Revenue contextRef="C1">100</ex:Revenue>
+    <link:note xmlns:link="http://www.xbrl.org/2003/linkbase" contextRef="C1">not a fact</link:note>
+  </xbrli:xbrl>
+  <ex:After contextRef="C1">2</ex:After>
+</env:Envelope>`
+
+	r := strings.NewReader(src)
+	idx, err := xbrl.OpenIndexed(r, r.Size())
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"C1"}, idx.ContextIDs())
+	require.Equal(t, 1, idx.FactCount())
+	f, err := idx.Fact(0)
+	require.NoError(t, err)
+	assert.Equal(t, "Revenue", f.Name().Local())
+
+	doc, err := xbrl.Parse(strings.NewReader(src))
+	require.NoError(t, err)
+	assert.Len(t, doc.Facts(), 1)
+	assert.Len(t, doc.Contexts(), 1)
+}
+
+func TestOpenIndexed_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := xbrl.OpenIndexed(nil, 0)
+	assert.Error(t, err)
+
+	broken := strings.NewReader("<xbrli:xbrl><unclosed")
+	_, err = xbrl.OpenIndexed(broken, broken.Size())
+	assert.ErrorContains(t, err, "decode token")
+
+	sjis := strings.NewReader(`<?xml version="1.0" encoding="Shift_JIS"?>` + minimalInstance)
+	_, err = xbrl.OpenIndexed(sjis, sjis.Size())
+	assert.ErrorContains(t, err, `only UTF-8 input, got encoding "Shift_JIS"`)
+
+	const dupTmpl = `<xbrli:xbrl xmlns:xbrli="http://www.xbrl.org/2003/instance" xmlns:iso4217="http://www.xbrl.org/2003/iso4217">
+  %[1]s
+  %[1]s
+</xbrli:xbrl>`
+	dups := []struct {
+		name, elem, want string
+	}{
+		{
+			name: "context",
+			elem: `<xbrli:context id="C1"><xbrli:entity><xbrli:identifier scheme="s">E</xbrli:identifier></xbrli:entity><xbrli:period><xbrli:instant>2025-12-31</xbrli:instant></xbrli:period></xbrli:context>`,
+			want: `xbrl: duplicate context ID "C1"`,
+		},
+		{
+			name: "unit",
+			elem: `<xbrli:unit id="U1"><xbrli:measure>iso4217:JPY</xbrli:measure></xbrli:unit>`,
+			want: `xbrl: duplicate unit ID "U1"`,
+		},
+	}
+	for _, d := range dups {
+		dr := strings.NewReader(fmt.Sprintf(dupTmpl, d.elem))
+		_, err = xbrl.OpenIndexed(dr, dr.Size())
+		assert.EqualError(t, err, d.want, d.name)
+	}
+
+	r := strings.NewReader(minimalInstance)
+	idx, err := xbrl.OpenIndexed(r, r.Size())
+	require.NoError(t, err)
+
+	_, err = idx.Fact(1)
+	assert.ErrorContains(t, err, "out of range")
+	_, err = idx.Fact(-1)
+	assert.Error(t, err)
+	_, err = idx.Context("missing")
+	assert.ErrorContains(t, err, `context "missing" not found`)
+	_, err = idx.Unit("missing")
+	assert.ErrorContains(t, err, `unit "missing" not found`)
+
+	var nilIdx *xbrl.IndexedDocument
+	assert.Zero(t, nilIdx.FactCount())
+	assert.Nil(t, nilIdx.ContextIDs())
+	_, err = nilIdx.Fact(0)
+	assert.Error(t, err)
+}
@@ -0,0 +1,689 @@
+package xbrl
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// Standard label roles.
+const (
+	RoleLabel        = "http://www.xbrl.org/2003/role/label"
+	RoleTerseLabel   = "http://www.xbrl.org/2003/role/terseLabel"
+	RoleVerboseLabel = "http://www.xbrl.org/2003/role/verboseLabel"
+	RoleTotalLabel   = "http://www.xbrl.org/2003/role/totalLabel"
+)
+
+// Standard arcroles used by linkbases.
+const (
+	ArcroleConceptLabel       = "http://www.xbrl.org/2003/arcrole/concept-label"
+	ArcroleConceptReference   = "http://www.xbrl.org/2003/arcrole/concept-reference"
+	ArcroleParentChild        = "http://www.xbrl.org/2003/arcrole/parent-child"
+	ArcroleSummationItem      = "http://www.xbrl.org/2003/arcrole/summation-item"
+	ArcroleAll                = "http://xbrl.org/int/dim/arcrole/all"
+	ArcroleHypercubeDimension = "http://xbrl.org/int/dim/arcrole/hypercube-dimension"
+	ArcroleDimensionDomain    = "http://xbrl.org/int/dim/arcrole/dimension-domain"
+	ArcroleDomainMember       = "http://xbrl.org/int/dim/arcrole/domain-member"
+	ArcroleDimensionDefault   = "http://xbrl.org/int/dim/arcrole/dimension-default"
+)
+
+// LinkbaseType identifies the kind of a linkbase.
+type LinkbaseType int
+
+const (
+	// LinkbaseUnknown means the type could not be determined. Parsing a
+	// linkbase of unknown type processes every extended link in it.
+	LinkbaseUnknown LinkbaseType = iota
+	LinkbaseLabel
+	LinkbasePresentation
+	LinkbaseCalculation
+	LinkbaseDefinition
+	LinkbaseReference
+)
+
+// String returns a human-readable name of the linkbase type.
+func (t LinkbaseType) String() string {
+	switch t {
+	case LinkbaseLabel:
+		return "label"
+	case LinkbasePresentation:
+		return "presentation"
+	case LinkbaseCalculation:
+		return "calculation"
+	case LinkbaseDefinition:
+		return "definition"
+	case LinkbaseReference:
+		return "reference"
+	default:
+		return "unknown"
+	}
+}
+
+// linkbaseTypeFromRole detects the linkbase type from a linkbaseRef
+// xlink:role such as ".../role/labelLinkbaseRef".
+func linkbaseTypeFromRole(role string) LinkbaseType {
+	name := role[strings.LastIndex(role, "/")+1:]
+	return linkbaseTypeFromName(strings.TrimSuffix(name, "LinkbaseRef"))
+}
+
+// linkbaseTypeFromLink detects the linkbase type from the local name of
+// an extended link element such as "labelLink".
+func linkbaseTypeFromLink(local string) LinkbaseType {
+	return linkbaseTypeFromName(strings.TrimSuffix(local, "Link"))
+}
+
+func linkbaseTypeFromName(name string) LinkbaseType {
+	switch name {
+	case "label":
+		return LinkbaseLabel
+	case "presentation":
+		return LinkbasePresentation
+	case "calculation":
+		return LinkbaseCalculation
+	case "definition":
+		return LinkbaseDefinition
+	case "reference":
+		return LinkbaseReference
+	default:
+		return LinkbaseUnknown
+	}
+}
+
+// LinkbaseRef represents a <linkbaseRef> element found in an instance
+// document or a taxonomy schema.
+type LinkbaseRef struct {
+	href    string
+	role    string
+	arcrole string
+}
+
+// Href returns the xlink:href of the linkbaseRef.
+func (l LinkbaseRef) Href() string {
+	return l.href
+}
+
+// Role returns the xlink:role of the linkbaseRef, if any.
+func (l LinkbaseRef) Role() string {
+	return l.role
+}
+
+// Arcrole returns the xlink:arcrole of the linkbaseRef.
+func (l LinkbaseRef) Arcrole() string {
+	return l.arcrole
+}
+
+// Type returns the linkbase type indicated by the xlink:role.
+// It returns LinkbaseUnknown if the role is absent or not a standard one.
+func (l LinkbaseRef) Type() LinkbaseType {
+	return linkbaseTypeFromRole(l.role)
+}
+
+func parseLinkbaseRef(se xml.StartElement) LinkbaseRef {
+	return LinkbaseRef{
+		href:    strings.TrimSpace(xlinkAttr(se.Attr, "href")),
+		role:    strings.TrimSpace(xlinkAttr(se.Attr, "role")),
+		arcrole: strings.TrimSpace(xlinkAttr(se.Attr, "arcrole")),
+	}
+}
+
+// Label is a label resource attached to a concept by a label linkbase.
+type Label struct {
+	role string
+	lang string
+	text string
+}
+
+// Role returns the label role (e.g. RoleLabel).
+func (l Label) Role() string {
+	return l.role
+}
+
+// Lang returns the xml:lang of the label.
+func (l Label) Lang() string {
+	return l.lang
+}
+
+// Text returns the label text.
+func (l Label) Text() string {
+	return l.text
+}
+
+// Reference is a reference resource attached to a concept by a
+// reference linkbase.
+type Reference struct {
+	role  string
+	parts []ReferencePart
+}
+
+// Role returns the reference role.
+func (r Reference) Role() string {
+	return r.role
+}
+
+// Parts returns a copy of the reference parts in document order.
+func (r Reference) Parts() []ReferencePart {
+	out := make([]ReferencePart, len(r.parts))
+	copy(out, r.parts)
+	return out
+}
+
+// ReferencePart is a single part of a reference, such as "Name" or
+// "Paragraph".
+type ReferencePart struct {
+	name  string
+	value string
+}
+
+// Name returns the local name of the part element.
+func (p ReferencePart) Name() string {
+	return p.name
+}
+
+// Value returns the text of the part element.
+func (p ReferencePart) Value() string {
+	return p.value
+}
+
+// Relationship is an arc between two concepts from a presentation,
+// calculation or definition linkbase.
+type Relationship struct {
+	arcrole        string
+	role           string
+	from           QName
+	to             QName
+	order          float64
+	weight         float64
+	preferredLabel string
+}
+
+// Arcrole returns the arcrole of the relationship (e.g. ArcroleParentChild).
+func (r Relationship) Arcrole() string {
+	return r.arcrole
+}
+
+// Role returns the extended link role the relationship belongs to.
+func (r Relationship) Role() string {
+	return r.role
+}
+
+// From returns the source concept of the relationship.
+func (r Relationship) From() QName {
+	return r.from
+}
+
+// To returns the target concept of the relationship.
+func (r Relationship) To() QName {
+	return r.to
+}
+
+// Order returns the @order of the arc. It defaults to 1.
+func (r Relationship) Order() float64 {
+	return r.order
+}
+
+// Weight returns the @weight of a calculation arc, or 0 if absent.
+func (r Relationship) Weight() float64 {
+	return r.weight
+}
+
+// PreferredLabel returns the @preferredLabel role of a presentation arc,
+// if any.
+func (r Relationship) PreferredLabel() string {
+	return r.preferredLabel
+}
+
+// LinkbaseRefs returns a copy of the linkbaseRefs declared in the
+// taxonomy schemas.
+func (t *Taxonomy) LinkbaseRefs() []LinkbaseRef {
+	if t == nil {
+		return nil
+	}
+	out := make([]LinkbaseRef, len(t.linkbaseRefs))
+	copy(out, t.linkbaseRefs)
+	return out
+}
+
+// Labels returns a copy of the labels attached to the concept q.
+// Concepts are matched by namespace URI and local name.
+func (t *Taxonomy) Labels(q QName) []Label {
+	if t == nil {
+		return nil
+	}
+	labels := t.labels[conceptKey{q.uri, q.local}]
+	if len(labels) == 0 {
+		return nil
+	}
+	out := make([]Label, len(labels))
+	copy(out, labels)
+	return out
+}
+
+// Label returns the text of the concept's label with the given role and
+// language.
+//
+// An empty role means RoleLabel. An empty lang matches any language;
+// otherwise an exact (case-insensitive) match is preferred, falling back
+// to a label whose primary language subtag matches (e.g. "en" for "en-US").
+func (t *Taxonomy) Label(q QName, role, lang string) (string, bool) {
+	if t == nil {
+		return "", false
+	}
+	if role == "" {
+		role = RoleLabel
+	}
+
+	var fallback *Label
+	labels := t.labels[conceptKey{q.uri, q.local}]
+	for i := range labels {
+		l := &labels[i]
+		if l.role != role {
+			continue
+		}
+		if lang == "" || strings.EqualFold(l.lang, lang) {
+			return l.text, true
+		}
+		if fallback == nil && strings.EqualFold(primaryLang(l.lang), primaryLang(lang)) {
+			fallback = l
+		}
+	}
+	if fallback != nil {
+		return fallback.text, true
+	}
+	return "", false
+}
+
+// primaryLang returns the primary subtag of a language tag.
+func primaryLang(lang string) string {
+	if i := strings.IndexByte(lang, '-'); i >= 0 {
+		return lang[:i]
+	}
+	return lang
+}
+
+// References returns a copy of the references attached to the concept q.
+// Concepts are matched by namespace URI and local name.
+func (t *Taxonomy) References(q QName) []Reference {
+	if t == nil {
+		return nil
+	}
+	refs := t.references[conceptKey{q.uri, q.local}]
+	if len(refs) == 0 {
+		return nil
+	}
+	out := make([]Reference, len(refs))
+	copy(out, refs)
+	return out
+}
+
+// Relationships returns the concept relationships with the given arcrole
+// in the order they were loaded. An empty arcrole returns all of them.
+func (t *Taxonomy) Relationships(arcrole string) []Relationship {
+	if t == nil {
+		return nil
+	}
+	var out []Relationship
+	for _, r := range t.relationships {
+		if arcrole == "" || r.arcrole == arcrole {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// DimensionDefault returns the default member of the dimension dim, as
+// declared by a dimension-default relationship in a definition linkbase.
+// Dimensions are matched by namespace URI and local name.
+func (t *Taxonomy) DimensionDefault(dim QName) (QName, bool) {
+	if t == nil {
+		return QName{}, false
+	}
+	for _, r := range t.relationships {
+		if r.arcrole == ArcroleDimensionDefault &&
+			r.from.uri == dim.uri && r.from.local == dim.local {
+			return r.to, true
+		}
+	}
+	return QName{}, false
+}
+
+// LinkbaseRefs returns a copy of the linkbaseRefs in the instance document.
+func (d *Document) LinkbaseRefs() []LinkbaseRef {
+	if d == nil {
+		return nil
+	}
+	out := make([]LinkbaseRef, len(d.linkbaseRefs))
+	copy(out, d.linkbaseRefs)
+	return out
+}
+
+// LoadLinkbases opens and parses every linkbase referenced by the
+// document's linkbaseRefs and by the linkbaseRefs of the attached
+// taxonomy's schemas, and attaches the results to the taxonomy.
+//
+// The linkbase type is detected from each linkbaseRef's xlink:role, or
+// from the extended links it contains if the role is absent. Each href
+// is loaded at most once. A taxonomy must be attached beforehand (e.g.
+// with LoadTaxonomyFromSchemaRefs) so that locators can be resolved to
+// concepts; otherwise ErrNoTaxonomy is returned.
+func (d *Document) LoadLinkbases(
+	opener func(href string) (io.ReadCloser, error),
+) error {
+	return d.loadLinkbases(opener, nil)
+}
+
+// loadLinkbases is LoadLinkbases, loading only the hrefs accepted by
+// include (all of them if include is nil).
+func (d *Document) loadLinkbases(
+	opener func(href string) (io.ReadCloser, error),
+	include func(href string) bool,
+) error {
+	if d == nil {
+		return fmt.Errorf("xbrl: document is nil")
+	}
+	if opener == nil {
+		return fmt.Errorf("xbrl: opener is nil")
+	}
+	if d.taxonomy == nil {
+		return ErrNoTaxonomy
+	}
+
+	refs := append(d.LinkbaseRefs(), d.taxonomy.linkbaseRefs...)
+	seen := make(map[string]struct{}, len(refs))
+	for _, ref := range refs {
+		href := ref.Href()
+		if href == "" || (include != nil && !include(href)) {
+			continue
+		}
+		if _, ok := seen[href]; ok {
+			continue
+		}
+		seen[href] = struct{}{}
+
+		rc, err := opener(href)
+		if err != nil {
+			return fmt.Errorf("xbrl: open linkbaseRef %q: %w", href, err)
+		}
+		err = d.taxonomy.ParseLinkbase(rc, ref.Type())
+		rc.Close()
+		if err != nil {
+			return fmt.Errorf("xbrl: parse linkbaseRef %q: %w", href, err)
+		}
+	}
+	return nil
+}
+
+// ParseLinkbase parses a linkbase from r and attaches its labels,
+// references and relationships to the taxonomy.
+//
+// If typ is not LinkbaseUnknown, only extended links of that type are
+// processed. Locators are resolved to concepts by the fragment of their
+// xlink:href, which must match a concept's @id; unresolved locators are
+// ignored. Arcs with use="prohibited" are ignored as well; prohibition
+// and overriding of relationships are not applied.
+func (t *Taxonomy) ParseLinkbase(r io.Reader, typ LinkbaseType) error {
+	if t == nil {
+		return ErrNoTaxonomy
+	}
+
+	dec := xml.NewDecoder(skipBOM(r))
+	dec.CharsetReader = charsetReader
+
+	byID := make(map[string]*Concept, len(t.concepts))
+	for _, c := range t.concepts {
+		if c != nil && c.id != "" {
+			byID[c.id] = c
+		}
+	}
+
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("xbrl: decode linkbase token: %w", err)
+		}
+
+		se, ok := tok.(xml.StartElement)
+		if !ok || xlinkAttr(se.Attr, "type") != "extended" {
+			continue
+		}
+		if typ != LinkbaseUnknown && linkbaseTypeFromLink(se.Name.Local) != typ {
+			if err := dec.Skip(); err != nil {
+				return fmt.Errorf("xbrl: skip extended link: %w", err)
+			}
+			continue
+		}
+		if err := t.parseExtendedLink(dec, se, byID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// linkArc is an arc of an extended link before its endpoints are resolved.
+type linkArc struct {
+	arcrole        string
+	from           string
+	to             string
+	order          float64
+	weight         float64
+	preferredLabel string
+}
+
+// parseExtendedLink consumes an extended link element and attaches the
+// labels, references and relationships it defines to t.
+func (t *Taxonomy) parseExtendedLink(dec *xml.Decoder, start xml.StartElement, byID map[string]*Concept) error {
+	role := xlinkAttr(start.Attr, "role")
+
+	locs := make(map[string][]*Concept)
+	labels := make(map[string][]Label)
+	refs := make(map[string][]Reference)
+	var arcs []linkArc
+
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return fmt.Errorf("xbrl: parse extended link: %w", err)
+		}
+
+		switch el := tok.(type) {
+		case xml.StartElement:
+			label := xlinkAttr(el.Attr, "label")
+			switch xlinkAttr(el.Attr, "type") {
+			case "locator":
+				if c := byID[hrefFragment(xlinkAttr(el.Attr, "href"))]; c != nil {
+					locs[label] = append(locs[label], c)
+				}
+				if err := dec.Skip(); err != nil {
+					return fmt.Errorf("xbrl: parse locator: %w", err)
+				}
+
+			case "resource":
+				switch el.Name.Local {
+				case "label":
+					text, err := readElementText(dec, el)
+					if err != nil {
+						return fmt.Errorf("xbrl: parse label: %w", err)
+					}
+					labels[label] = append(labels[label], Label{
+						role: xlinkAttr(el.Attr, "role"),
+						lang: xmlLangAttr(el.Attr),
+						text: strings.TrimSpace(text),
+					})
+				case "reference":
+					ref, err := parseReferenceResource(dec, el)
+					if err != nil {
+						return err
+					}
+					refs[label] = append(refs[label], ref)
+				default:
+					if err := dec.Skip(); err != nil {
+						return fmt.Errorf("xbrl: skip resource: %w", err)
+					}
+				}
+
+			case "arc":
+				if attrValue(el.Attr, "use") != "prohibited" {
+					arcs = append(arcs, parseLinkArc(el))
+				}
+				if err := dec.Skip(); err != nil {
+					return fmt.Errorf("xbrl: parse arc: %w", err)
+				}
+
+			default:
+				if err := dec.Skip(); err != nil {
+					return fmt.Errorf("xbrl: skip link child: %w", err)
+				}
+			}
+
+		case xml.EndElement:
+			if el.Name == start.Name {
+				t.addArcs(role, arcs, locs, labels, refs)
+				return nil
+			}
+		}
+	}
+}
+
+// addArcs resolves the arcs of one extended link and attaches the result.
+func (t *Taxonomy) addArcs(
+	role string,
+	arcs []linkArc,
+	locs map[string][]*Concept,
+	labels map[string][]Label,
+	refs map[string][]Reference,
+) {
+	for _, a := range arcs {
+		for _, from := range locs[a.from] {
+			key := conceptKey{from.qname.uri, from.qname.local}
+			switch a.arcrole {
+			case ArcroleConceptLabel:
+				if t.labels == nil {
+					t.labels = make(map[conceptKey][]Label)
+				}
+				t.labels[key] = append(t.labels[key], labels[a.to]...)
+
+			case ArcroleConceptReference:
+				if t.references == nil {
+					t.references = make(map[conceptKey][]Reference)
+				}
+				t.references[key] = append(t.references[key], refs[a.to]...)
+
+			default:
+				for _, to := range locs[a.to] {
+					t.relationships = append(t.relationships, Relationship{
+						arcrole:        a.arcrole,
+						role:           role,
+						from:           from.qname,
+						to:             to.qname,
+						order:          a.order,
+						weight:         a.weight,
+						preferredLabel: a.preferredLabel,
+					})
+				}
+			}
+		}
+	}
+}
+
+func parseLinkArc(se xml.StartElement) linkArc {
+	a := linkArc{
+		arcrole:        xlinkAttr(se.Attr, "arcrole"),
+		from:           xlinkAttr(se.Attr, "from"),
+		to:             xlinkAttr(se.Attr, "to"),
+		order:          1,
+		preferredLabel: attrValue(se.Attr, "preferredLabel"),
+	}
+	if v, err := strconv.ParseFloat(attrValue(se.Attr, "order"), 64); err == nil {
+		a.order = v
+	}
+	if v, err := strconv.ParseFloat(attrValue(se.Attr, "weight"), 64); err == nil {
+		a.weight = v
+	}
+	return a
+}
+
+// parseReferenceResource consumes a link:reference resource and its parts.
+func parseReferenceResource(dec *xml.Decoder, start xml.StartElement) (Reference, error) {
+	ref := Reference{role: xlinkAttr(start.Attr, "role")}
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return Reference{}, fmt.Errorf("xbrl: parse reference: %w", err)
+		}
+		switch el := tok.(type) {
+		case xml.StartElement:
+			text, err := readElementText(dec, el)
+			if err != nil {
+				return Reference{}, fmt.Errorf("xbrl: parse reference part: %w", err)
+			}
+			ref.parts = append(ref.parts, ReferencePart{
+				name:  el.Name.Local,
+				value: strings.TrimSpace(text),
+			})
+		case xml.EndElement:
+			if el.Name == start.Name {
+				return ref, nil
+			}
+		}
+	}
+}
+
+// xlinkAttr returns the value of the xlink attribute with the given
+// local name, or an empty string if absent.
+func xlinkAttr(attrs []xml.Attr, local string) string {
+	for _, a := range attrs {
+		if a.Name.Local == local && (a.Name.Space == nsXLink || a.Name.Space == "xlink") {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// attrValue returns the value of the unqualified attribute with the
+// given local name, or an empty string if absent.
+func attrValue(attrs []xml.Attr, local string) string {
+	for _, a := range attrs {
+		if a.Name.Local == local && a.Name.Space == "" {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// xmlLangAttr returns the value of the xml:lang attribute, if any.
+func xmlLangAttr(attrs []xml.Attr) string {
+	for _, a := range attrs {
+		if a.Name.Local == "lang" && a.Name.Space == nsXML {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// hrefFragment returns the fragment identifier of an href
+// (the part after '#').
+func hrefFragment(href string) string {
+	if i := strings.IndexByte(href, '#'); i >= 0 {
+		return href[i+1:]
+	}
+	return ""
+}
+
+// resolveHref resolves href relative to the document at base.
+// Absolute hrefs are returned unchanged.
+func resolveHref(base, href string) string {
+	h, err := url.Parse(href)
+	if err != nil || h.IsAbs() || path.IsAbs(href) {
+		return href
+	}
+	if b, err := url.Parse(base); err == nil && b.IsAbs() {
+		return b.ResolveReference(h).String()
+	}
+	return path.Join(path.Dir(base), href)
+}
@@ -0,0 +1,268 @@
+package xbrl_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/aethiopicuschan/xbrl-go/pkg/xbrl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const linkbaseInstance = `<?xml version="1.0" encoding="UTF-8"?>
+<xbrli:xbrl
+    xmlns:xbrli="http://www.xbrl.org/2003/instance"
+    xmlns:link="http://www.xbrl.org/2003/linkbase"
+    xmlns:xlink="http://www.w3.org/1999/xlink"
+    xmlns:ex="http://example.com/ex">
+  <link:schemaRef xlink:type="simple" xlink:href="tax/ex.xsd"/>
+  <link:linkbaseRef xlink:type="simple" xlink:href="tax/ex_pre.xml"
+      xlink:arcrole="http://www.w3.org/1999/xlink/properties/linkbase"/>
+</xbrli:xbrl>
+`
+
+const linkbaseSchema = `<?xml version="1.0" encoding="UTF-8"?>
+<xs:schema
+    xmlns:xs="http://www.w3.org/2001/XMLSchema"
+    xmlns:xbrli="http://www.xbrl.org/2003/instance"
+    xmlns:link="http://www.xbrl.org/2003/linkbase"
+    xmlns:xlink="http://www.w3.org/1999/xlink"
+    xmlns:ex="http://example.com/ex"
+    targetNamespace="http://example.com/ex">
+  <xs:annotation>
+    <xs:appinfo>
+      <link:linkbaseRef xlink:type="simple" xlink:href="ex_lab.xml"
+          xlink:role="http://www.xbrl.org/2003/role/labelLinkbaseRef"
+          xlink:arcrole="http://www.w3.org/1999/xlink/properties/linkbase"/>
+    </xs:appinfo>
+  </xs:annotation>
+  <xs:element id="ex_Assets" name="Assets" substitutionGroup="xbrli:item" type="xbrli:monetaryItemType" periodType="instant"/>
+  <xs:element id="ex_Cash" name="Cash" substitutionGroup="xbrli:item" type="xbrli:monetaryItemType" periodType="instant"/>
+</xs:schema>
+`
+
+const labelLinkbase = `<?xml version="1.0" encoding="UTF-8"?>
+<link:linkbase
+    xmlns:link="http://www.xbrl.org/2003/linkbase"
+    xmlns:xlink="http://www.w3.org/1999/xlink">
+  <link:labelLink xlink:type="extended" xlink:role="http://www.xbrl.org/2003/role/link">
+    <link:loc xlink:type="locator" xlink:href="ex.xsd#ex_Assets" xlink:label="Assets"/>
+    <link:label xlink:type="resource" xlink:label="lab_Assets" xlink:role="http://www.xbrl.org/2003/role/label" xml:lang="en">Total assets</link:label>
+    <link:label xlink:type="resource" xlink:label="lab_Assets" xlink:role="http://www.xbrl.org/2003/role/label" xml:lang="ja">資産合計</link:label>
+    <link:label xlink:type="resource" xlink:label="lab_Assets" xlink:role="http://www.xbrl.org/2003/role/terseLabel" xml:lang="en">Assets</link:label>
+    <link:labelArc xlink:type="arc" xlink:arcrole="http://www.xbrl.org/2003/arcrole/concept-label" xlink:from="Assets" xlink:to="lab_Assets"/>
+    <link:loc xlink:type="locator" xlink:href="ex.xsd#ex_Unknown" xlink:label="Unknown"/>
+    <link:label xlink:type="resource" xlink:label="lab_Unknown" xml:lang="en">Ignored</link:label>
+    <link:labelArc xlink:type="arc" xlink:arcrole="http://www.xbrl.org/2003/arcrole/concept-label" xlink:from="Unknown" xlink:to="lab_Unknown"/>
+  </link:labelLink>
+</link:linkbase>
+`
+
+// presentationLinkbase is referenced without a role, so all of its
+// extended links are processed.
+const presentationLinkbase = `<?xml version="1.0" encoding="UTF-8"?>
+<link:linkbase
+    xmlns:link="http://www.xbrl.org/2003/linkbase"
+    xmlns:xlink="http://www.w3.org/1999/xlink">
+  <link:presentationLink xlink:type="extended" xlink:role="http://example.com/role/BalanceSheet">
+    <link:loc xlink:type="locator" xlink:href="ex.xsd#ex_Assets" xlink:label="Assets"/>
+    <link:loc xlink:type="locator" xlink:href="ex.xsd#ex_Cash" xlink:label="Cash"/>
+    <link:presentationArc xlink:type="arc" xlink:arcrole="http://www.xbrl.org/2003/arcrole/parent-child"
+        xlink:from="Assets" xlink:to="Cash" order="2" preferredLabel="http://www.xbrl.org/2003/role/terseLabel"/>
+    <link:presentationArc xlink:type="arc" xlink:arcrole="http://www.xbrl.org/2003/arcrole/parent-child"
+        xlink:from="Cash" xlink:to="Assets" use="prohibited"/>
+  </link:presentationLink>
+  <link:referenceLink xlink:type="extended" xlink:role="http://www.xbrl.org/2003/role/link">
+    <link:loc xlink:type="locator" xlink:href="ex.xsd#ex_Cash" xlink:label="Cash"/>
+    <link:reference xlink:type="resource" xlink:label="ref_Cash">
+      <ref:Name xmlns:ref="http://www.xbrl.org/2006/ref">IAS</ref:Name>
+    </link:reference>
+    <link:referenceArc xlink:type="arc" xlink:arcrole="http://www.xbrl.org/2003/arcrole/concept-reference" xlink:from="Cash" xlink:to="ref_Cash"/>
+  </link:referenceLink>
+</link:linkbase>
+`
+
+// mapOpener returns an opener serving files from the given map.
+func mapOpener(files map[string]string, opened *[]string) func(string) (io.ReadCloser, error) {
+	return func(href string) (io.ReadCloser, error) {
+		if opened != nil {
+			*opened = append(*opened, href)
+		}
+		s, ok := files[href]
+		if !ok {
+			return nil, errors.New("not found")
+		}
+		return io.NopCloser(strings.NewReader(s)), nil
+	}
+}
+
+func TestDocument_LoadLinkbases(t *testing.T) {
+	t.Parallel()
+
+	files := map[string]string{
+		"tax/ex.xsd":     linkbaseSchema,
+		"tax/ex_lab.xml": labelLinkbase,
+		"tax/ex_pre.xml": presentationLinkbase,
+	}
+
+	doc, err := xbrl.Parse(strings.NewReader(linkbaseInstance))
+	require.NoError(t, err)
+
+	refs := doc.LinkbaseRefs()
+	require.Len(t, refs, 1)
+	assert.Equal(t, "tax/ex_pre.xml", refs[0].Href())
+	assert.Equal(t, xbrl.LinkbaseUnknown, refs[0].Type())
+
+	tax, err := doc.LoadTaxonomyFromSchemaRefs(mapOpener(files, nil))
+	require.NoError(t, err)
+
+	schemaRefs := tax.LinkbaseRefs()
+	require.Len(t, schemaRefs, 1)
+	assert.Equal(t, "tax/ex_lab.xml", schemaRefs[0].Href(), "resolved against the schema href")
+	assert.Equal(t, xbrl.LinkbaseLabel, schemaRefs[0].Type())
+
+	var opened []string
+	require.NoError(t, doc.LoadLinkbases(mapOpener(files, &opened)))
+	assert.Equal(t, []string{"tax/ex_pre.xml", "tax/ex_lab.xml"}, opened)
+
+	assets := xbrl.NewQNameForTest("other", "Assets", "http://example.com/ex")
+	cash := xbrl.NewQNameForTest("ex", "Cash", "http://example.com/ex")
+
+	t.Run("labels", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name   string
+			role   string
+			lang   string
+			want   string
+			wantOK bool
+		}{
+			{name: "standard label in English", lang: "en", want: "Total assets", wantOK: true},
+			{name: "standard label in Japanese", lang: "ja", want: "資産合計", wantOK: true},
+			{name: "primary language fallback", lang: "en-US", want: "Total assets", wantOK: true},
+			{name: "any language", want: "Total assets", wantOK: true},
+			{name: "terse label", role: xbrl.RoleTerseLabel, lang: "en", want: "Assets", wantOK: true},
+			{name: "missing language", lang: "fr", wantOK: false},
+			{name: "missing role", role: xbrl.RoleTotalLabel, wantOK: false},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+				got, ok := tax.Label(assets, tt.role, tt.lang)
+				assert.Equal(t, tt.wantOK, ok)
+				assert.Equal(t, tt.want, got)
+			})
+		}
+
+		assert.Len(t, tax.Labels(assets), 3)
+		assert.Nil(t, tax.Labels(cash))
+	})
+
+	t.Run("references", func(t *testing.T) {
+		t.Parallel()
+
+		refs := tax.References(cash)
+		require.Len(t, refs, 1)
+		parts := refs[0].Parts()
+		require.Len(t, parts, 1)
+		assert.Equal(t, "Name", parts[0].Name())
+		assert.Equal(t, "IAS", parts[0].Value())
+	})
+
+	t.Run("relationships", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Empty(t, tax.Relationships(xbrl.ArcroleSummationItem))
+
+		rels := tax.Relationships(xbrl.ArcroleParentChild)
+		require.Len(t, rels, 1, "prohibited arcs are ignored")
+		r := rels[0]
+		assert.Equal(t, "http://example.com/role/BalanceSheet", r.Role())
+		assert.Equal(t, "Assets", r.From().Local())
+		assert.Equal(t, "Cash", r.To().Local())
+		assert.Equal(t, 2.0, r.Order())
+		assert.Equal(t, xbrl.RoleTerseLabel, r.PreferredLabel())
+	})
+}
+
+func TestDocument_LoadLinkbases_Errors(t *testing.T) {
+	t.Parallel()
+
+	doc, err := xbrl.Parse(strings.NewReader(linkbaseInstance))
+	require.NoError(t, err)
+
+	var nilDoc *xbrl.Document
+	assert.Error(t, nilDoc.LoadLinkbases(mapOpener(nil, nil)))
+	assert.Error(t, doc.LoadLinkbases(nil))
+	assert.ErrorIs(t, doc.LoadLinkbases(mapOpener(nil, nil)), xbrl.ErrNoTaxonomy)
+
+	doc.SetTaxonomy(xbrl.NewTaxonomy())
+	err = doc.LoadLinkbases(mapOpener(nil, nil))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "tax/ex_pre.xml")
+
+	err = doc.LoadLinkbases(mapOpener(map[string]string{"tax/ex_pre.xml": "<broken"}, nil))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "parse linkbaseRef")
+}
+
+func TestTaxonomy_ParseLinkbase_TypeFilter(t *testing.T) {
+	t.Parallel()
+
+	q := xbrl.NewQNameForTest("ex", "Assets", "http://example.com/ex")
+	newTax := func() *xbrl.Taxonomy {
+		return xbrl.NewTaxonomyForTest(map[xbrl.QName]*xbrl.Concept{
+			q: xbrl.NewConceptForTest(q, "ex_Assets", xbrl.QName{}, xbrl.QName{}, false, false, "", ""),
+		})
+	}
+
+	tests := []struct {
+		name      string
+		typ       xbrl.LinkbaseType
+		wantLabel bool
+	}{
+		{name: "unknown type processes all links", typ: xbrl.LinkbaseUnknown, wantLabel: true},
+		{name: "matching type", typ: xbrl.LinkbaseLabel, wantLabel: true},
+		{name: "other type skips label links", typ: xbrl.LinkbasePresentation, wantLabel: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tax := newTax()
+			require.NoError(t, tax.ParseLinkbase(strings.NewReader(labelLinkbase), tt.typ))
+			_, ok := tax.Label(q, "", "en")
+			assert.Equal(t, tt.wantLabel, ok)
+		})
+	}
+
+	var nilTax *xbrl.Taxonomy
+	assert.ErrorIs(t, nilTax.ParseLinkbase(strings.NewReader(labelLinkbase), xbrl.LinkbaseLabel), xbrl.ErrNoTaxonomy)
+}
+
+func TestLinkbaseType_String(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		typ  xbrl.LinkbaseType
+		want string
+	}{
+		{xbrl.LinkbaseUnknown, "unknown"},
+		{xbrl.LinkbaseLabel, "label"},
+		{xbrl.LinkbasePresentation, "presentation"},
+		{xbrl.LinkbaseCalculation, "calculation"},
+		{xbrl.LinkbaseDefinition, "definition"},
+		{xbrl.LinkbaseReference, "reference"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.typ.String())
+		})
+	}
+}
@@ -1,6 +1,49 @@
 package xbrl
 
-import "strings"
+import (
+	"strings"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// NormalizeOptions selects the transformations applied by NormalizeText.
+//
+// The zero value leaves the input unchanged.
+type NormalizeOptions struct {
+	// NFKC applies Unicode NFKC normalization, folding compatibility
+	// characters such as full-width ASCII ("１２３" -> "123"),
+	// half-width katakana and ligatures to their canonical forms.
+	NFKC bool
+
+	// CollapseSpace converts space-like runes (NBSP, ideographic space)
+	// to ASCII space, collapses runs of whitespace into a single space
+	// and trims the result, as Fact.NormalizedValue does.
+	CollapseSpace bool
+
+	// FoldCase applies Unicode case folding for case-insensitive
+	// comparison.
+	FoldCase bool
+}
+
+// NormalizeText normalizes s according to opts.
+//
+// It is intended for matching concept names, labels and values across
+// documents, especially Japanese filings that mix full-width and
+// half-width characters. Transformations are applied in the order
+// NFKC, case folding, space collapsing.
+func NormalizeText(s string, opts NormalizeOptions) string {
+	if opts.NFKC {
+		s = norm.NFKC.String(s)
+	}
+	if opts.FoldCase {
+		s = cases.Fold().String(s)
+	}
+	if opts.CollapseSpace {
+		s = normalizeSpace(s)
+	}
+	return s
+}
 
 // normalizeSpace replaces several space-like runes with ASCII space
 // and collapses consecutive whitespace into a single space.
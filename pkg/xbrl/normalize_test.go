@@ -50,3 +50,64 @@ func TestNormalizeSpace(t *testing.T) {
 		})
 	}
 }
+
+func TestNormalizeText(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		in   string
+		opts xbrl.NormalizeOptions
+		want string
+	}{
+		{
+			name: "zero options leave input unchanged",
+			in:   " １２３　ABC ",
+			opts: xbrl.NormalizeOptions{},
+			want: " １２３　ABC ",
+		},
+		{
+			name: "NFKC folds full-width digits and letters",
+			in:   "売上高１２３ＡＢＣ",
+			opts: xbrl.NormalizeOptions{NFKC: true},
+			want: "売上高123ABC",
+		},
+		{
+			name: "NFKC folds half-width katakana and ligatures",
+			in:   "ｶﾌﾞｼｷﬁ",
+			opts: xbrl.NormalizeOptions{NFKC: true},
+			want: "カブシキfi",
+		},
+		{
+			name: "NFKC and space collapsing",
+			in:   "　１，２３４　　百万円  ",
+			opts: xbrl.NormalizeOptions{NFKC: true, CollapseSpace: true},
+			want: "1,234 百万円",
+		},
+		{
+			name: "space collapsing only keeps full-width digits",
+			in:   " １２３  ４ ",
+			opts: xbrl.NormalizeOptions{CollapseSpace: true},
+			want: "１２３ ４",
+		},
+		{
+			name: "case folding",
+			in:   "NetSales STRASSE Straße",
+			opts: xbrl.NormalizeOptions{FoldCase: true},
+			want: "netsales strasse strasse",
+		},
+		{
+			name: "all options",
+			in:   "  ＮｅｔＳａｌｅｓ　Ｔｏｔａｌ ",
+			opts: xbrl.NormalizeOptions{NFKC: true, CollapseSpace: true, FoldCase: true},
+			want: "netsales total",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, xbrl.NormalizeText(tt.in, tt.opts))
+		})
+	}
+}
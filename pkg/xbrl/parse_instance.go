@@ -1,6 +1,8 @@
 package xbrl
 
 import (
+	"bufio"
+	"bytes"
 	"encoding/xml"
 	"fmt"
 	"io"
@@ -20,8 +22,189 @@ func ParseFile(path string) (*Document, error) {
 	return Parse(f)
 }
 
+// ParseOptions configures optional behavior of ParseWithOptions.
+//
+// The zero value gives the same behavior as Parse.
+type ParseOptions struct {
+	// OnUnknownElement, if set, is called for each top-level element (a
+	// direct child of the xbrl element) that is neither a schemaRef,
+	// context, unit, footnoteLink, nor a detected fact. innerXML is the
+	// raw inner XML of the element. The element is consumed, so facts
+	// nested inside it are not detected.
+	OnUnknownElement func(se xml.StartElement, innerXML string)
+
+	// StrictDates makes parsing fail if a context's instant, startDate or
+	// endDate is not a valid xsd:date or xsd:dateTime lexical form.
+	// By default dates are stored as-is without validation.
+	StrictDates bool
+
+	// RawIdentifiers keeps entity identifier values and explicit member
+	// text (see Dimension.MemberText) exactly as they appear in the
+	// instance. By default surrounding whitespace is trimmed. Member
+	// QNames are always resolved from the trimmed text.
+	RawIdentifiers bool
+
+	// StrictPeriod makes parsing fail if a context's period repeats an
+	// instant, startDate, endDate or forever element. By default the last
+	// occurrence silently wins. Errors wrap ErrInvalidContext.
+	StrictPeriod bool
+
+	// ResolveReferences attaches the context and unit of each fact to the
+	// fact after parsing, so that Fact.Context and Fact.Unit work without
+	// the Document. Facts may precede their contexts and units in the
+	// source.
+	ResolveReferences bool
+
+	// CaptureSource keeps the raw outer XML of each fact, available via
+	// Fact.SourceXML. The whole input is buffered while parsing, so this
+	// is off by default.
+	CaptureSource bool
+
+	// FactDetector, if set, decides which elements are parsed as item
+	// facts, replacing the default rule (the element has a contextRef
+	// attribute). It is consulted for every element inside the root that
+	// is not a schemaRef, linkbaseRef, context, footnoteLink or unit, nor
+	// in one of the NonFactNamespaces. Elements it rejects are descended
+	// into, so facts nested inside them are still found.
+	FactDetector func(se xml.StartElement) bool
+
+	// NonFactNamespaces lists namespace URIs whose elements are never
+	// parsed as facts, even if they carry a contextRef or FactDetector
+	// accepts them. If nil, the XBRL instance, linkbase and XLink
+	// namespaces are used; set it to an empty slice to consider elements of
+	// every namespace.
+	NonFactNamespaces []string
+
+	// LenientDimensions accepts explicitMember and typedMember elements
+	// of any namespace in a context's segment and scenario. By default
+	// only those in the XBRL Dimensions namespace
+	// (http://xbrl.org/2006/xbrldi) are parsed as dimensions, and others
+	// are ignored.
+	LenientDimensions bool
+
+	// KeepFact, if set, is called for each detected fact before it is
+	// parsed. Facts it rejects are skipped without being stored, which
+	// caps memory when only a few facts are needed. Contexts and units
+	// are always kept.
+	KeepFact func(se xml.StartElement) bool
+
+	// RootPrefixes sets the prefix of each fact's QName from the namespaces
+	// in scope on the xbrl root element, so that facts display with the
+	// prefix the document declares up front even if an inner element
+	// redeclares the namespace under another prefix. Facts whose namespace
+	// is not declared on the root keep the prefix from their own scope.
+	RootPrefixes bool
+
+	// ResolveXInclude expands xi:include elements before parsing, so that
+	// an instance assembled from several files with XInclude is parsed as
+	// one document. Included documents are read with XIncludeOpener.
+	// Include cycles are reported as errors. It is off by default.
+	ResolveXInclude bool
+
+	// XIncludeOpener opens the document referenced by an xi:include. It
+	// receives the href resolved against the href of the including
+	// document; hrefs in the top-level document are passed as-is. The
+	// returned reader is read to the end and closed by the parser.
+	// It is required when ResolveXInclude is set.
+	XIncludeOpener func(href string) (io.ReadCloser, error)
+}
+
+// defaultNonFactNamespaces are the namespaces of the XBRL instance,
+// linkbase and XLink specifications, whose elements are not facts.
+var defaultNonFactNamespaces = []string{nsXBRLI, nsLink, nsXLink}
+
 // Parse parses an XBRL instance document from an io.Reader.
+//
+// The xbrl element need not be the document element: an instance wrapped
+// in an envelope, such as a SOAP body or a custom container, is parsed as
+// if its xbrl element were the root. Elements outside the xbrl element
+// are ignored.
 func Parse(r io.Reader) (*Document, error) {
+	return ParseWithOptions(r, ParseOptions{})
+}
+
+// ParseFileWithOptions parses an XBRL instance document from a file path
+// using the given options.
+func ParseFileWithOptions(path string, opts ParseOptions) (*Document, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("xbrl: open file: %w", err)
+	}
+	defer f.Close()
+
+	return ParseWithOptions(f, opts)
+}
+
+// ParseFileWithProgress parses an XBRL instance document from a file path,
+// calling progress as the file is read so that callers can report
+// progress on large files. bytesRead is the number of bytes consumed so
+// far and totalBytes is the file size at the time it was opened.
+//
+// progress is called after every read from the file, and once more with
+// the final count when the end of the file is reached. A nil progress
+// behaves like ParseFile.
+func ParseFileWithProgress(path string, progress func(bytesRead, totalBytes int64)) (*Document, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("xbrl: open file: %w", err)
+	}
+	defer f.Close()
+
+	if progress == nil {
+		return Parse(f)
+	}
+	st, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("xbrl: stat file: %w", err)
+	}
+	return Parse(&progressReader{r: f, total: st.Size(), progress: progress})
+}
+
+// progressReader reports the number of bytes read from r to progress.
+type progressReader struct {
+	r        io.Reader
+	read     int64
+	total    int64
+	progress func(bytesRead, totalBytes int64)
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	p.read += int64(n)
+	if n > 0 || err == io.EOF {
+		p.progress(p.read, p.total)
+	}
+	return n, err
+}
+
+// ParseWithOptions parses an XBRL instance document from an io.Reader
+// using the given options.
+func ParseWithOptions(r io.Reader, opts ParseOptions) (*Document, error) {
+	r = skipBOM(r)
+
+	if opts.ResolveXInclude {
+		if opts.XIncludeOpener == nil {
+			return nil, fmt.Errorf("xbrl: XIncludeOpener is nil")
+		}
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return nil, fmt.Errorf("xbrl: read input: %w", err)
+		}
+		data, err = expandXInclude(data, "", opts.XIncludeOpener, nil)
+		if err != nil {
+			return nil, err
+		}
+		r = bytes.NewReader(data)
+	}
+
+	// source records the bytes read by the decoder, so that decoder
+	// offsets index into it.
+	var source *bytes.Buffer
+	if opts.CaptureSource {
+		source = new(bytes.Buffer)
+		r = io.TeeReader(r, source)
+	}
+
 	dec := xml.NewDecoder(r)
 	dec.CharsetReader = charsetReader
 
@@ -31,20 +214,48 @@ func Parse(r io.Reader) (*Document, error) {
 
 	nsMap := newNamespaceStack()
 
+	isFact := factDetector(opts)
+
+	// depth is the element depth of the current token (root = 1).
+	depth := 0
+
+	// rootDepth is the depth of the xbrl element once it has been seen.
+	// It is 1 unless the instance is wrapped in an envelope such as a
+	// SOAP body. Only elements inside the xbrl element are detected, so
+	// envelope content before or after it is ignored.
+	rootDepth, rootSeen, rootClosed := 0, false, false
+
+	// consumed restores the namespace stack and depth after a sub-parser
+	// consumed an element including its end tag.
+	consumed := func(se xml.StartElement) {
+		nsMap.Pop(xml.EndElement{Name: se.Name})
+		depth--
+	}
+
 	for {
+		// tokStart is the offset of the token about to be read.
+		tokStart := dec.InputOffset()
 		tok, err := dec.Token()
 		if err == io.EOF {
 			break
 		}
 		if err != nil {
-			return nil, fmt.Errorf("xbrl: decode token: %w", err)
+			return nil, positionError(dec, "decode token", err)
 		}
 
 		switch t := tok.(type) {
 		case xml.StartElement:
 			nsMap.Push(t)
+			depth++
 
 			if isXbrlRoot(t) {
+				if !rootSeen {
+					rootDepth, rootSeen = depth, true
+					doc.rootAttrs = append([]xml.Attr(nil), t.Attr...)
+				}
+				continue
+			}
+			if !rootSeen || rootClosed {
 				continue
 			}
 
@@ -53,46 +264,159 @@ func Parse(r io.Reader) (*Document, error) {
 				sr := parseSchemaRef(t)
 				doc.schemaRefs = append(doc.schemaRefs, sr)
 
+			case t.Name.Local == "linkbaseRef":
+				doc.linkbaseRefs = append(doc.linkbaseRefs, parseLinkbaseRef(t))
+
 			case t.Name.Local == "context":
-				ctx, err := parseContext(dec, t, nsMap)
+				ctx, err := parseContext(dec, t, nsMap, opts)
 				if err != nil {
 					return nil, err
 				}
+				if opts.StrictDates {
+					if err := ctx.period.validateDates(ctx.id); err != nil {
+						return nil, err
+					}
+				}
+				if _, ok := doc.contexts[ctx.id]; ok {
+					doc.warnf("duplicate context ID %q; the last definition is used", ctx.id)
+				}
 				doc.contexts[ctx.id] = ctx
+				if len(ctx.dimensions) > 0 {
+					doc.hasDimensions = true
+				}
+				consumed(t)
+
+			case t.Name.Local == "footnoteLink":
+				if err := doc.parseFootnoteLink(dec, t); err != nil {
+					return nil, err
+				}
+				consumed(t)
 
 			case t.Name.Local == "unit":
 				unit, err := parseUnit(dec, t, nsMap)
 				if err != nil {
 					return nil, err
 				}
+				doc.checkUnit(unit)
 				doc.units[unit.id] = unit
+				consumed(t)
 
-			default:
-				// item facts (simplified detection)
-				if hasAttr(t.Attr, "contextRef") {
-					fact, err := parseItemFact(dec, t, nsMap)
-					if err != nil {
-						return nil, err
+			case isFact(t):
+				if opts.KeepFact != nil && !opts.KeepFact(t) {
+					if err := dec.Skip(); err != nil {
+						return nil, positionError(dec, "skip fact", err)
+					}
+					consumed(t)
+					continue
+				}
+				fact, err := parseItemFact(dec, t, nsMap)
+				if err != nil {
+					return nil, err
+				}
+				if opts.RootPrefixes {
+					if p, ok := nsMap.PrefixForURIAt(rootDepth, fact.name.uri); ok {
+						fact.name.prefix = p
 					}
-					doc.facts = append(doc.facts, fact)
 				}
+				fact.index = len(doc.facts)
+				doc.checkFact(fact, t)
+				if source != nil {
+					fact.source = string(source.Bytes()[tokStart:dec.InputOffset()])
+				}
+				doc.facts = append(doc.facts, fact)
+				consumed(t)
+
+			case depth == rootDepth+1 && opts.OnUnknownElement != nil:
+				inner, err := decodeInnerXML(dec, t)
+				if err != nil {
+					return nil, err
+				}
+				opts.OnUnknownElement(t, inner)
+				consumed(t)
 			}
 
 		case xml.EndElement:
+			if rootSeen && depth == rootDepth {
+				rootClosed = true
+			}
 			nsMap.Pop(t)
+			depth--
 		}
 	}
 
+	if opts.ResolveReferences {
+		doc.resolveReferences()
+	}
 	return &doc, nil
 }
 
+// ParseWithTaxonomy parses an XBRL instance document, detecting facts by
+// their concepts in tax instead of by the contextRef attribute: an
+// element is a fact if its namespace URI and local name resolve to an
+// item concept of tax. Tuples are not facts themselves; the items nested
+// in them are detected. The taxonomy is attached to the returned
+// Document.
+func ParseWithTaxonomy(r io.Reader, tax *Taxonomy) (*Document, error) {
+	if tax == nil {
+		return nil, ErrNoTaxonomy
+	}
+	concepts := conceptsByKey(tax)
+	doc, err := ParseWithOptions(r, ParseOptions{
+		FactDetector: func(se xml.StartElement) bool {
+			return concepts[conceptKey{se.Name.Space, se.Name.Local}].IsItem()
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	doc.setTaxonomy(tax)
+	return doc, nil
+}
+
+// ParseFiltered parses an XBRL instance document, keeping only the facts
+// whose start element passes keep. Other facts are discarded while
+// parsing rather than after, so extracting a few facts from a large
+// instance does not hold all of them in memory. Contexts and units are
+// always kept. See ParseOptions.KeepFact.
+func ParseFiltered(r io.Reader, keep func(se xml.StartElement) bool) (*Document, error) {
+	return ParseWithOptions(r, ParseOptions{KeepFact: keep})
+}
+
 // ---------- Element detection / small parsers ----------
 
 func isXbrlRoot(se xml.StartElement) bool {
-	// XBRL root element is usually "xbrl"
+	// XBRL root element is usually "xbrl". Only the local name is
+	// compared, so any prefix (or none) is accepted.
 	return strings.EqualFold(se.Name.Local, "xbrl")
 }
 
+// factDetector returns the fact detection configured by opts: elements of
+// the NonFactNamespaces are never facts, and other elements are checked
+// with FactDetector, or isItemFact if it is unset.
+func factDetector(opts ParseOptions) func(se xml.StartElement) bool {
+	isFact := opts.FactDetector
+	if isFact == nil {
+		isFact = isItemFact
+	}
+	nonFactNS := opts.NonFactNamespaces
+	if nonFactNS == nil {
+		nonFactNS = defaultNonFactNamespaces
+	}
+	isNonFact := make(map[string]bool, len(nonFactNS))
+	for _, uri := range nonFactNS {
+		isNonFact[uri] = true
+	}
+	return func(se xml.StartElement) bool {
+		return !isNonFact[se.Name.Space] && isFact(se)
+	}
+}
+
+// isItemFact is the default fact detection: item facts carry a
+// contextRef attribute.
+func isItemFact(se xml.StartElement) bool {
+	return hasAttr(se.Attr, "contextRef")
+}
+
 func isSchemaRef(se xml.StartElement) bool {
 	return se.Name.Local == "schemaRef"
 }
@@ -108,7 +432,7 @@ func parseSchemaRef(se xml.StartElement) SchemaRef {
 	return SchemaRef{href: href}
 }
 
-func parseContext(dec *xml.Decoder, start xml.StartElement, ns *namespaceStack) (*Context, error) {
+func parseContext(dec *xml.Decoder, start xml.StartElement, ns *namespaceStack, opts ParseOptions) (*Context, error) {
 	ctx := &Context{}
 	for _, a := range start.Attr {
 		if a.Name.Local == "id" {
@@ -117,37 +441,38 @@ func parseContext(dec *xml.Decoder, start xml.StartElement, ns *namespaceStack)
 	}
 
 	var dims []Dimension
+	what := fmt.Sprintf("parse context %q", ctx.id)
 
 	for {
 		tok, err := dec.Token()
 		if err != nil {
-			return nil, fmt.Errorf("xbrl: parse context: %w", err)
+			return nil, positionError(dec, what, err)
 		}
 		switch t := tok.(type) {
 		case xml.StartElement:
 			switch t.Name.Local {
 			case "entity":
-				ent, segDims, err := parseEntity(dec, t, ns)
+				ent, segDims, err := parseEntity(dec, t, ns, opts)
 				if err != nil {
-					return nil, err
+					return nil, positionError(dec, what, err)
 				}
 				ctx.entity = *ent
 				dims = append(dims, segDims...)
 			case "period":
-				p, err := parsePeriod(dec, t)
+				p, err := parsePeriod(dec, t, opts)
 				if err != nil {
-					return nil, err
+					return nil, positionError(dec, what, err)
 				}
 				ctx.period = *p
 			case "scenario":
-				scnDims, err := parseDimensionsContainer(dec, t, ns)
+				scnDims, err := parseDimensionsContainer(dec, t, ns, opts)
 				if err != nil {
-					return nil, err
+					return nil, positionError(dec, what, err)
 				}
 				dims = append(dims, scnDims...)
 			default:
 				if err := dec.Skip(); err != nil {
-					return nil, err
+					return nil, positionError(dec, what, err)
 				}
 			}
 		case xml.EndElement:
@@ -159,7 +484,7 @@ func parseContext(dec *xml.Decoder, start xml.StartElement, ns *namespaceStack)
 	}
 }
 
-func parseEntity(dec *xml.Decoder, start xml.StartElement, ns *namespaceStack) (*Entity, []Dimension, error) {
+func parseEntity(dec *xml.Decoder, start xml.StartElement, ns *namespaceStack, opts ParseOptions) (*Entity, []Dimension, error) {
 	ent := &Entity{}
 	var dims []Dimension
 
@@ -182,10 +507,13 @@ func parseEntity(dec *xml.Decoder, start xml.StartElement, ns *namespaceStack) (
 				if err := dec.DecodeElement(&value, &t); err != nil {
 					return nil, nil, fmt.Errorf("xbrl: parse identifier: %w", err)
 				}
-				ident.value = strings.TrimSpace(value)
+				ident.value = value
+				if !opts.RawIdentifiers {
+					ident.value = strings.TrimSpace(value)
+				}
 				ent.identifier = ident
 			case "segment":
-				segDims, err := parseDimensionsContainer(dec, t, ns)
+				segDims, err := parseDimensionsContainer(dec, t, ns, opts)
 				if err != nil {
 					return nil, nil, err
 				}
@@ -203,8 +531,12 @@ func parseEntity(dec *xml.Decoder, start xml.StartElement, ns *namespaceStack) (
 	}
 }
 
-func parsePeriod(dec *xml.Decoder, start xml.StartElement) (*Period, error) {
+func parsePeriod(dec *xml.Decoder, start xml.StartElement, opts ParseOptions) (*Period, error) {
 	p := &Period{}
+	var seen map[string]bool // period children seen, in strict mode
+	if opts.StrictPeriod {
+		seen = make(map[string]bool)
+	}
 	for {
 		tok, err := dec.Token()
 		if err != nil {
@@ -212,6 +544,15 @@ func parsePeriod(dec *xml.Decoder, start xml.StartElement) (*Period, error) {
 		}
 		switch t := tok.(type) {
 		case xml.StartElement:
+			if opts.StrictPeriod {
+				switch name := t.Name.Local; name {
+				case "instant", "startDate", "endDate", "forever":
+					if seen[name] {
+						return nil, fmt.Errorf("%w: period has multiple %s elements", ErrInvalidContext, name)
+					}
+					seen[name] = true
+				}
+			}
 			switch t.Name.Local {
 			case "instant":
 				var v string
@@ -260,10 +601,12 @@ func parseUnit(dec *xml.Decoder, start xml.StartElement, ns *namespaceStack) (*U
 		}
 	}
 
+	what := fmt.Sprintf("parse unit %q", u.id)
+
 	for {
 		tok, err := dec.Token()
 		if err != nil {
-			return nil, fmt.Errorf("xbrl: parse unit: %w", err)
+			return nil, positionError(dec, what, err)
 		}
 		switch t := tok.(type) {
 		case xml.StartElement:
@@ -272,21 +615,21 @@ func parseUnit(dec *xml.Decoder, start xml.StartElement, ns *namespaceStack) (*U
 				// simple unit measure (top-level)
 				q, err := parseMeasureElement(dec, t, ns)
 				if err != nil {
-					return nil, err
+					return nil, positionError(dec, what, err)
 				}
 				u.measures = append(u.measures, q)
 			case "divide":
 				// divide unit
 				num, den, err := parseDivide(dec, t, ns)
 				if err != nil {
-					return nil, err
+					return nil, positionError(dec, what, err)
 				}
 				u.divide = true
 				u.numerator = num
 				u.denominator = den
 			default:
 				if err := dec.Skip(); err != nil {
-					return nil, err
+					return nil, positionError(dec, what, err)
 				}
 			}
 		case xml.EndElement:
@@ -421,17 +764,20 @@ func parseItemFact(dec *xml.Decoder, start xml.StartElement, ns *namespaceStack)
 			f.lang = a.Value
 		}
 
-		// xsi:nil="true"
-		if a.Name.Space == "http://www.w3.org/2001/XMLSchema-instance" && a.Name.Local == "nil" {
-			if strings.EqualFold(a.Value, "true") {
-				f.nil = true
-			}
+		// xsi:nil="true"; invalid values are reported by checkFact.
+		if a.Name.Space == nsXSI && a.Name.Local == "nil" {
+			f.nil, _ = parseXSDBool(a.Value)
 		}
 	}
 
-	var value string
-	if err := dec.DecodeElement(&value, &start); err != nil {
-		return nil, fmt.Errorf("xbrl: parse fact %s: %w", start.Name.Local, err)
+	// Inherit xml:lang from the nearest ancestor if not set on the fact.
+	if f.lang == "" && ns != nil {
+		f.lang = ns.Lang()
+	}
+
+	value, err := readElementText(dec, start)
+	if err != nil {
+		return nil, positionError(dec, "parse fact "+start.Name.Local, err)
 	}
 	f.value = strings.TrimSpace(value)
 
@@ -440,9 +786,17 @@ func parseItemFact(dec *xml.Decoder, start xml.StartElement, ns *namespaceStack)
 
 // parseDimensionsContainer parses a <segment> or <scenario> element and
 // returns all explicit/typed dimensions contained within it.
-func parseDimensionsContainer(dec *xml.Decoder, start xml.StartElement, ns *namespaceStack) ([]Dimension, error) {
+func parseDimensionsContainer(dec *xml.Decoder, start xml.StartElement, ns *namespaceStack, opts ParseOptions) ([]Dimension, error) {
 	var dims []Dimension
 
+	source := DimensionSourceUnknown
+	switch start.Name.Local {
+	case "segment":
+		source = DimensionSourceSegment
+	case "scenario":
+		source = DimensionSourceScenario
+	}
+
 	for {
 		tok, err := dec.Token()
 		if err != nil {
@@ -450,18 +804,23 @@ func parseDimensionsContainer(dec *xml.Decoder, start xml.StartElement, ns *name
 		}
 		switch t := tok.(type) {
 		case xml.StartElement:
-			switch t.Name.Local {
-			case "explicitMember":
-				d, err := parseExplicitMember(dec, t, ns)
+			// Members in other namespaces are not dimensions unless
+			// LenientDimensions is set.
+			member := opts.LenientDimensions || t.Name.Space == nsXBRLDI
+			switch {
+			case member && t.Name.Local == "explicitMember":
+				d, err := parseExplicitMember(dec, t, ns, opts)
 				if err != nil {
 					return nil, err
 				}
+				d.source = source
 				dims = append(dims, d)
-			case "typedMember":
+			case member && t.Name.Local == "typedMember":
 				d, err := parseTypedMember(dec, t, ns)
 				if err != nil {
 					return nil, err
 				}
+				d.source = source
 				dims = append(dims, d)
 			default:
 				if err := dec.Skip(); err != nil {
@@ -476,7 +835,7 @@ func parseDimensionsContainer(dec *xml.Decoder, start xml.StartElement, ns *name
 	}
 }
 
-func parseExplicitMember(dec *xml.Decoder, start xml.StartElement, ns *namespaceStack) (Dimension, error) {
+func parseExplicitMember(dec *xml.Decoder, start xml.StartElement, ns *namespaceStack, opts ParseOptions) (Dimension, error) {
 	var dimName string
 	for _, a := range start.Attr {
 		if a.Name.Local == "dimension" {
@@ -502,11 +861,15 @@ func parseExplicitMember(dec *xml.Decoder, start xml.StartElement, ns *namespace
 	}
 
 	// member QName from element text
-	var value string
-	if err := dec.DecodeElement(&value, &start); err != nil {
+	var raw string
+	if err := dec.DecodeElement(&raw, &start); err != nil {
 		return Dimension{}, fmt.Errorf("xbrl: parse explicitMember: %w", err)
 	}
-	value = strings.TrimSpace(value)
+	value := strings.TrimSpace(raw)
+	memberText := value
+	if opts.RawIdentifiers {
+		memberText = raw
+	}
 	memPrefix := prefixOf(value)
 	memLocal := localOf(value)
 	memURI := ""
@@ -527,6 +890,7 @@ func parseExplicitMember(dec *xml.Decoder, start xml.StartElement, ns *namespace
 		dimension:  dimQ,
 		explicit:   true,
 		member:     memQ,
+		memberText: memberText,
 		typedValue: "",
 	}, nil
 }
@@ -577,6 +941,72 @@ func parseTypedMember(dec *xml.Decoder, start xml.StartElement, ns *namespaceSta
 
 // ---------- small utilities ----------
 
+// positionError wraps err with the decoder's current line, column and
+// byte offset so that errors in large documents can be located.
+func positionError(dec *xml.Decoder, what string, err error) error {
+	line, col := dec.InputPos()
+	return &positionErr{
+		msg: fmt.Sprintf("xbrl: %s at line %d, column %d (offset %d)", what, line, col, dec.InputOffset()),
+		err: err,
+	}
+}
+
+// positionErr is the error returned by positionError. The "xbrl: " prefix
+// of the wrapped error is dropped from the message, so that errors of
+// this package are not prefixed twice.
+type positionErr struct {
+	msg string
+	err error
+}
+
+func (e *positionErr) Error() string {
+	return e.msg + ": " + strings.TrimPrefix(e.err.Error(), "xbrl: ")
+}
+
+func (e *positionErr) Unwrap() error {
+	return e.err
+}
+
+// readElementText consumes the element started by start and returns its
+// character data.
+//
+// Text split across several chunks is concatenated: CDATA sections are
+// included verbatim, while comments and processing instructions are
+// ignored. Content of child elements is skipped.
+func readElementText(dec *xml.Decoder, start xml.StartElement) (string, error) {
+	var sb strings.Builder
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return "", err
+		}
+		switch t := tok.(type) {
+		case xml.CharData:
+			sb.Write(t)
+		case xml.StartElement:
+			if err := dec.Skip(); err != nil {
+				return "", err
+			}
+		case xml.EndElement:
+			if t.Name == start.Name {
+				return sb.String(), nil
+			}
+		}
+	}
+}
+
+// decodeInnerXML consumes the element started by start and returns its
+// raw inner XML.
+func decodeInnerXML(dec *xml.Decoder, start xml.StartElement) (string, error) {
+	var in struct {
+		XML string `xml:",innerxml"`
+	}
+	if err := dec.DecodeElement(&in, &start); err != nil {
+		return "", fmt.Errorf("xbrl: parse element %s: %w", start.Name.Local, err)
+	}
+	return in.XML, nil
+}
+
 func hasAttr(attrs []xml.Attr, local string) bool {
 	for _, a := range attrs {
 		if a.Name.Local == local {
@@ -602,6 +1032,18 @@ func localOf(s string) string {
 	return s[i+1:]
 }
 
+// utf8BOM is the UTF-8 encoded byte order mark.
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// skipBOM returns a reader that yields r without a leading UTF-8 BOM.
+func skipBOM(r io.Reader) io.Reader {
+	br := bufio.NewReader(r)
+	if b, err := br.Peek(len(utf8BOM)); err == nil && bytes.Equal(b, utf8BOM) {
+		_, _ = br.Discard(len(utf8BOM))
+	}
+	return br
+}
+
 // charsetReader is a placeholder. For now, we assume UTF-8 only.
 func charsetReader(charset string, input io.Reader) (io.Reader, error) {
 	// TODO : implement charset decoding if needed
@@ -612,11 +1054,13 @@ func charsetReader(charset string, input io.Reader) (io.Reader, error) {
 
 type namespaceStack struct {
 	stack []map[string]string // prefix -> URI
+	langs []string            // in-scope xml:lang per level
 }
 
 func newNamespaceStack() *namespaceStack {
 	return &namespaceStack{
 		stack: []map[string]string{{}},
+		langs: []string{""},
 	}
 }
 
@@ -627,6 +1071,8 @@ func (ns *namespaceStack) Push(se xml.StartElement) {
 		maps.Copy(top, ns.stack[len(ns.stack)-1])
 	}
 
+	lang := ns.Lang()
+
 	for _, a := range se.Attr {
 		if a.Name.Space == "xmlns" {
 			// xmlns:prefix="URI"
@@ -634,17 +1080,31 @@ func (ns *namespaceStack) Push(se xml.StartElement) {
 		} else if a.Name.Local == "xmlns" && a.Name.Space == "" {
 			// default namespace: xmlns="URI"
 			top[""] = a.Value
+		} else if a.Name.Space == nsXML && a.Name.Local == "lang" {
+			// xml:lang is inherited by descendants
+			lang = a.Value
 		}
 	}
 
 	ns.stack = append(ns.stack, top)
+	ns.langs = append(ns.langs, lang)
 }
 
 // Pop removes the top namespace context from the stack.
 func (ns *namespaceStack) Pop(_ xml.EndElement) {
 	if len(ns.stack) > 1 {
 		ns.stack = ns.stack[:len(ns.stack)-1]
+		ns.langs = ns.langs[:len(ns.langs)-1]
+	}
+}
+
+// Lang returns the xml:lang in scope for the current element, or an
+// empty string if none is declared.
+func (ns *namespaceStack) Lang() string {
+	if len(ns.langs) == 0 {
+		return ""
 	}
+	return ns.langs[len(ns.langs)-1]
 }
 
 // URIForPrefix returns the namespace URI for the given prefix in the current namespace context.
@@ -656,6 +1116,27 @@ func (ns *namespaceStack) URIForPrefix(prefix string) string {
 	return top[prefix]
 }
 
+// PrefixForURIAt returns the prefix in scope for the given URI at the
+// element of the given depth (root = 1) on the current path. If several
+// are in scope, the lexically smallest is returned so that the result is
+// stable.
+func (ns *namespaceStack) PrefixForURIAt(depth int, uri string) (string, bool) {
+	// stack[0] is the empty scope outside the root element.
+	if depth < 1 || depth >= len(ns.stack) || uri == "" {
+		return "", false
+	}
+	var (
+		prefix string
+		found  bool
+	)
+	for p, u := range ns.stack[depth] {
+		if u == uri && (!found || p < prefix) {
+			prefix, found = p, true
+		}
+	}
+	return prefix, found
+}
+
 // PrefixForURI returns the first prefix found for the given URI in the current namespace context.
 func (ns *namespaceStack) PrefixForURI(uri string) string {
 	if len(ns.stack) == 0 || uri == "" {
@@ -1,8 +1,12 @@
 package xbrl_test
 
 import (
+	"encoding/xml"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -54,9 +58,9 @@ const extendedInstance = `<?xml version="1.0" encoding="utf-8"?>
         ABC
       </xbrli:identifier>
       <xbrli:segment>
-        <xbrli:explicitMember dimension="ex:Region">
+        <xbrldi:explicitMember xmlns:xbrldi="http://xbrl.org/2006/xbrldi" dimension="ex:Region">
           ex:Japan
-        </xbrli:explicitMember>
+        </xbrldi:explicitMember>
       </xbrli:segment>
     </xbrli:entity>
     <xbrli:period>
@@ -64,9 +68,9 @@ const extendedInstance = `<?xml version="1.0" encoding="utf-8"?>
       <xbrli:endDate>2025-12-31</xbrli:endDate>
     </xbrli:period>
     <xbrli:scenario>
-      <xbrli:typedMember dimension="ex:Scenario">
+      <xbrldi:typedMember xmlns:xbrldi="http://xbrl.org/2006/xbrldi" dimension="ex:Scenario">
         <ex:ScenarioType> Base </ex:ScenarioType>
-      </xbrli:typedMember>
+      </xbrldi:typedMember>
     </xbrli:scenario>
   </xbrli:context>
 
@@ -192,6 +196,71 @@ func TestParse_ErrorCases(t *testing.T) {
 	}
 }
 
+func TestParse_ErrorPosition(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		xml      string
+		wantWhat string
+		wantLine int
+	}{
+		{
+			name: "malformed context",
+			xml: `<xbrli:xbrl xmlns:xbrli="http://www.xbrl.org/2003/instance">
+  <xbrli:context id="C1">
+    <xbrli:entity>
+      <xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier>
+    </xbrli:wrong>
+  </xbrli:context>
+</xbrli:xbrl>`,
+			wantWhat: `parse context "C1"`,
+			wantLine: 5,
+		},
+		{
+			name: "malformed unit",
+			xml: `<xbrli:xbrl xmlns:xbrli="http://www.xbrl.org/2003/instance">
+  <xbrli:unit id="U1">
+    <xbrli:measure>iso4217:JPY</xbrli:other>
+  </xbrli:unit>
+</xbrli:xbrl>`,
+			wantWhat: `parse unit "U1"`,
+			wantLine: 3,
+		},
+		{
+			name: "malformed fact",
+			xml: `<xbrli:xbrl xmlns:xbrli="http://www.xbrl.org/2003/instance" xmlns:ex="http://example.com">
+
+  <ex:Revenue contextRef="C1">1<b></ex:Revenue>
+</xbrli:xbrl>`,
+			wantWhat: "parse fact Revenue",
+			wantLine: 3,
+		},
+	}
+
+	posRe := regexp.MustCompile(`at line (\d+), column \d+ \(offset (\d+)\)`)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := xbrl.Parse(strings.NewReader(tt.xml))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantWhat)
+
+			m := posRe.FindStringSubmatch(err.Error())
+			require.NotNil(t, m, "error should include the position: %v", err)
+			line, _ := strconv.Atoi(m[1])
+			offset, _ := strconv.Atoi(m[2])
+			assert.Equal(t, tt.wantLine, line)
+			assert.Positive(t, offset)
+
+			var syntaxErr *xml.SyntaxError
+			assert.ErrorAs(t, err, &syntaxErr, "underlying decode error is wrapped")
+		})
+	}
+}
+
 func TestParseFile_FileNotFound(t *testing.T) {
 	t.Parallel()
 
@@ -280,3 +349,1073 @@ func TestParseFile_Success(t *testing.T) {
 	assert.Len(t, doc.Units(), 1)
 	assert.Len(t, doc.Facts(), 1)
 }
+
+func TestParse_BOMAndLeadingWhitespace(t *testing.T) {
+	t.Parallel()
+
+	const decl = `<?xml version="1.0" encoding="UTF-8"?>`
+
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{
+			name:  "BOM before root",
+			input: "\ufeff" + minimalInstance,
+		},
+		{
+			name:  "BOM before XML declaration",
+			input: "\ufeff" + decl + minimalInstance,
+		},
+		{
+			name:  "leading whitespace before XML declaration",
+			input: "\n  " + decl + minimalInstance,
+		},
+		{
+			name:  "root with unexpected prefix",
+			input: strings.ReplaceAll(minimalInstance, "xbrli:xbrl", "x:xbrl"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			doc, err := xbrl.Parse(strings.NewReader(tt.input))
+			require.NoError(t, err)
+
+			assert.Len(t, doc.SchemaRefs(), 1)
+			assert.Len(t, doc.Contexts(), 1)
+			assert.Len(t, doc.Units(), 1)
+			assert.Len(t, doc.Facts(), 1)
+		})
+	}
+}
+
+func TestParse_FactDocumentIndex(t *testing.T) {
+	t.Parallel()
+
+	xmlStr := `
+	<xbrli:xbrl
+	    xmlns:xbrli="http://www.xbrl.org/2003/instance"
+	    xmlns:ex="http://example.com/xbrl">
+	  <ex:A contextRef="C1">a</ex:A>
+	  <xbrli:context id="C1">
+	    <xbrli:entity>
+	      <xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier>
+	    </xbrli:entity>
+	    <xbrli:period>
+	      <xbrli:instant>2025-01-01</xbrli:instant>
+	    </xbrli:period>
+	  </xbrli:context>
+	  <ex:B contextRef="C1">b</ex:B>
+	  <ex:Ignored>no contextRef</ex:Ignored>
+	  <ex:A contextRef="C1">c</ex:A>
+	</xbrli:xbrl>
+	`
+
+	doc, err := xbrl.Parse(strings.NewReader(xmlStr))
+	require.NoError(t, err)
+
+	facts := doc.Facts()
+	require.Len(t, facts, 3)
+	for i, f := range facts {
+		assert.Equal(t, i, f.DocumentIndex())
+	}
+
+	// Indices survive filtering.
+	filtered := doc.FilterFacts(xbrl.NewFactFilter().ConceptLocal("A"))
+	require.Len(t, filtered, 2)
+	assert.Equal(t, 0, filtered[0].DocumentIndex())
+	assert.Equal(t, 2, filtered[1].DocumentIndex())
+	assert.Equal(t, "c", filtered[1].Value())
+
+	var nilFact *xbrl.Fact
+	assert.Equal(t, 0, nilFact.DocumentIndex())
+}
+
+func TestParse_ExtendedInstance_DimensionSource(t *testing.T) {
+	t.Parallel()
+
+	doc, err := xbrl.Parse(strings.NewReader(extendedInstance))
+	require.NoError(t, err)
+
+	ctx, ok := doc.ContextByID("C1")
+	require.True(t, ok)
+
+	dims := ctx.Dimensions()
+	require.Len(t, dims, 2)
+
+	tests := []struct {
+		name     string
+		dim      xbrl.Dimension
+		explicit bool
+		want     xbrl.DimensionSource
+	}{
+		{"explicitMember from segment", dims[0], true, xbrl.DimensionSourceSegment},
+		{"typedMember from scenario", dims[1], false, xbrl.DimensionSourceScenario},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.explicit, tt.dim.IsExplicit())
+			assert.Equal(t, tt.want, tt.dim.Source())
+		})
+	}
+}
+
+func TestParse_ExtendedInstance_HasDimension(t *testing.T) {
+	t.Parallel()
+
+	doc, err := xbrl.Parse(strings.NewReader(extendedInstance))
+	require.NoError(t, err)
+
+	c1, ok := doc.ContextByID("C1")
+	require.True(t, ok)
+	c2, ok := doc.ContextByID("C2")
+	require.True(t, ok)
+
+	assert.Equal(t, 2, c1.DimensionCount())
+	assert.Equal(t, 0, c2.DimensionCount())
+
+	tests := []struct {
+		name string
+		ctx  *xbrl.Context
+		dim  xbrl.QName
+		want bool
+	}{
+		{"explicit dimension", c1, xbrl.NewQNameForTest("ex", "Region", "http://example.com/xbrl"), true},
+		{"typed dimension", c1, xbrl.NewQNameForTest("ex", "Scenario", "http://example.com/xbrl"), true},
+		{"prefix is ignored", c1, xbrl.NewQNameForTest("other", "Region", "http://example.com/xbrl"), true},
+		{"different namespace", c1, xbrl.NewQNameForTest("ex", "Region", "http://example.com/other"), false},
+		{"undimensioned context", c2, xbrl.NewQNameForTest("ex", "Region", "http://example.com/xbrl"), false},
+		{"nil context", nil, xbrl.NewQNameForTest("ex", "Region", "http://example.com/xbrl"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.ctx.HasDimension(tt.dim))
+		})
+	}
+
+	var nilCtx *xbrl.Context
+	assert.Equal(t, 0, nilCtx.DimensionCount())
+}
+
+func TestParseWithOptions_LenientDimensions(t *testing.T) {
+	t.Parallel()
+
+	const src = `<xbrli:xbrl xmlns:xbrli="http://www.xbrl.org/2003/instance"
+    xmlns:xbrldi="http://xbrl.org/2006/xbrldi" xmlns:ex="http://example.com/xbrl"
+    xmlns:other="http://example.com/other">
+  <xbrli:context id="C1">
+    <xbrli:entity>
+      <xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier>
+      <xbrli:segment>
+        <xbrldi:explicitMember dimension="ex:Region">ex:Japan</xbrldi:explicitMember>
+        <other:explicitMember dimension="ex:Product">ex:Widgets</other:explicitMember>
+      </xbrli:segment>
+    </xbrli:entity>
+    <xbrli:period><xbrli:instant>2025-03-31</xbrli:instant></xbrli:period>
+    <xbrli:scenario>
+      <other:typedMember dimension="ex:Scenario"><ex:Value>Base</ex:Value></other:typedMember>
+    </xbrli:scenario>
+  </xbrli:context>
+</xbrli:xbrl>`
+
+	tests := []struct {
+		name string
+		opts xbrl.ParseOptions
+		want []string
+	}{
+		{"strict by default", xbrl.ParseOptions{}, []string{"Region"}},
+		{"lenient", xbrl.ParseOptions{LenientDimensions: true}, []string{"Region", "Product", "Scenario"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			doc, err := xbrl.ParseWithOptions(strings.NewReader(src), tt.opts)
+			require.NoError(t, err)
+			ctx, ok := doc.ContextByID("C1")
+			require.True(t, ok)
+			var got []string
+			for _, d := range ctx.Dimensions() {
+				got = append(got, d.Dimension().Local())
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseWithOptions_OnUnknownElement(t *testing.T) {
+	t.Parallel()
+
+	type call struct {
+		local string
+		inner string
+	}
+
+	tests := []struct {
+		name      string
+		input     string
+		wantCalls []call
+		wantFacts int
+	}{
+		{
+			name:  "extended fixture reports OtherElement",
+			input: extendedInstance,
+			wantCalls: []call{
+				{"OtherElement", "no contextRef, should be ignored"},
+			},
+			wantFacts: 2,
+		},
+		{
+			name: "only top-level elements are reported",
+			input: `
+<xbrli:xbrl xmlns:xbrli="http://www.xbrl.org/2003/instance" xmlns:ex="http://example.com/xbrl">
+  <ex:Vendor><ex:Inner>x</ex:Inner></ex:Vendor>
+  <ex:Revenue contextRef="C1">1</ex:Revenue>
+</xbrli:xbrl>`,
+			wantCalls: []call{
+				{"Vendor", "<ex:Inner>x</ex:Inner>"},
+			},
+			wantFacts: 1,
+		},
+		{
+			name:      "minimal fixture has no unknown elements",
+			input:     minimalInstance,
+			wantCalls: nil,
+			wantFacts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls []call
+			opts := xbrl.ParseOptions{
+				OnUnknownElement: func(se xml.StartElement, innerXML string) {
+					calls = append(calls, call{se.Name.Local, strings.TrimSpace(innerXML)})
+				},
+			}
+
+			doc, err := xbrl.ParseWithOptions(strings.NewReader(tt.input), opts)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantCalls, calls)
+			assert.Len(t, doc.Facts(), tt.wantFacts)
+		})
+	}
+}
+
+func TestParseFileWithOptions(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "instance.xbrl")
+	require.NoError(t, os.WriteFile(path, []byte(extendedInstance), 0o644))
+
+	var called int
+	doc, err := xbrl.ParseFileWithOptions(path, xbrl.ParseOptions{
+		OnUnknownElement: func(xml.StartElement, string) { called++ },
+	})
+	require.NoError(t, err)
+	assert.Len(t, doc.Facts(), 2)
+	assert.Equal(t, 1, called)
+
+	_, err = xbrl.ParseFileWithOptions(filepath.Join(dir, "missing.xbrl"), xbrl.ParseOptions{})
+	assert.ErrorContains(t, err, "xbrl: open file")
+}
+
+func TestParseFileWithProgress(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "instance.xbrl")
+	// Pad the instance so that it is read in several chunks.
+	src := strings.Replace(extendedInstance, "<xbrli:context",
+		"<!--"+strings.Repeat("x", 64*1024)+"-->\n<xbrli:context", 1)
+	require.NoError(t, os.WriteFile(path, []byte(src), 0o644))
+	total := int64(len(src))
+
+	var calls []int64
+	doc, err := xbrl.ParseFileWithProgress(path, func(bytesRead, totalBytes int64) {
+		assert.Equal(t, total, totalBytes)
+		calls = append(calls, bytesRead)
+	})
+	require.NoError(t, err)
+	assert.Len(t, doc.Facts(), 2)
+
+	require.Greater(t, len(calls), 1)
+	assert.True(t, slices.IsSorted(calls))
+	assert.Equal(t, total, calls[len(calls)-1])
+
+	doc, err = xbrl.ParseFileWithProgress(path, nil)
+	require.NoError(t, err)
+	assert.Len(t, doc.Facts(), 2)
+
+	_, err = xbrl.ParseFileWithProgress(filepath.Join(dir, "missing.xbrl"), func(int64, int64) {})
+	assert.ErrorContains(t, err, "xbrl: open file")
+}
+
+func TestParse_XMLLangInheritance(t *testing.T) {
+	t.Parallel()
+
+	xmlStr := `
+	<xbrli:xbrl
+	    xmlns:xbrli="http://www.xbrl.org/2003/instance"
+	    xmlns:ex="http://example.com/xbrl"
+	    xml:lang="ja">
+	  <ex:Inherited contextRef="C1">a</ex:Inherited>
+	  <ex:Own contextRef="C1" xml:lang="en">b</ex:Own>
+	  <ex:AfterOwn contextRef="C1">c</ex:AfterOwn>
+	  <ex:Tuple xml:lang="fr">
+	    <ex:Nested contextRef="C1">d</ex:Nested>
+	  </ex:Tuple>
+	  <ex:AfterTuple contextRef="C1">e</ex:AfterTuple>
+	</xbrli:xbrl>
+	`
+
+	doc, err := xbrl.Parse(strings.NewReader(xmlStr))
+	require.NoError(t, err)
+
+	want := map[string]string{
+		"Inherited":  "ja",
+		"Own":        "en",
+		"AfterOwn":   "ja",
+		"Nested":     "fr",
+		"AfterTuple": "ja",
+	}
+
+	facts := doc.Facts()
+	require.Len(t, facts, len(want))
+	for _, f := range facts {
+		assert.Equal(t, want[f.Name().Local()], f.Lang(), "lang of %s", f.Name().Local())
+	}
+}
+
+func TestParse_RootAttributes(t *testing.T) {
+	t.Parallel()
+
+	xmlStr := `
+	<xbrli:xbrl
+	    xmlns:xbrli="http://www.xbrl.org/2003/instance"
+	    xmlns:ex="http://example.com/xbrl"
+	    ex:framework="edinet"
+	    version="2.1">
+	  <ex:Revenue contextRef="C1">1</ex:Revenue>
+	</xbrli:xbrl>
+	`
+
+	doc, err := xbrl.Parse(strings.NewReader(xmlStr))
+	require.NoError(t, err)
+
+	attrs := doc.RootAttributes()
+	assert.Len(t, attrs, 4)
+
+	// Returned slice is a copy.
+	attrs[0].Value = "changed"
+	assert.NotEqual(t, "changed", doc.RootAttributes()[0].Value)
+
+	tests := []struct {
+		name   string
+		local  string
+		want   string
+		wantOK bool
+	}{
+		{"prefixed attribute", "framework", "edinet", true},
+		{"unprefixed attribute", "version", "2.1", true},
+		{"namespace declarations are skipped", "ex", "", false},
+		{"missing attribute", "missing", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, ok := doc.RootAttr(tt.local)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantOK, ok)
+		})
+	}
+
+	var nilDoc *xbrl.Document
+	assert.Nil(t, nilDoc.RootAttributes())
+	_, ok := nilDoc.RootAttr("version")
+	assert.False(t, ok)
+}
+
+func TestParseWithOptions_StrictDates(t *testing.T) {
+	t.Parallel()
+
+	withPeriod := func(period string) string {
+		return `
+	<xbrli:xbrl xmlns:xbrli="http://www.xbrl.org/2003/instance">
+	  <xbrli:context id="C1">
+	    <xbrli:entity>
+	      <xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier>
+	    </xbrli:entity>
+	    <xbrli:period>` + period + `</xbrli:period>
+	  </xbrli:context>
+	</xbrli:xbrl>`
+	}
+
+	tests := []struct {
+		name    string
+		period  string
+		strict  bool
+		wantErr string
+	}{
+		{
+			name:   "valid instant",
+			period: "<xbrli:instant>2025-03-31</xbrli:instant>",
+			strict: true,
+		},
+		{
+			name:   "valid dateTime with offset",
+			period: "<xbrli:startDate>2025-01-01T00:00:00+09:00</xbrli:startDate><xbrli:endDate>2025-12-31T23:59:59.5Z</xbrli:endDate>",
+			strict: true,
+		},
+		{
+			name:   "malformed instant is accepted by default",
+			period: "<xbrli:instant>2025/03/31</xbrli:instant>",
+			strict: false,
+		},
+		{
+			name:    "malformed instant in strict mode",
+			period:  "<xbrli:instant>2025/03/31</xbrli:instant>",
+			strict:  true,
+			wantErr: `xbrl: invalid period date: context "C1": instant "2025/03/31"`,
+		},
+		{
+			name:    "malformed endDate in strict mode",
+			period:  "<xbrli:startDate>2025-01-01</xbrli:startDate><xbrli:endDate>2025-13-01</xbrli:endDate>",
+			strict:  true,
+			wantErr: `xbrl: invalid period date: context "C1": endDate "2025-13-01"`,
+		},
+		{
+			name:    "empty date in strict mode",
+			period:  "<xbrli:instant></xbrli:instant>",
+			strict:  true,
+			wantErr: `xbrl: invalid period date: context "C1": instant ""`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			doc, err := xbrl.ParseWithOptions(strings.NewReader(withPeriod(tt.period)), xbrl.ParseOptions{StrictDates: tt.strict})
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.ErrorIs(t, err, xbrl.ErrInvalidPeriodDate)
+				assert.Nil(t, doc)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, doc.Contexts(), 1)
+		})
+	}
+}
+
+func TestParseWithOptions_RawIdentifiers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		raw        bool
+		wantID     string
+		wantMember string
+	}{
+		{
+			name:       "default trims",
+			raw:        false,
+			wantID:     "ABC",
+			wantMember: "ex:Japan",
+		},
+		{
+			name:       "raw keeps surrounding whitespace",
+			raw:        true,
+			wantID:     "\n        ABC\n      ",
+			wantMember: "\n          ex:Japan\n        ",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			doc, err := xbrl.ParseWithOptions(strings.NewReader(extendedInstance), xbrl.ParseOptions{RawIdentifiers: tt.raw})
+			require.NoError(t, err)
+
+			ctx, ok := doc.ContextByID("C1")
+			require.True(t, ok)
+			assert.Equal(t, tt.wantID, ctx.Entity().Identifier().Value())
+
+			dims := ctx.Dimensions()
+			require.Len(t, dims, 2)
+			assert.Equal(t, tt.wantMember, dims[0].MemberText())
+			// The member QName is resolved from the trimmed text either way.
+			assert.Equal(t, "ex", dims[0].Member().Prefix())
+			assert.Equal(t, "Japan", dims[0].Member().Local())
+			assert.Equal(t, "", dims[1].MemberText(), "typed member has no member text")
+		})
+	}
+}
+
+func TestParse_FactMixedContent(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "CDATA with special characters",
+			body: `<![CDATA[<p>R&D "costs" < 5%</p>]]>`,
+			want: `<p>R&D "costs" < 5%</p>`,
+		},
+		{
+			name: "text split by comments",
+			body: `12<!-- thousands -->345`,
+			want: "12345",
+		},
+		{
+			name: "text mixed with CDATA and comments",
+			body: ` A <!-- c1 --><![CDATA[& B]]><?pi ignored?> &amp; C `,
+			want: "A & B & C",
+		},
+		{
+			name: "child element content is skipped",
+			body: `before<ex:Child>inner</ex:Child>after`,
+			want: "beforeafter",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			xmlStr := `<xbrli:xbrl xmlns:xbrli="http://www.xbrl.org/2003/instance" xmlns:ex="http://example.com/xbrl">` +
+				`<ex:Text contextRef="C1">` + tt.body + `</ex:Text>` +
+				`<ex:Next contextRef="C1">next</ex:Next>` +
+				`</xbrli:xbrl>`
+
+			doc, err := xbrl.Parse(strings.NewReader(xmlStr))
+			require.NoError(t, err)
+
+			facts := doc.Facts()
+			require.Len(t, facts, 2)
+			assert.Equal(t, tt.want, facts[0].Value())
+			assert.Equal(t, "next", facts[1].Value())
+		})
+	}
+}
+
+func TestParse_MultiMeasureUnits(t *testing.T) {
+	t.Parallel()
+
+	const src = `<xbrli:xbrl xmlns:xbrli="http://www.xbrl.org/2003/instance"
+    xmlns:iso4217="http://www.xbrl.org/2003/iso4217"
+    xmlns:utr="http://www.xbrl.org/2009/utr">
+  <xbrli:unit id="Area">
+    <xbrli:measure>utr:m</xbrli:measure>
+    <xbrli:measure>utr:m</xbrli:measure>
+  </xbrli:unit>
+  <xbrli:unit id="PerSqmPerSec">
+    <xbrli:divide>
+      <xbrli:unitNumerator>
+        <xbrli:measure>iso4217:JPY</xbrli:measure>
+      </xbrli:unitNumerator>
+      <xbrli:unitDenominator>
+        <xbrli:measure>utr:m</xbrli:measure>
+        <xbrli:measure>utr:m</xbrli:measure>
+        <xbrli:measure>utr:s</xbrli:measure>
+      </xbrli:unitDenominator>
+    </xbrli:divide>
+  </xbrli:unit>
+</xbrli:xbrl>`
+
+	doc, err := xbrl.Parse(strings.NewReader(src))
+	require.NoError(t, err)
+
+	area, ok := doc.UnitByID("Area")
+	require.True(t, ok)
+	assert.Len(t, area.Measures(), 2)
+	assert.Equal(t, "m·m", area.String())
+
+	rate, ok := doc.UnitByID("PerSqmPerSec")
+	require.True(t, ok)
+	assert.Len(t, rate.NumeratorMeasures(), 1)
+	den := rate.DenominatorMeasures()
+	require.Len(t, den, 3)
+	assert.Equal(t, "http://www.xbrl.org/2009/utr", den[2].URI())
+	assert.Equal(t, "JPY/(m·m·s)", rate.String())
+}
+
+func TestParseWithOptions_CaptureSource(t *testing.T) {
+	t.Parallel()
+
+	t.Run("minimal fixture", func(t *testing.T) {
+		t.Parallel()
+
+		doc, err := xbrl.ParseWithOptions(strings.NewReader("\ufeff"+minimalInstance), xbrl.ParseOptions{CaptureSource: true})
+		require.NoError(t, err)
+		require.Len(t, doc.Facts(), 1)
+
+		const want = `<ex:Revenue contextRef="C1" unitRef="U1" decimals="0">12345</ex:Revenue>`
+		assert.Equal(t, want, doc.Facts()[0].SourceXML())
+		assert.Contains(t, minimalInstance, want)
+	})
+
+	t.Run("self-closing and nested content", func(t *testing.T) {
+		t.Parallel()
+
+		const nilFact = `<ex:Note contextRef="C1" xsi:nil="true"/>`
+		const mixed = `<ex:Text contextRef="C1" xml:lang="en">a<!-- c --><![CDATA[<b>]]></ex:Text>`
+		input := `<xbrli:xbrl xmlns:xbrli="http://www.xbrl.org/2003/instance"
+    xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"
+    xmlns:ex="http://example.com/xbrl">
+  ` + nilFact + `
+  ` + mixed + `
+</xbrli:xbrl>`
+
+		doc, err := xbrl.ParseWithOptions(strings.NewReader(input), xbrl.ParseOptions{CaptureSource: true})
+		require.NoError(t, err)
+		require.Len(t, doc.Facts(), 2)
+		assert.Equal(t, nilFact, doc.Facts()[0].SourceXML())
+		assert.Equal(t, mixed, doc.Facts()[1].SourceXML())
+	})
+
+	t.Run("off by default", func(t *testing.T) {
+		t.Parallel()
+
+		doc, err := xbrl.Parse(strings.NewReader(minimalInstance))
+		require.NoError(t, err)
+		require.Len(t, doc.Facts(), 1)
+		assert.Empty(t, doc.Facts()[0].SourceXML())
+
+		var nilFact *xbrl.Fact
+		assert.Empty(t, nilFact.SourceXML())
+	})
+}
+
+func TestParseWithOptions_StrictPeriod(t *testing.T) {
+	t.Parallel()
+
+	withPeriod := func(period string) string {
+		return `
+	<xbrli:xbrl xmlns:xbrli="http://www.xbrl.org/2003/instance">
+	  <xbrli:context id="C1">
+	    <xbrli:entity>
+	      <xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier>
+	    </xbrli:entity>
+	    <xbrli:period>` + period + `</xbrli:period>
+	  </xbrli:context>
+	</xbrli:xbrl>`
+	}
+
+	tests := []struct {
+		name        string
+		period      string
+		strict      bool
+		wantErr     string
+		wantInstant string
+	}{
+		{
+			name:        "repeated instant keeps the last by default",
+			period:      "<xbrli:instant>2025-03-31</xbrli:instant><xbrli:instant>2025-06-30</xbrli:instant>",
+			strict:      false,
+			wantInstant: "2025-06-30",
+		},
+		{
+			name:    "repeated instant in strict mode",
+			period:  "<xbrli:instant>2025-03-31</xbrli:instant><xbrli:instant>2025-06-30</xbrli:instant>",
+			strict:  true,
+			wantErr: "period has multiple instant elements",
+		},
+		{
+			name:    "repeated endDate in strict mode",
+			period:  "<xbrli:startDate>2025-01-01</xbrli:startDate><xbrli:endDate>2025-06-30</xbrli:endDate><xbrli:endDate>2025-12-31</xbrli:endDate>",
+			strict:  true,
+			wantErr: "period has multiple endDate elements",
+		},
+		{
+			name:    "repeated forever in strict mode",
+			period:  "<xbrli:forever/><xbrli:forever/>",
+			strict:  true,
+			wantErr: "period has multiple forever elements",
+		},
+		{
+			name:        "single instant in strict mode",
+			period:      "<xbrli:instant>2025-03-31</xbrli:instant>",
+			strict:      true,
+			wantInstant: "2025-03-31",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			doc, err := xbrl.ParseWithOptions(strings.NewReader(withPeriod(tt.period)), xbrl.ParseOptions{StrictPeriod: tt.strict})
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, xbrl.ErrInvalidContext)
+				assert.Contains(t, err.Error(), `parse context "C1"`)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				assert.Equal(t, 1, strings.Count(err.Error(), "xbrl: "), "prefixed once: %v", err)
+				assert.Nil(t, doc)
+				return
+			}
+			require.NoError(t, err)
+			ctx, ok := doc.ContextByID("C1")
+			require.True(t, ok)
+			if tt.wantInstant != "" {
+				got, ok := ctx.Period().Instant()
+				require.True(t, ok)
+				assert.Equal(t, tt.wantInstant, got)
+			}
+		})
+	}
+}
+
+func TestParseWithOptions_ResolveReferences(t *testing.T) {
+	t.Parallel()
+
+	// Facts appear before the contexts and units they refer to.
+	const src = `<xbrli:xbrl xmlns:xbrli="http://www.xbrl.org/2003/instance"
+    xmlns:iso4217="http://www.xbrl.org/2003/iso4217" xmlns:ex="http://example.com/xbrl">
+  <ex:Revenue contextRef="C1" unitRef="JPY" decimals="0">100</ex:Revenue>
+  <ex:Note contextRef="C2">text</ex:Note>
+  <ex:Orphan contextRef="Missing" unitRef="Missing">1</ex:Orphan>
+  <xbrli:context id="C1">
+    <xbrli:entity><xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:instant>2025-03-31</xbrli:instant></xbrli:period>
+  </xbrli:context>
+  <xbrli:context id="C2">
+    <xbrli:entity><xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:forever/></xbrli:period>
+  </xbrli:context>
+  <xbrli:unit id="JPY"><xbrli:measure>iso4217:JPY</xbrli:measure></xbrli:unit>
+</xbrli:xbrl>`
+
+	doc, err := xbrl.ParseWithOptions(strings.NewReader(src), xbrl.ParseOptions{ResolveReferences: true})
+	require.NoError(t, err)
+	facts := doc.Facts()
+	require.Len(t, facts, 3)
+
+	c1, _ := doc.ContextByID("C1")
+	c2, _ := doc.ContextByID("C2")
+	jpy, _ := doc.UnitByID("JPY")
+
+	assert.Same(t, c1, facts[0].Context())
+	assert.Same(t, jpy, facts[0].Unit())
+	assert.Same(t, c2, facts[1].Context())
+	assert.Nil(t, facts[1].Unit(), "no unitRef")
+	assert.Nil(t, facts[2].Context(), "unresolvable contextRef")
+	assert.Nil(t, facts[2].Unit(), "unresolvable unitRef")
+
+	t.Run("clone points into the copy", func(t *testing.T) {
+		t.Parallel()
+
+		clone := doc.Clone()
+		cf := clone.Facts()[0]
+		cc, _ := clone.ContextByID("C1")
+		cu, _ := clone.UnitByID("JPY")
+		assert.Same(t, cc, cf.Context())
+		assert.Same(t, cu, cf.Unit())
+		assert.NotSame(t, c1, cf.Context())
+	})
+
+	t.Run("off by default", func(t *testing.T) {
+		t.Parallel()
+
+		plain, err := xbrl.Parse(strings.NewReader(src))
+		require.NoError(t, err)
+		f := plain.Facts()[0]
+		assert.Nil(t, f.Context())
+		assert.Nil(t, f.Unit())
+		ctx, ok := plain.ContextOf(f)
+		require.True(t, ok, "the document still resolves it")
+		assert.Equal(t, "C1", ctx.ID())
+
+		var nilFact *xbrl.Fact
+		assert.Nil(t, nilFact.Context())
+		assert.Nil(t, nilFact.Unit())
+	})
+}
+
+func TestParseWithOptions_FactDetector(t *testing.T) {
+	t.Parallel()
+
+	const src = `<xbrli:xbrl xmlns:xbrli="http://www.xbrl.org/2003/instance" xmlns:ex="http://example.com/xbrl">
+  <xbrli:context id="C1">
+    <xbrli:entity><xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:instant>2025-03-31</xbrli:instant></xbrli:period>
+  </xbrli:context>
+  <ex:Revenue contextRef="C1">100</ex:Revenue>
+  <ex:Memo>free text</ex:Memo>
+  <ex:Wrapper><ex:Cost contextRef="C1">40</ex:Cost></ex:Wrapper>
+</xbrli:xbrl>`
+
+	tests := []struct {
+		name     string
+		detector func(se xml.StartElement) bool
+		want     []string
+	}{
+		{
+			name:     "default detection",
+			detector: nil,
+			want:     []string{"Revenue", "Cost"},
+		},
+		{
+			name: "element without contextRef",
+			detector: func(se xml.StartElement) bool {
+				return se.Name.Local == "Memo" || hasContextRef(se)
+			},
+			want: []string{"Revenue", "Memo", "Cost"},
+		},
+		{
+			name: "rejected elements are descended into",
+			detector: func(se xml.StartElement) bool {
+				return se.Name.Local == "Cost"
+			},
+			want: []string{"Cost"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			doc, err := xbrl.ParseWithOptions(strings.NewReader(src), xbrl.ParseOptions{FactDetector: tt.detector})
+			require.NoError(t, err)
+			var got []string
+			for _, f := range doc.Facts() {
+				got = append(got, f.Name().Local())
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	doc, err := xbrl.ParseWithOptions(strings.NewReader(src), xbrl.ParseOptions{
+		FactDetector: func(se xml.StartElement) bool { return se.Name.Local == "Memo" },
+	})
+	require.NoError(t, err)
+	require.Len(t, doc.Facts(), 1)
+	memo := doc.Facts()[0]
+	assert.Equal(t, "free text", memo.Value())
+	assert.Empty(t, memo.ContextRef())
+	assert.Equal(t, "http://example.com/xbrl", memo.Name().URI())
+}
+
+func TestParseWithOptions_RootPrefixes(t *testing.T) {
+	t.Parallel()
+
+	// Revenue rebinds ex, so its namespace is only reachable as alt in its
+	// own scope. Cost's namespace is not declared on the root at all.
+	const src = `<xbrli:xbrl xmlns:xbrli="http://www.xbrl.org/2003/instance" xmlns:ex="http://example.com/xbrl">
+  <alt:Revenue xmlns:alt="http://example.com/xbrl" xmlns:ex="urn:unrelated" contextRef="C1">100</alt:Revenue>
+  <ex:Profit contextRef="C1">10</ex:Profit>
+  <other:Cost xmlns:other="http://example.com/other" contextRef="C1">40</other:Cost>
+</xbrli:xbrl>`
+
+	tests := []struct {
+		name string
+		opts xbrl.ParseOptions
+		want []string
+	}{
+		{"scope prefixes", xbrl.ParseOptions{}, []string{"alt:Revenue", "ex:Profit", "other:Cost"}},
+		{"root prefixes", xbrl.ParseOptions{RootPrefixes: true}, []string{"ex:Revenue", "ex:Profit", "other:Cost"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			doc, err := xbrl.ParseWithOptions(strings.NewReader(src), tt.opts)
+			require.NoError(t, err)
+			var got []string
+			for _, f := range doc.Facts() {
+				got = append(got, f.Name().Prefix()+":"+f.Name().Local())
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParse_WrappedInstance(t *testing.T) {
+	t.Parallel()
+
+	const src = `<?xml version="1.0" encoding="UTF-8"?>
+<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">
+  <soap:Header>
+    <ex:Tracking xmlns:ex="http://example.com/xbrl" contextRef="C1">envelope data</ex:Tracking>
+    <context id="Envelope"/>
+  </soap:Header>
+  <soap:Body>
+    <wrapper xmlns:ex="http://example.com/xbrl">
+      <xbrli:xbrl xmlns:xbrli="http://www.xbrl.org/2003/instance" xmlns:iso4217="http://www.xbrl.org/2003/iso4217">
+        <xbrli:context id="C1">
+          <xbrli:entity><xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier></xbrli:entity>
+          <xbrli:period><xbrli:instant>2025-03-31</xbrli:instant></xbrli:period>
+        </xbrli:context>
+        <xbrli:unit id="JPY"><xbrli:measure>iso4217:JPY</xbrli:measure></xbrli:unit>
+        <alt:Revenue xmlns:alt="http://example.com/xbrl" contextRef="C1" unitRef="JPY" decimals="0">100</alt:Revenue>
+        <ex:Note>not a fact</ex:Note>
+      </xbrli:xbrl>
+      <ex:Trailer contextRef="C1" unitRef="JPY">1</ex:Trailer>
+    </wrapper>
+  </soap:Body>
+</soap:Envelope>`
+
+	var unknown []string
+	doc, err := xbrl.ParseWithOptions(strings.NewReader(src), xbrl.ParseOptions{
+		OnUnknownElement: func(se xml.StartElement, _ string) { unknown = append(unknown, se.Name.Local) },
+		RootPrefixes:     true,
+	})
+	require.NoError(t, err)
+
+	assert.Len(t, doc.Contexts(), 1, "contexts outside the xbrl element are ignored")
+	assert.Len(t, doc.Units(), 1)
+	require.Len(t, doc.Facts(), 1, "facts outside the xbrl element are ignored")
+	f := doc.Facts()[0]
+	assert.Equal(t, "ex", f.Name().Prefix(), "prefix in scope on the xbrl element")
+	assert.Equal(t, "100", f.Value())
+	assert.Equal(t, []string{"Note"}, unknown)
+	assert.Equal(t, "http://www.xbrl.org/2003/instance", doc.Namespaces()["xbrli"])
+	assert.NotContains(t, doc.Namespaces(), "soap")
+}
+
+func TestParseWithOptions_NonFactNamespaces(t *testing.T) {
+	t.Parallel()
+
+	const src = `<xbrli:xbrl xmlns:xbrli="http://www.xbrl.org/2003/instance"
+    xmlns:link="http://www.xbrl.org/2003/linkbase" xmlns:ex="http://example.com/xbrl">
+  <xbrli:context id="C1">
+    <xbrli:entity><xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:instant>2025-03-31</xbrli:instant></xbrli:period>
+  </xbrli:context>
+  <link:roleRef contextRef="C1" roleURI="http://example.com/role"/>
+  <xbrli:stray contextRef="C1">1</xbrli:stray>
+  <ex:Revenue contextRef="C1">100</ex:Revenue>
+</xbrli:xbrl>`
+
+	tests := []struct {
+		name string
+		opts xbrl.ParseOptions
+		want []string
+	}{
+		{
+			name: "spec namespaces skipped by default",
+			want: []string{"Revenue"},
+		},
+		{
+			name: "detector cannot override the skip list",
+			opts: xbrl.ParseOptions{FactDetector: func(xml.StartElement) bool { return true }},
+			want: []string{"Revenue"},
+		},
+		{
+			name: "custom list",
+			opts: xbrl.ParseOptions{NonFactNamespaces: []string{"http://example.com/xbrl"}},
+			want: []string{"roleRef", "stray"},
+		},
+		{
+			name: "empty list",
+			opts: xbrl.ParseOptions{NonFactNamespaces: []string{}},
+			want: []string{"roleRef", "stray", "Revenue"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			doc, err := xbrl.ParseWithOptions(strings.NewReader(src), tt.opts)
+			require.NoError(t, err)
+			var got []string
+			for _, f := range doc.Facts() {
+				got = append(got, f.Name().Local())
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseFiltered(t *testing.T) {
+	t.Parallel()
+
+	var seen []string
+	doc, err := xbrl.ParseFiltered(strings.NewReader(extendedInstance), func(se xml.StartElement) bool {
+		seen = append(seen, se.Name.Local)
+		return se.Name.Local == "Revenue"
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"Revenue", "NilFact"}, seen)
+	require.Len(t, doc.Facts(), 1)
+	assert.Equal(t, "Revenue", doc.Facts()[0].Name().Local())
+	assert.Equal(t, "12345", doc.Facts()[0].Value())
+	assert.Len(t, doc.Contexts(), 2)
+	assert.Len(t, doc.Units(), 3)
+
+	all, err := xbrl.ParseFiltered(strings.NewReader(extendedInstance), nil)
+	require.NoError(t, err)
+	assert.Len(t, all.Facts(), 2)
+}
+
+func hasContextRef(se xml.StartElement) bool {
+	for _, a := range se.Attr {
+		if a.Name.Local == "contextRef" {
+			return true
+		}
+	}
+	return false
+}
+
+func TestParseWithTaxonomy(t *testing.T) {
+	t.Parallel()
+
+	const ns = "http://example.com/xbrl"
+	item := xbrl.NewQNameForTest("xbrli", "item", "http://www.xbrl.org/2003/instance")
+	tuple := xbrl.NewQNameForTest("xbrli", "tuple", "http://www.xbrl.org/2003/instance")
+	concept := func(local string, subst xbrl.QName) (xbrl.QName, *xbrl.Concept) {
+		q := xbrl.NewQNameForTest("ex", local, ns)
+		return q, xbrl.NewConceptForTest(q, "ex_"+local, subst, xbrl.QName{}, false, true, "instant", "")
+	}
+	revenue, revenueC := concept("Revenue", item)
+	note, noteC := concept("Note", item)
+	address, addressC := concept("Address", tuple)
+	street, streetC := concept("Street", item)
+	tax := xbrl.NewTaxonomyForTest(map[xbrl.QName]*xbrl.Concept{
+		revenue: revenueC, note: noteC, address: addressC, street: streetC,
+	})
+
+	const src = `<xbrli:xbrl xmlns:xbrli="http://www.xbrl.org/2003/instance"
+    xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xmlns:ex="http://example.com/xbrl">
+  <xbrli:context id="C1">
+    <xbrli:entity><xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:instant>2025-03-31</xbrli:instant></xbrli:period>
+  </xbrli:context>
+  <ex:Revenue contextRef="C1">100</ex:Revenue>
+  <ex:Note xsi:nil="true"/>
+  <ex:Address><ex:Street contextRef="C1">Main St</ex:Street></ex:Address>
+  <ex:Unknown contextRef="C1">ignored</ex:Unknown>
+</xbrli:xbrl>`
+
+	doc, err := xbrl.ParseWithTaxonomy(strings.NewReader(src), tax)
+	require.NoError(t, err)
+	assert.Same(t, tax, doc.Taxonomy())
+
+	var got []string
+	for _, f := range doc.Facts() {
+		got = append(got, f.Name().Local())
+	}
+	assert.Equal(t, []string{"Revenue", "Note", "Street"}, got,
+		"items without contextRef are detected, tuples are descended into and unknown elements skipped")
+	assert.True(t, doc.Facts()[1].IsNil())
+
+	_, err = xbrl.ParseWithTaxonomy(strings.NewReader(src), nil)
+	assert.ErrorIs(t, err, xbrl.ErrNoTaxonomy)
+}
@@ -0,0 +1,50 @@
+package xbrl
+
+import (
+	"maps"
+	"slices"
+)
+
+// PlacementIssue describes a context that declares dimensions in both its
+// segment and its scenario.
+type PlacementIssue struct {
+	ContextID string
+
+	// Segment and Scenario hold the dimensions of the context by where
+	// they are declared, in document order.
+	Segment  []Dimension
+	Scenario []Dimension
+}
+
+// DimensionPlacementReport returns the contexts that place dimensions in
+// both the segment and the scenario, ordered by context ID. Taxonomies
+// usually expect all dimensions in one of the two containers, as the
+// hypercube's xbrldt:contextElement allows only one of them.
+//
+// It returns nil if no context mixes placements.
+func (d *Document) DimensionPlacementReport() []PlacementIssue {
+	if d == nil || !d.hasDimensions {
+		return nil
+	}
+
+	var out []PlacementIssue
+	for _, id := range slices.Sorted(maps.Keys(d.contexts)) {
+		c := d.contexts[id]
+		if c == nil {
+			continue
+		}
+		issue := PlacementIssue{ContextID: id}
+		for _, dim := range c.dimensions {
+			switch dim.source {
+			case DimensionSourceSegment:
+				issue.Segment = append(issue.Segment, dim)
+			case DimensionSourceScenario:
+				issue.Scenario = append(issue.Scenario, dim)
+			}
+		}
+		if len(issue.Segment) > 0 && len(issue.Scenario) > 0 {
+			out = append(out, issue)
+		}
+	}
+	return out
+}
@@ -0,0 +1,61 @@
+package xbrl_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aethiopicuschan/xbrl-go/pkg/xbrl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocument_DimensionPlacementReport(t *testing.T) {
+	t.Parallel()
+
+	const src = `<xbrli:xbrl xmlns:xbrli="http://www.xbrl.org/2003/instance"
+    xmlns:xbrldi="http://xbrl.org/2006/xbrldi" xmlns:ex="http://example.com/xbrl">
+  <xbrli:context id="Mixed">
+    <xbrli:entity>
+      <xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier>
+      <xbrli:segment><xbrldi:explicitMember dimension="ex:RegionAxis">ex:JapanMember</xbrldi:explicitMember></xbrli:segment>
+    </xbrli:entity>
+    <xbrli:period><xbrli:instant>2025-03-31</xbrli:instant></xbrli:period>
+    <xbrli:scenario><xbrldi:explicitMember dimension="ex:ScenarioAxis">ex:ActualMember</xbrldi:explicitMember></xbrli:scenario>
+  </xbrli:context>
+  <xbrli:context id="SegmentOnly">
+    <xbrli:entity>
+      <xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier>
+      <xbrli:segment><xbrldi:explicitMember dimension="ex:RegionAxis">ex:USMember</xbrldi:explicitMember></xbrli:segment>
+    </xbrli:entity>
+    <xbrli:period><xbrli:instant>2025-03-31</xbrli:instant></xbrli:period>
+  </xbrli:context>
+  <xbrli:context id="ScenarioOnly">
+    <xbrli:entity><xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:instant>2025-03-31</xbrli:instant></xbrli:period>
+    <xbrli:scenario><xbrldi:explicitMember dimension="ex:ScenarioAxis">ex:BudgetMember</xbrldi:explicitMember></xbrli:scenario>
+  </xbrli:context>
+</xbrli:xbrl>`
+
+	doc, err := xbrl.Parse(strings.NewReader(src))
+	require.NoError(t, err)
+
+	report := doc.DimensionPlacementReport()
+	require.Len(t, report, 1)
+	issue := report[0]
+	assert.Equal(t, "Mixed", issue.ContextID)
+	require.Len(t, issue.Segment, 1)
+	require.Len(t, issue.Scenario, 1)
+	assert.Equal(t, "RegionAxis", issue.Segment[0].Dimension().Local())
+	assert.Equal(t, "ScenarioAxis", issue.Scenario[0].Dimension().Local())
+
+	t.Run("no issues", func(t *testing.T) {
+		t.Parallel()
+
+		clean, err := xbrl.Parse(strings.NewReader(regionInstance))
+		require.NoError(t, err)
+		assert.Nil(t, clean.DimensionPlacementReport())
+
+		var nilDoc *xbrl.Document
+		assert.Nil(t, nilDoc.DimensionPlacementReport())
+	})
+}
@@ -0,0 +1,132 @@
+package xbrl
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Errors returned by FactQuery when a single fact is expected.
+var (
+	ErrNoMatch        = errors.New("xbrl: no fact matches the query")
+	ErrAmbiguousMatch = errors.New("xbrl: multiple facts match the query")
+)
+
+// FactQuery is a fluent query over the facts of a document that returns
+// values directly, e.g.
+//
+//	v, err := doc.Query().Concept("Revenue").Context("C1").Float64()
+//
+// The criteria are those of FactFilter. A FactQuery is not safe for
+// concurrent use while it is being built.
+type FactQuery struct {
+	doc    *Document
+	filter *FactFilter
+}
+
+// Query starts a query over the facts of the document.
+func (d *Document) Query() *FactQuery {
+	return &FactQuery{doc: d, filter: NewFactFilter()}
+}
+
+// Concept restricts the query to facts whose concept has the given local
+// name.
+func (q *FactQuery) Concept(local string) *FactQuery {
+	if q == nil {
+		return nil
+	}
+	q.filter.ConceptLocal(local)
+	return q
+}
+
+// ConceptURI restricts the query to facts whose concept is in the given
+// namespace.
+func (q *FactQuery) ConceptURI(uri string) *FactQuery {
+	if q == nil {
+		return nil
+	}
+	q.filter.ConceptURI(uri)
+	return q
+}
+
+// Context restricts the query to facts with the given context ID.
+func (q *FactQuery) Context(id string) *FactQuery {
+	if q == nil {
+		return nil
+	}
+	q.filter.ContextID(id)
+	return q
+}
+
+// Unit restricts the query to facts with the given unit ID.
+func (q *FactQuery) Unit(id string) *FactQuery {
+	if q == nil {
+		return nil
+	}
+	q.filter.UnitID(id)
+	return q
+}
+
+// Dimension restricts the query to facts whose context has the explicit
+// dimension member (see FactFilter.Dimension).
+func (q *FactQuery) Dimension(dim, member QName) *FactQuery {
+	if q == nil {
+		return nil
+	}
+	q.filter.Dimension(dim, member)
+	return q
+}
+
+// Facts returns the matching facts in document order.
+func (q *FactQuery) Facts() []*Fact {
+	if q == nil {
+		return nil
+	}
+	return q.doc.FilterFacts(q.filter)
+}
+
+// One returns the single matching fact. It returns an error wrapping
+// ErrNoMatch if no fact matches and ErrAmbiguousMatch if several do.
+func (q *FactQuery) One() (*Fact, error) {
+	facts := q.Facts()
+	switch len(facts) {
+	case 0:
+		return nil, ErrNoMatch
+	case 1:
+		return facts[0], nil
+	default:
+		return nil, fmt.Errorf("%w: %d facts", ErrAmbiguousMatch, len(facts))
+	}
+}
+
+// Float64 returns the value of the single matching fact as a float64.
+//
+// If a taxonomy is attached, the value is converted with
+// Document.AsFloat64, so the concept must be numeric; otherwise the raw
+// value is parsed as a decimal number. Errors are those of One, ErrNilFact
+// for xsi:nil facts, and ErrInvalidValue for malformed values.
+func (q *FactQuery) Float64() (float64, error) {
+	f, err := q.One()
+	if err != nil {
+		return 0, err
+	}
+	if f.IsNil() {
+		return 0, ErrNilFact
+	}
+	if q.doc.Taxonomy() != nil {
+		return q.doc.AsFloat64(f)
+	}
+	return parseDecimalFloat(f.Value())
+}
+
+// Strings returns the raw values of all matching facts in document order.
+func (q *FactQuery) Strings() []string {
+	facts := q.Facts()
+	if len(facts) == 0 {
+		return nil
+	}
+	out := make([]string, len(facts))
+	for i, f := range facts {
+		out[i] = f.Value()
+	}
+	return out
+}
@@ -0,0 +1,160 @@
+package xbrl
+
+import "math/bits"
+
+// QueryIndex is a precomputed index over the facts of a Document for
+// evaluating many FactFilters efficiently.
+//
+// It holds one bitset per concept local name, concept URI, context ID and
+// unit ID, plus a bitset of nil facts. Apply intersects the bitsets for
+// the criteria set on a filter and only evaluates the remaining criteria
+// (regexp, value predicates, dimensions) on the candidates.
+//
+// The index is a snapshot: facts added to the Document afterwards are not
+// visible through it.
+type QueryIndex struct {
+	doc   *Document
+	facts []*Fact
+
+	all     bitset
+	byLocal map[string]bitset
+	byURI   map[string]bitset
+	byCtx   map[string]bitset
+	byUnit  map[string]bitset
+	nils    bitset
+}
+
+// NewQueryIndex builds a QueryIndex for the document's current facts.
+func (d *Document) NewQueryIndex() *QueryIndex {
+	if d == nil {
+		return nil
+	}
+
+	n := len(d.facts)
+	idx := &QueryIndex{
+		doc:     d,
+		facts:   d.Facts(),
+		all:     newBitset(n),
+		byLocal: make(map[string]bitset),
+		byURI:   make(map[string]bitset),
+		byCtx:   make(map[string]bitset),
+		byUnit:  make(map[string]bitset),
+		nils:    newBitset(n),
+	}
+
+	for i, f := range idx.facts {
+		if f == nil {
+			continue
+		}
+		idx.all.set(i)
+		addToIndex(idx.byLocal, f.name.local, n, i)
+		addToIndex(idx.byURI, f.name.uri, n, i)
+		addToIndex(idx.byCtx, f.contextRef, n, i)
+		addToIndex(idx.byUnit, f.unitRef, n, i)
+		if f.nil {
+			idx.nils.set(i)
+		}
+	}
+
+	return idx
+}
+
+// Apply returns the facts matching the filter, in document order.
+//
+// The result is the same as Document.FilterFacts on the indexed document.
+func (q *QueryIndex) Apply(f *FactFilter) []*Fact {
+	if q == nil || f == nil || f.err != nil {
+		return nil
+	}
+
+	candidates := q.all.clone()
+	if f.conceptLocal != "" {
+		candidates.and(q.byLocal[f.conceptLocal])
+	}
+	if f.conceptURI != "" {
+		candidates.and(q.byURI[f.conceptURI])
+	}
+	if f.contextID != "" {
+		candidates.and(q.byCtx[f.contextID])
+	}
+	if f.unitID != "" {
+		candidates.and(q.byUnit[f.unitID])
+	}
+	if f.nilFilter != nil {
+		if *f.nilFilter {
+			candidates.and(q.nils)
+		} else {
+			candidates.andNot(q.nils)
+		}
+	}
+
+	residual := f.conceptRe != nil || len(f.valuePreds) > 0 || len(f.dims) > 0 ||
+		f.unitFilter != nil || f.decimalsMin != nil
+
+	out := make([]*Fact, 0)
+	candidates.each(func(i int) {
+		fact := q.facts[i]
+		if residual && !q.doc.matchFact(f, fact) {
+			return
+		}
+		out = append(out, fact)
+	})
+	return out
+}
+
+func addToIndex(m map[string]bitset, key string, n, i int) {
+	b, ok := m[key]
+	if !ok {
+		b = newBitset(n)
+		m[key] = b
+	}
+	b.set(i)
+}
+
+// bitset is a fixed-size set of non-negative integers.
+type bitset []uint64
+
+func newBitset(n int) bitset {
+	return make(bitset, (n+63)/64)
+}
+
+func (b bitset) set(i int) {
+	b[i/64] |= 1 << (uint(i) % 64)
+}
+
+func (b bitset) clone() bitset {
+	out := make(bitset, len(b))
+	copy(out, b)
+	return out
+}
+
+// and keeps only the bits also set in other. A nil other clears b.
+func (b bitset) and(other bitset) {
+	for i := range b {
+		if i < len(other) {
+			b[i] &= other[i]
+		} else {
+			b[i] = 0
+		}
+	}
+}
+
+// andNot clears the bits set in other.
+func (b bitset) andNot(other bitset) {
+	for i := range b {
+		if i < len(other) {
+			b[i] &^= other[i]
+		}
+	}
+}
+
+// each calls fn for every set bit in ascending order.
+func (b bitset) each(fn func(i int)) {
+	for w, word := range b {
+		for word != 0 {
+			t := bits.TrailingZeros64(word)
+			fn(w*64 + t)
+			word &= word - 1
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package xbrl_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aethiopicuschan/xbrl-go/pkg/xbrl"
+	"github.com/stretchr/testify/assert"
+)
+
+// newIndexedDoc builds a document with n facts spread over several
+// concepts, contexts and units. Every 7th fact is nil, every 5th has no
+// unit, and decimals vary between absent, negative, zero, positive and INF.
+func newIndexedDoc(n int) *xbrl.Document {
+	dim := xbrl.NewQNameForTest("d", "RegionAxis", "urn:dim")
+	contexts := map[string]*xbrl.Context{}
+	for i := range 10 {
+		id := fmt.Sprintf("C%d", i)
+		var dims []xbrl.Dimension
+		if i%2 == 0 {
+			mem := xbrl.NewQNameForTest("d", fmt.Sprintf("M%d", i%4), "urn:dim")
+			dims = append(dims, xbrl.NewDimensionForTest(dim, true, mem, ""))
+		}
+		contexts[id] = xbrl.NewContextForTest(id, xbrl.Entity{}, xbrl.Period{}, dims)
+	}
+
+	decimals := []string{"", "-3", "0", "2", "INF", "x"}
+	facts := make([]*xbrl.Fact, 0, n)
+	for i := range n {
+		unit := fmt.Sprintf("U%d", i%4)
+		if i%5 == 0 {
+			unit = ""
+		}
+		q := xbrl.NewQNameForTest("p", fmt.Sprintf("Concept%d", i%50), fmt.Sprintf("urn:ns%d", i%3))
+		facts = append(facts, xbrl.NewFactForTest(
+			xbrl.FactKindItem,
+			q,
+			fmt.Sprintf("v%d", i),
+			fmt.Sprintf("C%d", i%10),
+			unit,
+			decimals[i%len(decimals)],
+			"", "", "",
+			i%7 == 0,
+		))
+	}
+	facts = append(facts, nil)
+	return xbrl.NewDocumentForTest(nil, contexts, nil, facts, nil)
+}
+
+func TestQueryIndex_ApplyMatchesFilterFacts(t *testing.T) {
+	t.Parallel()
+
+	doc := newIndexedDoc(500)
+	idx := doc.NewQueryIndex()
+
+	dim := xbrl.NewQNameForTest("", "RegionAxis", "urn:dim")
+	mem := xbrl.NewQNameForTest("", "M2", "urn:dim")
+
+	tests := []struct {
+		name   string
+		filter func() *xbrl.FactFilter
+	}{
+		{"empty filter", func() *xbrl.FactFilter { return xbrl.NewFactFilter() }},
+		{"concept local", func() *xbrl.FactFilter { return xbrl.NewFactFilter().ConceptLocal("Concept3") }},
+		{"concept local and uri", func() *xbrl.FactFilter {
+			return xbrl.NewFactFilter().ConceptLocal("Concept3").ConceptURI("urn:ns1")
+		}},
+		{"context and unit", func() *xbrl.FactFilter { return xbrl.NewFactFilter().ContextID("C4").UnitID("U0") }},
+		{"only nil", func() *xbrl.FactFilter { return xbrl.NewFactFilter().OnlyNil() }},
+		{"exclude nil", func() *xbrl.FactFilter { return xbrl.NewFactFilter().ExcludeNil().UnitID("U1") }},
+		{"unknown context", func() *xbrl.FactFilter { return xbrl.NewFactFilter().ContextID("missing") }},
+		{"regexp", func() *xbrl.FactFilter { return xbrl.NewFactFilter().ConceptLocalRegexp("^Concept1") }},
+		{"value predicate", func() *xbrl.FactFilter { return xbrl.NewFactFilter().ValueContains("9") }},
+		{"dimension", func() *xbrl.FactFilter { return xbrl.NewFactFilter().Dimension(dim, mem).ExcludeNil() }},
+		{"has unit", func() *xbrl.FactFilter { return xbrl.NewFactFilter().HasUnit() }},
+		{"no unit", func() *xbrl.FactFilter { return xbrl.NewFactFilter().NoUnit() }},
+		{"decimals at least", func() *xbrl.FactFilter { return xbrl.NewFactFilter().DecimalsAtLeast(0) }},
+		{"decimals with context", func() *xbrl.FactFilter {
+			return xbrl.NewFactFilter().ContextID("C3").DecimalsAtLeast(-3).HasUnit()
+		}},
+		{"invalid regexp", func() *xbrl.FactFilter { return xbrl.NewFactFilter().ConceptLocalRegexp("(") }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, doc.FilterFacts(tt.filter()), idx.Apply(tt.filter()))
+		})
+	}
+}
+
+func TestQueryIndex_Nil(t *testing.T) {
+	t.Parallel()
+
+	var doc *xbrl.Document
+	assert.Nil(t, doc.NewQueryIndex())
+
+	var idx *xbrl.QueryIndex
+	assert.Nil(t, idx.Apply(xbrl.NewFactFilter()))
+
+	idx = newIndexedDoc(10).NewQueryIndex()
+	assert.Nil(t, idx.Apply(nil))
+}
+
+// benchmarkFilters returns a set of filters representative of repeated
+// point queries against a large document.
+func benchmarkFilters() []*xbrl.FactFilter {
+	var filters []*xbrl.FactFilter
+	for i := range 100 {
+		filters = append(filters, xbrl.NewFactFilter().
+			ConceptLocal(fmt.Sprintf("Concept%d", i%50)).
+			ContextID(fmt.Sprintf("C%d", i%10)).
+			ExcludeNil())
+	}
+	return filters
+}
+
+func BenchmarkFilterFacts_ManyQueries(b *testing.B) {
+	doc := newIndexedDoc(50000)
+	filters := benchmarkFilters()
+
+	for b.Loop() {
+		for _, f := range filters {
+			_ = doc.FilterFacts(f)
+		}
+	}
+}
+
+func BenchmarkQueryIndex_ManyQueries(b *testing.B) {
+	doc := newIndexedDoc(50000)
+	filters := benchmarkFilters()
+	idx := doc.NewQueryIndex()
+
+	for b.Loop() {
+		for _, f := range filters {
+			_ = idx.Apply(f)
+		}
+	}
+}
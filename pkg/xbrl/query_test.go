@@ -0,0 +1,87 @@
+package xbrl_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aethiopicuschan/xbrl-go/pkg/xbrl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFactQuery(t *testing.T) {
+	t.Parallel()
+
+	doc, err := xbrl.Parse(strings.NewReader(regionInstance))
+	require.NoError(t, err)
+
+	t.Run("Float64 single match", func(t *testing.T) {
+		t.Parallel()
+
+		v, err := doc.Query().Concept("Revenue").Context("Total").Float64()
+		require.NoError(t, err)
+		assert.Equal(t, 1000.0, v)
+	})
+
+	t.Run("Float64 with taxonomy", func(t *testing.T) {
+		t.Parallel()
+
+		v, err := newRegionDoc(t, "monetaryItemType").Query().Concept("Revenue").Context("Japan").Float64()
+		require.NoError(t, err)
+		assert.Equal(t, 600.0, v)
+
+		_, err = newRegionDoc(t, "stringItemType").Query().Concept("Revenue").Context("Japan").Float64()
+		assert.ErrorIs(t, err, xbrl.ErrUnsupportedType)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := doc.Query().Concept("Revenue").Float64()
+		assert.ErrorIs(t, err, xbrl.ErrAmbiguousMatch)
+		assert.EqualError(t, err, "xbrl: multiple facts match the query: 5 facts")
+
+		_, err = doc.Query().Concept("Profit").Float64()
+		assert.ErrorIs(t, err, xbrl.ErrNoMatch)
+
+		_, err = doc.Query().Concept("Revenue").Context("US").ConceptURI("http://example.com/xbrl").Float64()
+		assert.ErrorIs(t, err, xbrl.ErrAmbiguousMatch, "the nil fact also matches")
+	})
+
+	t.Run("Float64 rejects non-decimal values", func(t *testing.T) {
+		t.Parallel()
+
+		for _, v := range []string{"NaN", "Inf", "1_000", "0x10"} {
+			doc, err := xbrl.Parse(strings.NewReader(strings.Replace(regionInstance, ">1000<", ">"+v+"<", 1)))
+			require.NoError(t, err)
+			_, err = doc.Query().Concept("Revenue").Context("Total").Float64()
+			assert.ErrorIs(t, err, xbrl.ErrInvalidValue, v)
+		}
+	})
+
+	t.Run("Strings", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, []string{"1000", "600", "50", "400", ""}, doc.Query().Concept("Revenue").Unit("JPY").Strings())
+		assert.Nil(t, doc.Query().Concept("Profit").Strings())
+	})
+
+	t.Run("Dimension", func(t *testing.T) {
+		t.Parallel()
+
+		axis := xbrl.NewQNameForTest("ex", "RegionAxis", "http://example.com/xbrl")
+		japan := xbrl.NewQNameForTest("ex", "JapanMember", "http://example.com/xbrl")
+		assert.Equal(t, []string{"600", "50"}, doc.Query().Concept("Revenue").Dimension(axis, japan).Strings())
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		t.Parallel()
+
+		var nilDoc *xbrl.Document
+		_, err := nilDoc.Query().Concept("Revenue").Float64()
+		assert.ErrorIs(t, err, xbrl.ErrNoMatch)
+
+		var q *xbrl.FactQuery
+		assert.Nil(t, q.Concept("Revenue").Context("Total").Facts())
+	})
+}
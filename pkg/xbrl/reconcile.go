@@ -0,0 +1,185 @@
+package xbrl
+
+import (
+	"math/big"
+	"slices"
+	"strings"
+)
+
+// ReconciliationReport describes how the facts of two documents compare.
+type ReconciliationReport struct {
+	// Matched is the number of fact pairs whose values are equal.
+	Matched int
+	// Differences holds fact pairs with the same concept and context
+	// signature whose values differ.
+	Differences []FactDifference
+	// OnlyInA holds facts of the first document without a counterpart.
+	OnlyInA []*Fact
+	// OnlyInB holds facts of the second document without a counterpart.
+	OnlyInB []*Fact
+}
+
+// FactDifference is a pair of corresponding facts whose values differ.
+type FactDifference struct {
+	A *Fact
+	B *Fact
+}
+
+// IsEmpty reports whether the report contains no differences and no
+// unmatched facts.
+func (r ReconciliationReport) IsEmpty() bool {
+	return len(r.Differences) == 0 && len(r.OnlyInA) == 0 && len(r.OnlyInB) == 0
+}
+
+// ReconcileDocuments compares the facts of a and b.
+//
+// Facts correspond when their concepts have the same namespace URI and
+// local name and their contexts have the same Signature, so contexts
+// with different IDs but equivalent content are aligned. If several
+// facts share a key within a document, they are paired in document
+// order. Values are compared numerically when both parse as decimals
+// (so "1000" equals "1000.0"), and as trimmed strings otherwise.
+//
+// All slices in the report are in document order. A nil document is
+// treated as empty.
+func ReconcileDocuments(a, b *Document) ReconciliationReport {
+	var report ReconciliationReport
+
+	bByKey := make(map[reconcileKey][]*Fact)
+	for _, f := range b.reconcileFacts() {
+		k := b.reconcileKey(f)
+		bByKey[k] = append(bByKey[k], f)
+	}
+
+	matchedB := make(map[*Fact]struct{})
+	for _, fa := range a.reconcileFacts() {
+		k := a.reconcileKey(fa)
+		candidates := bByKey[k]
+		if len(candidates) == 0 {
+			report.OnlyInA = append(report.OnlyInA, fa)
+			continue
+		}
+		fb := candidates[0]
+		bByKey[k] = candidates[1:]
+		matchedB[fb] = struct{}{}
+
+		if factValuesEqual(fa, fb) {
+			report.Matched++
+		} else {
+			report.Differences = append(report.Differences, FactDifference{A: fa, B: fb})
+		}
+	}
+
+	for _, fb := range b.reconcileFacts() {
+		if _, ok := matchedB[fb]; !ok {
+			report.OnlyInB = append(report.OnlyInB, fb)
+		}
+	}
+	return report
+}
+
+// reconcileKey identifies corresponding facts across documents.
+type reconcileKey struct {
+	uri       string
+	local     string
+	signature string
+}
+
+// reconcileFacts returns the non-nil facts of the document.
+func (d *Document) reconcileFacts() []*Fact {
+	if d == nil {
+		return nil
+	}
+	out := make([]*Fact, 0, len(d.facts))
+	for _, f := range d.facts {
+		if f != nil {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+func (d *Document) reconcileKey(f *Fact) reconcileKey {
+	k := reconcileKey{uri: f.name.uri, local: f.name.local}
+	if ctx, ok := d.contexts[f.contextRef]; ok {
+		k.signature = ctx.Signature()
+	} else {
+		// Unresolvable contexts only match by their ID.
+		k.signature = "#" + f.contextRef
+	}
+	return k
+}
+
+// factValuesEqual compares two fact values numerically when possible.
+func factValuesEqual(a, b *Fact) bool {
+	if a.nil || b.nil {
+		return a.nil == b.nil
+	}
+	av := strings.TrimSpace(a.value)
+	bv := strings.TrimSpace(b.value)
+	if ar, ok := parseDecimal(av); ok {
+		if br, ok := parseDecimal(bv); ok {
+			return ar.Cmp(br) == 0
+		}
+	}
+	return av == bv
+}
+
+// parseDecimal parses s as a decimal number, optionally with an exponent.
+// Values that are not in that lexical form, such as "1/2" or "0x10",
+// are rejected even though big.Rat would accept them.
+func parseDecimal(s string) (*big.Rat, bool) {
+	if !isDecimalLexical(s, true) {
+		return nil, false
+	}
+	return new(big.Rat).SetString(s)
+}
+
+// Signature returns a canonical string describing the content of the
+// context: its entity identifier, period and dimensions. The context ID
+// is not included, so equivalent contexts in different documents have
+// the same signature. Dimensions are sorted, so their order does not
+// matter.
+func (c *Context) Signature() string {
+	if c == nil {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString(c.entity.identifier.scheme)
+	sb.WriteByte('|')
+	sb.WriteString(c.entity.identifier.value)
+	sb.WriteByte('|')
+
+	p := c.period
+	switch {
+	case p.forever:
+		sb.WriteString("forever")
+	case p.instant != nil:
+		sb.WriteString(*p.instant)
+	default:
+		if p.startDate != nil {
+			sb.WriteString(*p.startDate)
+		}
+		sb.WriteString("--")
+		if p.endDate != nil {
+			sb.WriteString(*p.endDate)
+		}
+	}
+
+	dims := make([]string, len(c.dimensions))
+	for i, d := range c.dimensions {
+		name := QName{local: d.dimension.local, uri: d.dimension.uri}.String()
+		if d.explicit {
+			dims[i] = name + "=" + QName{local: d.member.local, uri: d.member.uri}.String()
+		} else {
+			dims[i] = name + "~" + d.typedValue
+		}
+	}
+	slices.Sort(dims)
+	for _, d := range dims {
+		sb.WriteByte('|')
+		sb.WriteString(d)
+	}
+	return sb.String()
+}
@@ -0,0 +1,197 @@
+package xbrl_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/aethiopicuschan/xbrl-go/pkg/xbrl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const reconcileInstanceA = `<xbrli:xbrl
+    xmlns:xbrli="http://www.xbrl.org/2003/instance"
+    xmlns:xbrldi="http://xbrl.org/2006/xbrldi"
+    xmlns:ex="http://example.com/ex">
+  <xbrli:context id="C1">
+    <xbrli:entity>
+      <xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier>
+    </xbrli:entity>
+    <xbrli:period>
+      <xbrli:startDate>2025-01-01</xbrli:startDate>
+      <xbrli:endDate>2025-12-31</xbrli:endDate>
+    </xbrli:period>
+  </xbrli:context>
+  <xbrli:context id="C2">
+    <xbrli:entity>
+      <xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier>
+    </xbrli:entity>
+    <xbrli:period>
+      <xbrli:startDate>2025-01-01</xbrli:startDate>
+      <xbrli:endDate>2025-12-31</xbrli:endDate>
+    </xbrli:period>
+    <xbrli:scenario>
+      <xbrldi:explicitMember dimension="ex:Region">ex:Japan</xbrldi:explicitMember>
+      <xbrldi:explicitMember dimension="ex:Product">ex:Widgets</xbrldi:explicitMember>
+    </xbrli:scenario>
+  </xbrli:context>
+  <ex:Revenue contextRef="C1" unitRef="JPY" decimals="0">1000</ex:Revenue>
+  <ex:NetIncome contextRef="C1" unitRef="JPY" decimals="0">200</ex:NetIncome>
+  <ex:Revenue contextRef="C2" unitRef="JPY" decimals="0">300</ex:Revenue>
+  <ex:Name contextRef="C1">ABC Corp</ex:Name>
+  <ex:Assets contextRef="C1" unitRef="JPY" decimals="0">5000</ex:Assets>
+</xbrli:xbrl>`
+
+// reconcileInstanceB uses different context IDs and dimension order.
+const reconcileInstanceB = `<xbrli:xbrl
+    xmlns:xbrli="http://www.xbrl.org/2003/instance"
+    xmlns:xbrldi="http://xbrl.org/2006/xbrldi"
+    xmlns:other="http://example.com/ex">
+  <xbrli:context id="CurrentYear">
+    <xbrli:entity>
+      <xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier>
+    </xbrli:entity>
+    <xbrli:period>
+      <xbrli:startDate>2025-01-01</xbrli:startDate>
+      <xbrli:endDate>2025-12-31</xbrli:endDate>
+    </xbrli:period>
+  </xbrli:context>
+  <xbrli:context id="CurrentYear_JapanWidgets">
+    <xbrli:entity>
+      <xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier>
+    </xbrli:entity>
+    <xbrli:period>
+      <xbrli:startDate>2025-01-01</xbrli:startDate>
+      <xbrli:endDate>2025-12-31</xbrli:endDate>
+    </xbrli:period>
+    <xbrli:scenario>
+      <xbrldi:explicitMember dimension="other:Product">other:Widgets</xbrldi:explicitMember>
+      <xbrldi:explicitMember dimension="other:Region">other:Japan</xbrldi:explicitMember>
+    </xbrli:scenario>
+  </xbrli:context>
+  <other:Revenue contextRef="CurrentYear" unitRef="JPY" decimals="0">1200</other:Revenue>
+  <other:NetIncome contextRef="CurrentYear" unitRef="JPY" decimals="0">200.00</other:NetIncome>
+  <other:Revenue contextRef="CurrentYear_JapanWidgets" unitRef="JPY" decimals="0">300</other:Revenue>
+  <other:Name contextRef="CurrentYear">ABC Corp</other:Name>
+</xbrli:xbrl>`
+
+func TestReconcileDocuments(t *testing.T) {
+	t.Parallel()
+
+	a, err := xbrl.Parse(strings.NewReader(reconcileInstanceA))
+	require.NoError(t, err)
+	b, err := xbrl.Parse(strings.NewReader(reconcileInstanceB))
+	require.NoError(t, err)
+
+	t.Run("value difference and missing fact", func(t *testing.T) {
+		t.Parallel()
+
+		report := xbrl.ReconcileDocuments(a, b)
+		assert.False(t, report.IsEmpty())
+		assert.Equal(t, 3, report.Matched, "NetIncome, dimensional Revenue and Name match")
+
+		require.Len(t, report.Differences, 1)
+		diff := report.Differences[0]
+		assert.Equal(t, "Revenue", diff.A.Name().Local())
+		assert.Equal(t, "1000", diff.A.Value())
+		assert.Equal(t, "1200", diff.B.Value())
+
+		require.Len(t, report.OnlyInA, 1)
+		assert.Equal(t, "Assets", report.OnlyInA[0].Name().Local())
+		assert.Empty(t, report.OnlyInB)
+	})
+
+	t.Run("reversed", func(t *testing.T) {
+		t.Parallel()
+
+		report := xbrl.ReconcileDocuments(b, a)
+		require.Len(t, report.Differences, 1)
+		assert.Equal(t, "1200", report.Differences[0].A.Value())
+		assert.Empty(t, report.OnlyInA)
+		require.Len(t, report.OnlyInB, 1)
+		assert.Equal(t, "Assets", report.OnlyInB[0].Name().Local())
+	})
+
+	t.Run("identical documents", func(t *testing.T) {
+		t.Parallel()
+
+		report := xbrl.ReconcileDocuments(a, a)
+		assert.True(t, report.IsEmpty())
+		assert.Equal(t, 5, report.Matched)
+	})
+
+	t.Run("nil documents", func(t *testing.T) {
+		t.Parallel()
+
+		assert.True(t, xbrl.ReconcileDocuments(nil, nil).IsEmpty())
+
+		report := xbrl.ReconcileDocuments(nil, b)
+		assert.Len(t, report.OnlyInB, 4)
+	})
+}
+
+func TestReconcileDocuments_NumericValues(t *testing.T) {
+	t.Parallel()
+
+	const tmpl = `<xbrli:xbrl xmlns:xbrli="http://www.xbrl.org/2003/instance" xmlns:ex="http://example.com/ex">
+  <xbrli:context id="C1">
+    <xbrli:entity><xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:instant>2025-12-31</xbrli:instant></xbrli:period>
+  </xbrli:context>
+  <ex:Amount contextRef="C1" unitRef="JPY" decimals="0">%s</ex:Amount>
+</xbrli:xbrl>`
+
+	tests := []struct {
+		name  string
+		a, b  string
+		equal bool
+	}{
+		{name: "trailing zeros", a: "16", b: "16.00", equal: true},
+		{name: "exponent form", a: "1E1", b: "10", equal: true},
+		{name: "hex prefix", a: "0x10", b: "16", equal: false},
+		{name: "fraction", a: "1/2", b: "0.5", equal: false},
+		{name: "underscore", a: "1_000", b: "1000", equal: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			a, err := xbrl.Parse(strings.NewReader(fmt.Sprintf(tmpl, tt.a)))
+			require.NoError(t, err)
+			b, err := xbrl.Parse(strings.NewReader(fmt.Sprintf(tmpl, tt.b)))
+			require.NoError(t, err)
+
+			report := xbrl.ReconcileDocuments(a, b)
+			if tt.equal {
+				assert.Equal(t, 1, report.Matched)
+				assert.Empty(t, report.Differences)
+			} else {
+				assert.Equal(t, 0, report.Matched)
+				assert.Len(t, report.Differences, 1)
+			}
+		})
+	}
+}
+
+func TestContext_Signature(t *testing.T) {
+	t.Parallel()
+
+	a, err := xbrl.Parse(strings.NewReader(reconcileInstanceA))
+	require.NoError(t, err)
+	b, err := xbrl.Parse(strings.NewReader(reconcileInstanceB))
+	require.NoError(t, err)
+
+	c1, _ := a.ContextByID("C1")
+	c2, _ := a.ContextByID("C2")
+	current, _ := b.ContextByID("CurrentYear")
+	japan, _ := b.ContextByID("CurrentYear_JapanWidgets")
+
+	assert.Equal(t, c1.Signature(), current.Signature(), "IDs are ignored")
+	assert.Equal(t, c2.Signature(), japan.Signature(), "prefixes and dimension order are ignored")
+	assert.NotEqual(t, c1.Signature(), c2.Signature())
+
+	var nilCtx *xbrl.Context
+	assert.Equal(t, "", nilCtx.Signature())
+}
@@ -0,0 +1,51 @@
+package xbrl
+
+import (
+	"slices"
+	"strings"
+)
+
+// SearchConcepts returns the concepts of the attached taxonomy whose
+// labels in lang contain query, ignoring case. Labels of any role are
+// searched. A label matches lang if its language equals lang or has the
+// same primary subtag ("en-US" matches "en"); an empty lang searches
+// labels in all languages. Concepts whose labels do not match are still
+// found by their local name, so the search is useful before labels are
+// loaded.
+//
+// Results are sorted by namespace URI and local name. It returns nil if
+// no taxonomy is attached or query is empty.
+func (d *Document) SearchConcepts(query, lang string) []*Concept {
+	if d == nil || d.taxonomy == nil || query == "" {
+		return nil
+	}
+	t := d.taxonomy
+	q := strings.ToLower(query)
+
+	var out []*Concept
+	for _, c := range t.concepts {
+		if c == nil {
+			continue
+		}
+		if t.labelContains(c.qname, q, lang) || strings.Contains(strings.ToLower(c.qname.local), q) {
+			out = append(out, c)
+		}
+	}
+	slices.SortFunc(out, compareConcepts)
+	return out
+}
+
+// labelContains reports whether a label of q in lang contains the
+// lower-cased substring sub.
+func (t *Taxonomy) labelContains(q QName, sub, lang string) bool {
+	for _, l := range t.labels[conceptKey{q.uri, q.local}] {
+		if lang != "" && !strings.EqualFold(l.lang, lang) &&
+			!strings.EqualFold(primaryLang(l.lang), primaryLang(lang)) {
+			continue
+		}
+		if strings.Contains(strings.ToLower(l.text), sub) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package xbrl_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aethiopicuschan/xbrl-go/pkg/xbrl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocument_SearchConcepts(t *testing.T) {
+	t.Parallel()
+
+	files := map[string]string{
+		"tax/ex.xsd":     linkbaseSchema,
+		"tax/ex_lab.xml": labelLinkbase,
+		"tax/ex_pre.xml": presentationLinkbase,
+	}
+	doc, err := xbrl.Parse(strings.NewReader(linkbaseInstance))
+	require.NoError(t, err)
+	_, err = doc.LoadTaxonomyFromSchemaRefs(mapOpener(files, nil))
+	require.NoError(t, err)
+	require.NoError(t, doc.LoadLinkbases(mapOpener(files, nil)))
+
+	bare, err := xbrl.Parse(strings.NewReader(linkbaseInstance))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		doc   *xbrl.Document
+		query string
+		lang  string
+		want  []string
+	}{
+		{name: "Japanese label substring", doc: doc, query: "資産", lang: "ja", want: []string{"Assets"}},
+		{name: "English label is case-insensitive", doc: doc, query: "TOTAL", lang: "en", want: []string{"Assets"}},
+		{name: "primary language fallback", doc: doc, query: "total", lang: "en-GB", want: []string{"Assets"}},
+		{name: "label in other language does not match", doc: doc, query: "total", lang: "ja", want: nil},
+		{name: "any language", doc: doc, query: "合計", lang: "", want: []string{"Assets"}},
+		{name: "local name fallback", doc: doc, query: "cash", lang: "ja", want: []string{"Cash"}},
+		{name: "matches several concepts", doc: doc, query: "s", lang: "en", want: []string{"Assets", "Cash"}},
+		{name: "no match", doc: doc, query: "負債", lang: "ja", want: nil},
+		{name: "empty query", doc: doc, query: "", lang: "ja", want: nil},
+		{name: "no taxonomy", doc: bare, query: "Assets", lang: "", want: nil},
+		{name: "nil document", doc: nil, query: "Assets", lang: "", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got []string
+			for _, c := range tt.doc.SearchConcepts(tt.query, tt.lang) {
+				got = append(got, c.QName().Local())
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package xbrl
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+)
+
+// StatementTable is a financial statement assembled by Document.Statement:
+// the concepts of a presentation role in presentation order, with one
+// value per period.
+type StatementTable struct {
+	role    string
+	periods []string
+	rows    []StatementRow
+}
+
+// Role returns the extended link role the table was built from.
+func (t *StatementTable) Role() string {
+	if t == nil {
+		return ""
+	}
+	return t.role
+}
+
+// Periods returns a copy of the column keys of the table: the
+// AlignmentKey of each period, latest first.
+func (t *StatementTable) Periods() []string {
+	if t == nil {
+		return nil
+	}
+	out := make([]string, len(t.periods))
+	copy(out, t.periods)
+	return out
+}
+
+// Rows returns a copy of the rows of the table in presentation order.
+func (t *StatementTable) Rows() []StatementRow {
+	if t == nil {
+		return nil
+	}
+	out := make([]StatementRow, len(t.rows))
+	copy(out, t.rows)
+	return out
+}
+
+// StatementRow is a single row of a StatementTable.
+type StatementRow struct {
+	concept        QName
+	depth          int
+	header         bool
+	preferredLabel string
+	values         []string
+}
+
+// Concept returns the concept presented on the row.
+func (r StatementRow) Concept() QName {
+	return r.concept
+}
+
+// Depth returns the depth of the row in the presentation tree; roots
+// have depth 0.
+func (r StatementRow) Depth() int {
+	return r.depth
+}
+
+// IsHeader reports whether the row is a section header, i.e. its concept
+// is abstract. Header rows have no values.
+func (r StatementRow) IsHeader() bool {
+	return r.header
+}
+
+// PreferredLabel returns the preferredLabel role of the arc that placed
+// the concept on the row, if any.
+func (r StatementRow) PreferredLabel() string {
+	return r.preferredLabel
+}
+
+// Values returns a copy of the row's values, one per period of the table
+// ("" where no fact is reported).
+func (r StatementRow) Values() []string {
+	if r.values == nil {
+		return nil
+	}
+	out := make([]string, len(r.values))
+	copy(out, r.values)
+	return out
+}
+
+// Statement assembles the statement presented by the parent-child
+// relationships of the given extended link role into a table. Rows follow
+// the presentation tree depth-first, with siblings sorted by @order;
+// abstract concepts become header rows. Columns are the periods (by
+// AlignmentKey) in which any line item has a fact, and cells are filled
+// as by Matrix.
+//
+// Linkbases must be loaded beforehand (see LoadLinkbases). It returns
+// ErrNoTaxonomy if no taxonomy is attached, and an error if the role has
+// no presentation relationships.
+func (d *Document) Statement(role string) (*StatementTable, error) {
+	if d == nil {
+		return nil, fmt.Errorf("xbrl: document is nil")
+	}
+	if d.taxonomy == nil {
+		return nil, ErrNoTaxonomy
+	}
+
+	children := make(map[conceptKey][]Relationship)
+	isChild := make(map[conceptKey]bool)
+	var roots []QName
+	seenRoot := make(map[conceptKey]bool)
+	for _, r := range d.taxonomy.relationships {
+		if r.arcrole != ArcroleParentChild || r.role != role {
+			continue
+		}
+		from := conceptKey{r.from.uri, r.from.local}
+		children[from] = append(children[from], r)
+		isChild[conceptKey{r.to.uri, r.to.local}] = true
+		if !seenRoot[from] {
+			seenRoot[from] = true
+			roots = append(roots, r.from)
+		}
+	}
+	if len(children) == 0 {
+		return nil, fmt.Errorf("xbrl: no presentation relationships for role %q", role)
+	}
+	for _, rels := range children {
+		slices.SortStableFunc(rels, func(a, b Relationship) int {
+			return cmp.Compare(a.order, b.order)
+		})
+	}
+
+	table := &StatementTable{role: role}
+	onPath := make(map[conceptKey]bool)
+	var walk func(q QName, depth int, preferredLabel string)
+	walk = func(q QName, depth int, preferredLabel string) {
+		k := conceptKey{q.uri, q.local}
+		if onPath[k] {
+			return // cycle
+		}
+		c, _ := d.taxonomy.Concept(q)
+		table.rows = append(table.rows, StatementRow{
+			concept:        q,
+			depth:          depth,
+			header:         c.Abstract(),
+			preferredLabel: preferredLabel,
+		})
+		onPath[k] = true
+		for _, r := range children[k] {
+			walk(r.to, depth+1, r.preferredLabel)
+		}
+		onPath[k] = false
+	}
+	for _, q := range roots {
+		if !isChild[conceptKey{q.uri, q.local}] {
+			walk(q, 0, "")
+		}
+	}
+
+	var items []QName
+	wanted := make(map[conceptKey]bool)
+	for _, row := range table.rows {
+		if !row.header {
+			items = append(items, row.concept)
+			wanted[conceptKey{row.concept.uri, row.concept.local}] = true
+		}
+	}
+	periods := make(map[string]bool)
+	for _, f := range d.facts {
+		if f == nil || f.nil || !wanted[conceptKey{f.name.uri, f.name.local}] {
+			continue
+		}
+		if c := d.contexts[f.contextRef]; c != nil {
+			if key := c.period.AlignmentKey(); key != "" {
+				periods[key] = true
+			}
+		}
+	}
+	for key := range periods {
+		table.periods = append(table.periods, key)
+	}
+	slices.SortFunc(table.periods, func(a, b string) int { return cmp.Compare(b, a) })
+
+	values := d.Matrix(items, table.periods)
+	i := 0
+	for j := range table.rows {
+		if !table.rows[j].header {
+			table.rows[j].values = values[i]
+			i++
+		}
+	}
+	return table, nil
+}
@@ -0,0 +1,171 @@
+package xbrl_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aethiopicuschan/xbrl-go/pkg/xbrl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const balanceSheetRole = "http://example.com/role/BalanceSheet"
+
+const statementInstance = `<?xml version="1.0" encoding="UTF-8"?>
+<xbrli:xbrl
+    xmlns:xbrli="http://www.xbrl.org/2003/instance"
+    xmlns:link="http://www.xbrl.org/2003/linkbase"
+    xmlns:xlink="http://www.w3.org/1999/xlink"
+    xmlns:iso4217="http://www.xbrl.org/2003/iso4217"
+    xmlns:ex="http://example.com/ex">
+  <link:schemaRef xlink:type="simple" xlink:href="ex.xsd"/>
+  <link:linkbaseRef xlink:type="simple" xlink:href="ex_pre.xml"
+      xlink:role="http://www.xbrl.org/2003/role/presentationLinkbaseRef"
+      xlink:arcrole="http://www.w3.org/1999/xlink/properties/linkbase"/>
+  <xbrli:context id="Prior">
+    <xbrli:entity><xbrli:identifier scheme="http://example.com">E1</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:instant>2024-12-31</xbrli:instant></xbrli:period>
+  </xbrli:context>
+  <xbrli:context id="Current">
+    <xbrli:entity><xbrli:identifier scheme="http://example.com">E1</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:instant>2025-12-31</xbrli:instant></xbrli:period>
+  </xbrli:context>
+  <xbrli:unit id="JPY"><xbrli:measure>iso4217:JPY</xbrli:measure></xbrli:unit>
+  <ex:Cash contextRef="Current" unitRef="JPY" decimals="0">100</ex:Cash>
+  <ex:Cash contextRef="Prior" unitRef="JPY" decimals="0">80</ex:Cash>
+  <ex:Receivables contextRef="Current" unitRef="JPY" decimals="0">50</ex:Receivables>
+  <ex:Assets contextRef="Current" unitRef="JPY" decimals="0">150</ex:Assets>
+  <ex:Assets contextRef="Prior" unitRef="JPY" decimals="0">80</ex:Assets>
+  <ex:Liabilities contextRef="Current" unitRef="JPY" decimals="0">70</ex:Liabilities>
+</xbrli:xbrl>
+`
+
+const statementSchema = `<?xml version="1.0" encoding="UTF-8"?>
+<xs:schema
+    xmlns:xs="http://www.w3.org/2001/XMLSchema"
+    xmlns:xbrli="http://www.xbrl.org/2003/instance"
+    targetNamespace="http://example.com/ex">
+  <xs:element id="ex_BalanceSheetAbstract" name="BalanceSheetAbstract" substitutionGroup="xbrli:item" type="xbrli:stringItemType" periodType="duration" abstract="true"/>
+  <xs:element id="ex_AssetsAbstract" name="AssetsAbstract" substitutionGroup="xbrli:item" type="xbrli:stringItemType" periodType="duration" abstract="true"/>
+  <xs:element id="ex_Cash" name="Cash" substitutionGroup="xbrli:item" type="xbrli:monetaryItemType" periodType="instant"/>
+  <xs:element id="ex_Receivables" name="Receivables" substitutionGroup="xbrli:item" type="xbrli:monetaryItemType" periodType="instant"/>
+  <xs:element id="ex_Assets" name="Assets" substitutionGroup="xbrli:item" type="xbrli:monetaryItemType" periodType="instant"/>
+  <xs:element id="ex_Liabilities" name="Liabilities" substitutionGroup="xbrli:item" type="xbrli:monetaryItemType" periodType="instant"/>
+</xs:schema>
+`
+
+// statementPresentation lists arcs out of order to check that siblings
+// are sorted by @order.
+const statementPresentation = `<?xml version="1.0" encoding="UTF-8"?>
+<link:linkbase
+    xmlns:link="http://www.xbrl.org/2003/linkbase"
+    xmlns:xlink="http://www.w3.org/1999/xlink">
+  <link:presentationLink xlink:type="extended" xlink:role="http://example.com/role/BalanceSheet">
+    <link:loc xlink:type="locator" xlink:href="ex.xsd#ex_BalanceSheetAbstract" xlink:label="BalanceSheetAbstract"/>
+    <link:loc xlink:type="locator" xlink:href="ex.xsd#ex_AssetsAbstract" xlink:label="AssetsAbstract"/>
+    <link:loc xlink:type="locator" xlink:href="ex.xsd#ex_Cash" xlink:label="Cash"/>
+    <link:loc xlink:type="locator" xlink:href="ex.xsd#ex_Receivables" xlink:label="Receivables"/>
+    <link:loc xlink:type="locator" xlink:href="ex.xsd#ex_Assets" xlink:label="Assets"/>
+    <link:loc xlink:type="locator" xlink:href="ex.xsd#ex_Liabilities" xlink:label="Liabilities"/>
+    <link:presentationArc xlink:type="arc" xlink:arcrole="http://www.xbrl.org/2003/arcrole/parent-child"
+        xlink:from="BalanceSheetAbstract" xlink:to="Liabilities" order="2"/>
+    <link:presentationArc xlink:type="arc" xlink:arcrole="http://www.xbrl.org/2003/arcrole/parent-child"
+        xlink:from="BalanceSheetAbstract" xlink:to="AssetsAbstract" order="1"/>
+    <link:presentationArc xlink:type="arc" xlink:arcrole="http://www.xbrl.org/2003/arcrole/parent-child"
+        xlink:from="AssetsAbstract" xlink:to="Assets" order="3" preferredLabel="http://www.xbrl.org/2003/role/totalLabel"/>
+    <link:presentationArc xlink:type="arc" xlink:arcrole="http://www.xbrl.org/2003/arcrole/parent-child"
+        xlink:from="AssetsAbstract" xlink:to="Receivables" order="2"/>
+    <link:presentationArc xlink:type="arc" xlink:arcrole="http://www.xbrl.org/2003/arcrole/parent-child"
+        xlink:from="AssetsAbstract" xlink:to="Cash" order="1"/>
+  </link:presentationLink>
+</link:linkbase>
+`
+
+func TestDocument_Statement(t *testing.T) {
+	t.Parallel()
+
+	files := map[string]string{
+		"ex.xsd":     statementSchema,
+		"ex_pre.xml": statementPresentation,
+	}
+	doc, err := xbrl.Parse(strings.NewReader(statementInstance))
+	require.NoError(t, err)
+	_, err = doc.LoadTaxonomyFromSchemaRefs(mapOpener(files, nil))
+	require.NoError(t, err)
+	require.NoError(t, doc.LoadLinkbases(mapOpener(files, nil)))
+
+	table, err := doc.Statement(balanceSheetRole)
+	require.NoError(t, err)
+	assert.Equal(t, balanceSheetRole, table.Role())
+	assert.Equal(t, []string{"2025-12-31", "2024-12-31"}, table.Periods())
+
+	type row struct {
+		local  string
+		depth  int
+		header bool
+		values []string
+	}
+	var got []row
+	for _, r := range table.Rows() {
+		got = append(got, row{r.Concept().Local(), r.Depth(), r.IsHeader(), r.Values()})
+	}
+	assert.Equal(t, []row{
+		{"BalanceSheetAbstract", 0, true, nil},
+		{"AssetsAbstract", 1, true, nil},
+		{"Cash", 2, false, []string{"100", "80"}},
+		{"Receivables", 2, false, []string{"50", ""}},
+		{"Assets", 2, false, []string{"150", "80"}},
+		{"Liabilities", 1, false, []string{"70", ""}},
+	}, got)
+
+	assert.Equal(t, xbrl.RoleTotalLabel, table.Rows()[4].PreferredLabel())
+}
+
+func TestDocument_Statement_Errors(t *testing.T) {
+	t.Parallel()
+
+	files := map[string]string{
+		"ex.xsd":     statementSchema,
+		"ex_pre.xml": statementPresentation,
+	}
+	withTaxonomy, err := xbrl.Parse(strings.NewReader(statementInstance))
+	require.NoError(t, err)
+	_, err = withTaxonomy.LoadTaxonomyFromSchemaRefs(mapOpener(files, nil))
+	require.NoError(t, err)
+	require.NoError(t, withTaxonomy.LoadLinkbases(mapOpener(files, nil)))
+
+	withoutTaxonomy, err := xbrl.Parse(strings.NewReader(statementInstance))
+	require.NoError(t, err)
+
+	var nilDoc *xbrl.Document
+
+	tests := []struct {
+		name    string
+		doc     *xbrl.Document
+		role    string
+		wantErr error
+		wantMsg string
+	}{
+		{name: "nil document", doc: nilDoc, role: balanceSheetRole, wantMsg: "xbrl: document is nil"},
+		{name: "no taxonomy", doc: withoutTaxonomy, role: balanceSheetRole, wantErr: xbrl.ErrNoTaxonomy},
+		{name: "unknown role", doc: withTaxonomy, role: "http://example.com/role/Unknown", wantMsg: "no presentation relationships"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			table, err := tt.doc.Statement(tt.role)
+			assert.Nil(t, table)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.ErrorContains(t, err, tt.wantMsg)
+			}
+		})
+	}
+
+	var nilTable *xbrl.StatementTable
+	assert.Empty(t, nilTable.Role())
+	assert.Nil(t, nilTable.Periods())
+	assert.Nil(t, nilTable.Rows())
+}
@@ -0,0 +1,132 @@
+package xbrl
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Summary is a structured overview of a document.
+type Summary struct {
+	SchemaRefs int
+	Contexts   int
+	Units      int
+	Facts      int
+
+	// Concepts is the number of distinct fact concepts, compared by
+	// namespace URI and local name.
+	Concepts int
+
+	// Entities lists the distinct entity identifiers of the contexts,
+	// sorted by scheme and then by value.
+	Entities []ContextIdentifier
+
+	// PeriodStart is the earliest start date or instant and PeriodEnd the
+	// latest end date or instant across all contexts. Both are zero if no
+	// context has a parseable date; forever periods are ignored.
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+
+	// Currencies lists the currency codes used by facts, as returned by
+	// Document.ReportingCurrencies.
+	Currencies []string
+}
+
+// Summary computes a structured overview of the document.
+// A nil document yields the zero Summary.
+func (d *Document) Summary() Summary {
+	if d == nil {
+		return Summary{}
+	}
+
+	s := Summary{
+		SchemaRefs: len(d.schemaRefs),
+		Contexts:   len(d.contexts),
+		Units:      len(d.units),
+		Facts:      len(d.facts),
+		Currencies: d.ReportingCurrencies(),
+	}
+
+	concepts := make(map[conceptKey]struct{})
+	for _, f := range d.facts {
+		if f != nil {
+			concepts[conceptKey{f.name.uri, f.name.local}] = struct{}{}
+		}
+	}
+	s.Concepts = len(concepts)
+
+	entities := make(map[ContextIdentifier]struct{})
+	for _, c := range d.contexts {
+		if c == nil {
+			continue
+		}
+		entities[c.entity.identifier] = struct{}{}
+	}
+	s.PeriodStart, s.PeriodEnd, _ = d.PeriodSpan()
+	for id := range entities {
+		s.Entities = append(s.Entities, id)
+	}
+	slices.SortFunc(s.Entities, func(a, b ContextIdentifier) int {
+		if c := cmp.Compare(a.scheme, b.scheme); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.value, b.value)
+	})
+
+	return s
+}
+
+// PeriodSpan returns the earliest start date or instant and the latest end
+// date or instant across all contexts, parsed as by Period.InstantTime.
+// Forever periods and unparseable dates are ignored; ok is false if no
+// context has a parseable date.
+func (d *Document) PeriodSpan() (start, end time.Time, ok bool) {
+	if d == nil {
+		return time.Time{}, time.Time{}, false
+	}
+	for _, c := range d.contexts {
+		if c == nil {
+			continue
+		}
+		for _, v := range []*string{c.period.instant, c.period.startDate, c.period.endDate} {
+			t, parsed := periodTime(v)
+			if !parsed {
+				continue
+			}
+			if !ok || t.Before(start) {
+				start = t
+			}
+			if !ok || t.After(end) {
+				end = t
+			}
+			ok = true
+		}
+	}
+	return start, end, ok
+}
+
+// String renders the summary as aligned "name: value" lines for display.
+func (s Summary) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "schemaRefs: %d\n", s.SchemaRefs)
+	fmt.Fprintf(&sb, "contexts  : %d\n", s.Contexts)
+	fmt.Fprintf(&sb, "units     : %d\n", s.Units)
+	fmt.Fprintf(&sb, "facts     : %d\n", s.Facts)
+	fmt.Fprintf(&sb, "concepts  : %d\n", s.Concepts)
+
+	entities := make([]string, len(s.Entities))
+	for i, e := range s.Entities {
+		entities[i] = e.value + " (" + e.scheme + ")"
+	}
+	fmt.Fprintf(&sb, "entities  : %s\n", strings.Join(entities, ", "))
+
+	period := ""
+	if !s.PeriodStart.IsZero() {
+		period = s.PeriodStart.Format(time.DateOnly) + " - " + s.PeriodEnd.Format(time.DateOnly)
+	}
+	fmt.Fprintf(&sb, "period    : %s\n", period)
+	fmt.Fprintf(&sb, "currencies: %s\n", strings.Join(s.Currencies, ", "))
+	return sb.String()
+}
@@ -0,0 +1,156 @@
+package xbrl_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aethiopicuschan/xbrl-go/pkg/xbrl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocument_Summary(t *testing.T) {
+	t.Parallel()
+
+	extended, err := xbrl.Parse(strings.NewReader(extendedInstance))
+	require.NoError(t, err)
+	minimal, err := xbrl.Parse(strings.NewReader(minimalInstance))
+	require.NoError(t, err)
+
+	const scheme = "http://example.com/entity"
+
+	tests := []struct {
+		name string
+		doc  *xbrl.Document
+		want xbrl.Summary
+	}{
+		{
+			name: "nil document",
+			doc:  nil,
+			want: xbrl.Summary{},
+		},
+		{
+			name: "extended fixture",
+			doc:  extended,
+			want: xbrl.Summary{
+				SchemaRefs: 1,
+				Contexts:   2,
+				Units:      3,
+				Facts:      2,
+				Concepts:   2,
+				Entities: []xbrl.ContextIdentifier{
+					xbrl.NewContextIdentifierForTest(scheme, "ABC"),
+					xbrl.NewContextIdentifierForTest(scheme, "XYZ"),
+				},
+				PeriodStart: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				PeriodEnd:   time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC),
+				// iso4217 is bound to a non-standard namespace in the fixture.
+				Currencies: nil,
+			},
+		},
+		{
+			name: "minimal fixture",
+			doc:  minimal,
+			want: xbrl.Summary{
+				SchemaRefs:  1,
+				Contexts:    1,
+				Units:       1,
+				Facts:       1,
+				Concepts:    1,
+				Entities:    []xbrl.ContextIdentifier{xbrl.NewContextIdentifierForTest(scheme, "ABC")},
+				PeriodStart: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				PeriodEnd:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				Currencies:  []string{"JPY"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.doc.Summary())
+		})
+	}
+}
+
+func TestSummary_String(t *testing.T) {
+	t.Parallel()
+
+	doc, err := xbrl.Parse(strings.NewReader(minimalInstance))
+	require.NoError(t, err)
+
+	want := "schemaRefs: 1\n" +
+		"contexts  : 1\n" +
+		"units     : 1\n" +
+		"facts     : 1\n" +
+		"concepts  : 1\n" +
+		"entities  : ABC (http://example.com/entity)\n" +
+		"period    : 2025-01-01 - 2025-01-01\n" +
+		"currencies: JPY\n"
+	assert.Equal(t, want, doc.Summary().String())
+
+	empty := "schemaRefs: 0\n" +
+		"contexts  : 0\n" +
+		"units     : 0\n" +
+		"facts     : 0\n" +
+		"concepts  : 0\n" +
+		"entities  : \n" +
+		"period    : \n" +
+		"currencies: \n"
+	assert.Equal(t, empty, xbrl.Summary{}.String())
+}
+
+func TestDocument_PeriodSpan(t *testing.T) {
+	t.Parallel()
+
+	const src = `<xbrli:xbrl xmlns:xbrli="http://www.xbrl.org/2003/instance">
+  <xbrli:context id="Prior">
+    <xbrli:entity><xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:instant>2024-03-31</xbrli:instant></xbrli:period>
+  </xbrli:context>
+  <xbrli:context id="Current">
+    <xbrli:entity><xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:startDate>2024-04-01</xbrli:startDate><xbrli:endDate>2025-03-31</xbrli:endDate></xbrli:period>
+  </xbrli:context>
+  <xbrli:context id="PriorYear">
+    <xbrli:entity><xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:startDate>2023-04-01</xbrli:startDate><xbrli:endDate>2024-03-31</xbrli:endDate></xbrli:period>
+  </xbrli:context>
+  <xbrli:context id="Forever">
+    <xbrli:entity><xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:forever/></xbrli:period>
+  </xbrli:context>
+  <xbrli:context id="Bad">
+    <xbrli:entity><xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:instant>2099-99-99</xbrli:instant></xbrli:period>
+  </xbrli:context>
+</xbrli:xbrl>`
+
+	doc, err := xbrl.Parse(strings.NewReader(src))
+	require.NoError(t, err)
+
+	start, end, ok := doc.PeriodSpan()
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC), end)
+
+	t.Run("no parseable periods", func(t *testing.T) {
+		t.Parallel()
+
+		const forever = `<xbrli:xbrl xmlns:xbrli="http://www.xbrl.org/2003/instance">
+  <xbrli:context id="Forever">
+    <xbrli:entity><xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:forever/></xbrli:period>
+  </xbrli:context>
+</xbrli:xbrl>`
+		doc, err := xbrl.Parse(strings.NewReader(forever))
+		require.NoError(t, err)
+		_, _, ok := doc.PeriodSpan()
+		assert.False(t, ok)
+
+		var nilDoc *xbrl.Document
+		_, _, ok = nilDoc.PeriodSpan()
+		assert.False(t, ok)
+	})
+}
@@ -18,14 +18,33 @@ func ParseTaxonomyFile(path string) (*Taxonomy, error) {
 	return ParseTaxonomy(f)
 }
 
+// ParseTaxonomyFiles parses several XBRL taxonomy schemas (XSD) from
+// file paths and merges them into one Taxonomy with Merge, in the order
+// given, so that concepts of a later schema overwrite those of an earlier
+// one with the same QName. It fails on the first schema that cannot be
+// opened or parsed.
+func ParseTaxonomyFiles(paths ...string) (*Taxonomy, error) {
+	tax := NewTaxonomy()
+	for _, path := range paths {
+		t, err := ParseTaxonomyFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("xbrl: taxonomy schema %q: %w", path, err)
+		}
+		tax.Merge(t)
+	}
+	return tax, nil
+}
+
 // ParseTaxonomy parses an XBRL taxonomy schema (XSD) from an io.Reader.
 //
 // This function focuses on xs:element declarations and extracts basic
 // concept information such as name, id, substitutionGroup, type,
 // abstract, nillable, periodType, and balance.
 //
-// It is intentionally minimal and does not attempt to parse linkbases
-// (labels, presentation, calculation, etc.).
+// It is intentionally minimal and does not parse linkbases (labels,
+// presentation, calculation, etc.). linkbaseRefs embedded in the schema
+// are recorded and can be loaded with Document.LoadLinkbases or
+// Taxonomy.ParseLinkbase.
 func ParseTaxonomy(r io.Reader) (*Taxonomy, error) {
 	dec := xml.NewDecoder(r)
 
@@ -58,13 +77,22 @@ func ParseTaxonomy(r io.Reader) (*Taxonomy, error) {
 
 			case "element":
 				c := conceptFromElement(t, targetNS, ns)
+				// skip element contents (annotation, etc.), picking up the
+				// base of an anonymous inline type on the way.
+				base, err := skipElementTypeBase(dec, ns)
+				if err != nil {
+					return nil, fmt.Errorf("xbrl: skip element: %w", err)
+				}
 				if c != nil {
+					if c.typeName.local == "" && base.local != "" {
+						c.typeName = base
+						c.anonymousType = true
+					}
 					tax.addConcept(c)
 				}
-				// skip element contents (annotation, etc.)
-				if err := dec.Skip(); err != nil {
-					return nil, fmt.Errorf("xbrl: skip element: %w", err)
-				}
+
+			case "linkbaseRef":
+				tax.linkbaseRefs = append(tax.linkbaseRefs, parseLinkbaseRef(t))
 			}
 
 		case xml.EndElement:
@@ -128,51 +156,11 @@ func conceptFromElement(se xml.StartElement, targetNS string, ns *namespaceStack
 		uri:    targetNS,
 	}
 
-	// substitutionGroup QName (e.g. xbrli:item).
-	var sgQName QName
-	if subst != "" {
-		p := prefixOf(subst)
-		l := localOf(subst)
-		u := ""
-		if ns != nil {
-			if p == "" {
-				u = ns.URIForPrefix("")
-			} else {
-				u = ns.URIForPrefix(p)
-			}
-		}
-		sgQName = QName{
-			prefix: p,
-			local:  l,
-			uri:    u,
-		}
-	}
-
-	// type QName (e.g. xbrli:monetaryItemType).
-	var typeQName QName
-	if typ != "" {
-		p := prefixOf(typ)
-		l := localOf(typ)
-		u := ""
-		if ns != nil {
-			if p == "" {
-				u = ns.URIForPrefix("")
-			} else {
-				u = ns.URIForPrefix(p)
-			}
-		}
-		typeQName = QName{
-			prefix: p,
-			local:  l,
-			uri:    u,
-		}
-	}
-
 	c := &Concept{
 		qname:             cq,
 		id:                id,
-		substitutionGroup: sgQName,
-		typeName:          typeQName,
+		substitutionGroup: resolveSchemaQName(subst, ns), // e.g. xbrli:item
+		typeName:          resolveSchemaQName(typ, ns),   // e.g. xbrli:monetaryItemType
 		abstract:          parseBool(abstractStr),
 		nillable:          parseBool(nillableStr),
 		periodType:        periodType,
@@ -182,8 +170,61 @@ func conceptFromElement(se xml.StartElement, targetNS string, ns *namespaceStack
 	return c
 }
 
+// resolveSchemaQName resolves a prefixed QName attribute value such as
+// "xbrli:item" against the namespaces in scope. It returns the zero QName
+// for an empty value.
+func resolveSchemaQName(v string, ns *namespaceStack) QName {
+	if v == "" {
+		return QName{}
+	}
+	p := prefixOf(v)
+	u := ""
+	if ns != nil {
+		u = ns.URIForPrefix(p)
+	}
+	return QName{
+		prefix: p,
+		local:  localOf(v),
+		uri:    u,
+	}
+}
+
+// skipElementTypeBase consumes the contents of an xs:element up to and
+// including its end tag, keeping ns in sync. It returns the base of the
+// first xs:restriction found, which is the base type of an anonymous
+// inline xs:simpleType or xs:complexType/xs:simpleContent, or the zero
+// QName if there is none.
+func skipElementTypeBase(dec *xml.Decoder, ns *namespaceStack) (QName, error) {
+	var base QName
+	for depth := 0; ; {
+		tok, err := dec.Token()
+		if err != nil {
+			return QName{}, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			ns.Push(t)
+			depth++
+			if t.Name.Space == nsXSD && t.Name.Local == "restriction" && base.local == "" {
+				for _, a := range t.Attr {
+					if a.Name.Space == "" && a.Name.Local == "base" {
+						base = resolveSchemaQName(strings.TrimSpace(a.Value), ns)
+					}
+				}
+			}
+		case xml.EndElement:
+			ns.Pop(t)
+			if depth == 0 {
+				return base, nil
+			}
+			depth--
+		}
+	}
+}
+
 // Merge merges concepts from other into t.
-// Existing concepts with the same QName are overwritten.
+// Existing concepts with the same QName are overwritten. linkbaseRefs,
+// labels, references and relationships of other are appended.
 func (t *Taxonomy) Merge(other *Taxonomy) {
 	if t == nil || other == nil {
 		return
@@ -194,18 +235,27 @@ func (t *Taxonomy) Merge(other *Taxonomy) {
 	for q, c := range other.concepts {
 		t.concepts[q] = c
 	}
-}
+	t.gen++
 
-// parseBool interprets common boolean lexical forms.
-// Only "true" / "1" (case-insensitive) are treated as true.
-func parseBool(s string) bool {
-	if s == "" {
-		return false
+	t.linkbaseRefs = append(t.linkbaseRefs, other.linkbaseRefs...)
+	t.relationships = append(t.relationships, other.relationships...)
+	if len(other.labels) > 0 && t.labels == nil {
+		t.labels = make(map[conceptKey][]Label)
+	}
+	for k, ls := range other.labels {
+		t.labels[k] = append(t.labels[k], ls...)
+	}
+	if len(other.references) > 0 && t.references == nil {
+		t.references = make(map[conceptKey][]Reference)
 	}
-	switch strings.ToLower(s) {
-	case "true", "1":
-		return true
-	default:
-		return false
+	for k, rs := range other.references {
+		t.references[k] = append(t.references[k], rs...)
 	}
 }
+
+// parseBool interprets an xs:boolean attribute value.
+// Invalid lexical forms are treated as false.
+func parseBool(s string) bool {
+	v, _ := parseXSDBool(s)
+	return v
+}
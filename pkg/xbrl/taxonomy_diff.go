@@ -0,0 +1,124 @@
+package xbrl
+
+import (
+	"cmp"
+	"slices"
+)
+
+// TaxonomyDiff describes the differences between two taxonomies.
+//
+// All slices are sorted by concept namespace URI and then by local name.
+type TaxonomyDiff struct {
+	// Added holds concepts that exist only in the new taxonomy.
+	Added []*Concept
+	// Removed holds concepts that exist only in the old taxonomy.
+	Removed []*Concept
+	// Changed holds concepts present in both whose attributes differ.
+	Changed []ConceptChange
+}
+
+// ConceptChange describes a concept whose attributes differ between
+// two taxonomies.
+type ConceptChange struct {
+	Old *Concept
+	New *Concept
+	// Fields lists the names of the changed attributes, using their XSD
+	// attribute names (e.g. "type", "periodType", "balance").
+	Fields []string
+}
+
+// IsEmpty reports whether the diff contains no changes.
+func (d TaxonomyDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// DiffTaxonomies compares two taxonomies and reports added, removed and
+// changed concepts.
+//
+// Concepts are matched by namespace URI and local name; prefixes are
+// ignored. The compared attributes are type, periodType, balance,
+// abstract, nillable and substitutionGroup. A nil taxonomy is treated
+// as empty.
+func DiffTaxonomies(old, new *Taxonomy) TaxonomyDiff {
+	oldConcepts := conceptsByKey(old)
+	newConcepts := conceptsByKey(new)
+
+	var diff TaxonomyDiff
+	for k, nc := range newConcepts {
+		oc, ok := oldConcepts[k]
+		if !ok {
+			diff.Added = append(diff.Added, nc)
+			continue
+		}
+		if fields := changedConceptFields(oc, nc); len(fields) > 0 {
+			diff.Changed = append(diff.Changed, ConceptChange{Old: oc, New: nc, Fields: fields})
+		}
+	}
+	for k, oc := range oldConcepts {
+		if _, ok := newConcepts[k]; !ok {
+			diff.Removed = append(diff.Removed, oc)
+		}
+	}
+
+	slices.SortFunc(diff.Added, compareConcepts)
+	slices.SortFunc(diff.Removed, compareConcepts)
+	slices.SortFunc(diff.Changed, func(a, b ConceptChange) int {
+		return compareConcepts(a.New, b.New)
+	})
+	return diff
+}
+
+type conceptKey struct{ uri, local string }
+
+// conceptsByKey indexes the concepts of t by namespace URI and local name.
+func conceptsByKey(t *Taxonomy) map[conceptKey]*Concept {
+	out := make(map[conceptKey]*Concept)
+	if t == nil {
+		return out
+	}
+	for q, c := range t.concepts {
+		if c == nil {
+			continue
+		}
+		out[conceptKey{q.uri, q.local}] = c
+	}
+	return out
+}
+
+// changedConceptFields returns the names of the attributes that differ
+// between a and b.
+func changedConceptFields(a, b *Concept) []string {
+	var fields []string
+	if !sameExpandedName(a.typeName, b.typeName) {
+		fields = append(fields, "type")
+	}
+	if a.periodType != b.periodType {
+		fields = append(fields, "periodType")
+	}
+	if a.balance != b.balance {
+		fields = append(fields, "balance")
+	}
+	if a.abstract != b.abstract {
+		fields = append(fields, "abstract")
+	}
+	if a.nillable != b.nillable {
+		fields = append(fields, "nillable")
+	}
+	if !sameExpandedName(a.substitutionGroup, b.substitutionGroup) {
+		fields = append(fields, "substitutionGroup")
+	}
+	return fields
+}
+
+// sameExpandedName reports whether a and b have the same namespace URI
+// and local name.
+func sameExpandedName(a, b QName) bool {
+	return a.uri == b.uri && a.local == b.local
+}
+
+func compareConcepts(a, b *Concept) int {
+	if c := cmp.Compare(a.qname.uri, b.qname.uri); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.qname.local, b.qname.local)
+}
@@ -0,0 +1,93 @@
+package xbrl_test
+
+import (
+	"testing"
+
+	"github.com/aethiopicuschan/xbrl-go/pkg/xbrl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffTaxonomies(t *testing.T) {
+	t.Parallel()
+
+	const uri = "http://example.com"
+	qRev := xbrl.NewQNameForTest("ex", "Revenue", uri)
+	qCost := xbrl.NewQNameForTest("ex", "Cost", uri)
+	qAssets := xbrl.NewQNameForTest("ex", "Assets", uri)
+	item := xbrl.NewQNameForTest("xbrli", "item", "http://www.xbrl.org/2003/instance")
+	monetary := xbrl.NewQNameForTest("xbrli", "monetaryItemType", "http://www.xbrl.org/2003/instance")
+	str := xbrl.NewQNameForTest("xbrli", "stringItemType", "http://www.xbrl.org/2003/instance")
+
+	oldRev := xbrl.NewConceptForTest(qRev, "", item, monetary, false, false, "duration", "credit")
+	oldCost := xbrl.NewConceptForTest(qCost, "", item, monetary, false, false, "duration", "debit")
+	old := xbrl.NewTaxonomyForTest(map[xbrl.QName]*xbrl.Concept{
+		qRev:  oldRev,
+		qCost: oldCost,
+	})
+
+	// Revenue differs only by prefix and is therefore unchanged.
+	newRevName := xbrl.NewQNameForTest("ex2", "Revenue", uri)
+	newRev := xbrl.NewConceptForTest(newRevName, "", item, monetary, false, false, "duration", "credit")
+	newCost := xbrl.NewConceptForTest(qCost, "", item, str, false, true, "instant", "debit")
+	newAssets := xbrl.NewConceptForTest(qAssets, "", item, monetary, false, false, "instant", "debit")
+	updated := xbrl.NewTaxonomyForTest(map[xbrl.QName]*xbrl.Concept{
+		newRevName: newRev,
+		qCost:      newCost,
+		qAssets:    newAssets,
+	})
+
+	tests := []struct {
+		name string
+		old  *xbrl.Taxonomy
+		new  *xbrl.Taxonomy
+		want xbrl.TaxonomyDiff
+	}{
+		{
+			name: "nil taxonomies",
+			want: xbrl.TaxonomyDiff{},
+		},
+		{
+			name: "identical taxonomies",
+			old:  old,
+			new:  old,
+			want: xbrl.TaxonomyDiff{},
+		},
+		{
+			name: "added and modified concepts",
+			old:  old,
+			new:  updated,
+			want: xbrl.TaxonomyDiff{
+				Added: []*xbrl.Concept{newAssets},
+				Changed: []xbrl.ConceptChange{
+					{Old: oldCost, New: newCost, Fields: []string{"type", "periodType", "nillable"}},
+				},
+			},
+		},
+		{
+			name: "reversed comparison reports removals",
+			old:  updated,
+			new:  old,
+			want: xbrl.TaxonomyDiff{
+				Removed: []*xbrl.Concept{newAssets},
+				Changed: []xbrl.ConceptChange{
+					{Old: newCost, New: oldCost, Fields: []string{"type", "periodType", "nillable"}},
+				},
+			},
+		},
+		{
+			name: "everything added to nil",
+			new:  old,
+			want: xbrl.TaxonomyDiff{Added: []*xbrl.Concept{oldCost, oldRev}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := xbrl.DiffTaxonomies(tt.old, tt.new)
+			require.Equal(t, tt.want, got)
+			assert.Equal(t, len(tt.want.Added)+len(tt.want.Removed)+len(tt.want.Changed) == 0, got.IsEmpty())
+		})
+	}
+}
@@ -220,6 +220,73 @@ func TestParseTaxonomy_MultipleElements(t *testing.T) {
 	assert.Len(t, concepts, 2)
 }
 
+// TestParseTaxonomy_AnonymousInlineType verifies that the restriction base
+// of an inline anonymous type becomes the concept's effective type.
+func TestParseTaxonomy_AnonymousInlineType(t *testing.T) {
+	t.Parallel()
+
+	const targetNS = "http://example.com/inline"
+
+	xml := `<?xml version="1.0" encoding="UTF-8"?>
+<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema"
+           xmlns:xbrli="http://www.xbrl.org/2003/instance"
+           targetNamespace="` + targetNS + `"
+           xmlns="` + targetNS + `">
+  <xs:element name="Amount" substitutionGroup="xbrli:item" periodType="instant">
+    <xs:complexType>
+      <xs:simpleContent>
+        <xs:restriction base="xbrli:monetaryItemType"/>
+      </xs:simpleContent>
+    </xs:complexType>
+  </xs:element>
+  <xs:element name="Flag" substitutionGroup="xbrli:item" periodType="instant">
+    <xs:simpleType xmlns:xbrli="http://example.com/shadow">
+      <xs:restriction base="xs:boolean"/>
+    </xs:simpleType>
+  </xs:element>
+  <xs:element name="Named" type="xbrli:sharesItemType" substitutionGroup="xbrli:item" periodType="instant"/>
+</xs:schema>`
+
+	tax, err := xbrl.ParseTaxonomy(strings.NewReader(xml))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	tests := []struct {
+		local     string
+		wantType  string
+		wantLocal string
+		wantURI   string
+		wantKind  xbrl.ConceptValueKind
+	}{
+		{"Amount", "", "monetaryItemType", "http://www.xbrl.org/2003/instance", xbrl.ConceptValueMonetary},
+		{"Flag", "", "boolean", "http://www.w3.org/2001/XMLSchema", xbrl.ConceptValueBoolean},
+		{"Named", "xbrli:sharesItemType", "sharesItemType", "http://www.xbrl.org/2003/instance", xbrl.ConceptValueNumeric},
+	}
+	for _, tt := range tests {
+		t.Run(tt.local, func(t *testing.T) {
+			t.Parallel()
+			c, ok := tax.Concept(xbrl.NewQNameForTest("", tt.local, targetNS))
+			if !assert.True(t, ok) {
+				return
+			}
+			assert.Equal(t, tt.wantType, c.TypeName())
+			assert.Equal(t, tt.wantLocal, c.EffectiveType().Local())
+			assert.Equal(t, tt.wantURI, c.EffectiveType().URI())
+			assert.Equal(t, tt.wantKind, c.ValueKind())
+			if tt.wantType == "" {
+				assert.Equal(t, xbrl.QName{}, c.Type())
+			} else {
+				assert.Equal(t, c.EffectiveType(), c.Type())
+			}
+		})
+	}
+
+	// The sibling after Flag must not see the namespace Flag redeclared.
+	named, _ := tax.Concept(xbrl.NewQNameForTest("", "Named", targetNS))
+	assert.Equal(t, "http://www.xbrl.org/2003/instance", named.SubstitutionGroup().URI())
+}
+
 // TestParseTaxonomyFile_SuccessAndOpenError covers ParseTaxonomyFile for
 // both successful and error cases.
 func TestParseTaxonomyFile_SuccessAndOpenError(t *testing.T) {
@@ -362,3 +429,50 @@ func TestTaxonomy_Merge(t *testing.T) {
 		assert.Nil(t, nilTax)
 	})
 }
+
+// TestParseTaxonomyFiles verifies that schemas with different target
+// namespaces are merged into one taxonomy.
+func TestParseTaxonomyFiles(t *testing.T) {
+	t.Parallel()
+
+	schema := func(targetNS, name string) string {
+		return `<?xml version="1.0" encoding="UTF-8"?>
+<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema"
+           xmlns:link="http://www.xbrl.org/2003/linkbase"
+           xmlns:xlink="http://www.w3.org/1999/xlink"
+           targetNamespace="` + targetNS + `"
+           xmlns="` + targetNS + `">
+  <xs:annotation><xs:appinfo>
+    <link:linkbaseRef xlink:type="simple" xlink:href="` + name + `-lab.xml"/>
+  </xs:appinfo></xs:annotation>
+  <xs:element name="` + name + `"/>
+</xs:schema>`
+	}
+
+	dir := t.TempDir()
+	base := filepath.Join(dir, "base.xsd")
+	ext := filepath.Join(dir, "ext.xsd")
+	assert.NoError(t, os.WriteFile(base, []byte(schema("http://example.com/base", "Base")), 0o644))
+	assert.NoError(t, os.WriteFile(ext, []byte(schema("http://example.com/ext", "Ext")), 0o644))
+
+	tax, err := xbrl.ParseTaxonomyFiles(base, ext)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Len(t, tax.Concepts(), 2)
+	_, ok := tax.Concept(xbrl.NewQNameForTest("", "Base", "http://example.com/base"))
+	assert.True(t, ok)
+	_, ok = tax.Concept(xbrl.NewQNameForTest("", "Ext", "http://example.com/ext"))
+	assert.True(t, ok)
+	assert.Len(t, tax.LinkbaseRefs(), 2)
+
+	empty, err := xbrl.ParseTaxonomyFiles()
+	assert.NoError(t, err)
+	assert.Empty(t, empty.Concepts())
+
+	missing := filepath.Join(dir, "missing.xsd")
+	tax, err = xbrl.ParseTaxonomyFiles(base, missing)
+	assert.Nil(t, tax)
+	assert.ErrorContains(t, err, `xbrl: taxonomy schema "`+missing+`"`)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
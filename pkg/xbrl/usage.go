@@ -0,0 +1,85 @@
+package xbrl
+
+import (
+	"cmp"
+	"slices"
+)
+
+// ConceptUsage returns how many facts reference each concept QName.
+//
+// Keys are the fact names as they appear in the instance document.
+func (d *Document) ConceptUsage() map[QName]int {
+	if d == nil {
+		return nil
+	}
+	out := make(map[QName]int)
+	for _, f := range d.facts {
+		if f == nil {
+			continue
+		}
+		out[f.name]++
+	}
+	return out
+}
+
+// UnusedConcepts returns the taxonomy concepts that are not referenced
+// by any fact in the document.
+//
+// Concepts are compared by namespace URI and local name; prefixes are
+// ignored. The result is sorted by URI and then by local name.
+// If no taxonomy is attached, it returns nil.
+func (d *Document) UnusedConcepts() []QName {
+	if d == nil || d.taxonomy == nil {
+		return nil
+	}
+
+	type key struct{ uri, local string }
+	used := make(map[key]struct{}, len(d.facts))
+	for _, f := range d.facts {
+		if f == nil {
+			continue
+		}
+		used[key{f.name.uri, f.name.local}] = struct{}{}
+	}
+
+	var out []QName
+	for q := range d.taxonomy.concepts {
+		if _, ok := used[key{q.uri, q.local}]; ok {
+			continue
+		}
+		out = append(out, q)
+	}
+	slices.SortFunc(out, func(a, b QName) int {
+		if c := cmp.Compare(a.uri, b.uri); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.local, b.local)
+	})
+	return out
+}
+
+// RequireConcepts returns the concepts in required that no fact of the
+// document references, in the order of required. It returns nil if all
+// of them are present.
+//
+// Concepts are compared by namespace URI and local name; prefixes are
+// ignored. A nil document has no facts, so every required concept is
+// reported missing.
+func (d *Document) RequireConcepts(required []QName) []QName {
+	present := make(map[conceptKey]struct{})
+	if d != nil {
+		for _, f := range d.facts {
+			if f != nil {
+				present[conceptKey{f.name.uri, f.name.local}] = struct{}{}
+			}
+		}
+	}
+
+	var missing []QName
+	for _, q := range required {
+		if _, ok := present[conceptKey{q.uri, q.local}]; !ok {
+			missing = append(missing, q)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,136 @@
+package xbrl_test
+
+import (
+	"testing"
+
+	"github.com/aethiopicuschan/xbrl-go/pkg/xbrl"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocument_ConceptUsage(t *testing.T) {
+	t.Parallel()
+
+	qRev := xbrl.NewQNameForTest("ex", "Revenue", "http://example.com")
+	qCost := xbrl.NewQNameForTest("ex", "Cost", "http://example.com")
+
+	doc := xbrl.NewDocumentForTest(
+		nil,
+		nil,
+		nil,
+		[]*xbrl.Fact{
+			xbrl.NewFactForTest(xbrl.FactKindItem, qRev, "1", "C1", "", "", "", "", "", false),
+			xbrl.NewFactForTest(xbrl.FactKindItem, qRev, "2", "C2", "", "", "", "", "", false),
+			nil,
+			xbrl.NewFactForTest(xbrl.FactKindItem, qCost, "3", "C1", "", "", "", "", "", false),
+		},
+		nil,
+	)
+
+	tests := []struct {
+		name string
+		doc  *xbrl.Document
+		want map[xbrl.QName]int
+	}{
+		{
+			name: "nil document",
+			doc:  nil,
+			want: nil,
+		},
+		{
+			name: "repeated concept is counted",
+			doc:  doc,
+			want: map[xbrl.QName]int{qRev: 2, qCost: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.doc.ConceptUsage())
+		})
+	}
+}
+
+func TestDocument_UnusedConcepts(t *testing.T) {
+	t.Parallel()
+
+	qRev := xbrl.NewQNameForTest("ex", "Revenue", "http://example.com")
+	qCost := xbrl.NewQNameForTest("ex", "Cost", "http://example.com")
+	qAssets := xbrl.NewQNameForTest("ex", "Assets", "http://example.com")
+
+	tax := xbrl.NewTaxonomyForTest(map[xbrl.QName]*xbrl.Concept{
+		qRev:    xbrl.NewConceptForTest(qRev, "", xbrl.QName{}, xbrl.QName{}, false, false, "", ""),
+		qCost:   xbrl.NewConceptForTest(qCost, "", xbrl.QName{}, xbrl.QName{}, false, false, "", ""),
+		qAssets: xbrl.NewConceptForTest(qAssets, "", xbrl.QName{}, xbrl.QName{}, false, false, "", ""),
+	})
+
+	// The fact uses a different prefix; only URI+local should matter.
+	factName := xbrl.NewQNameForTest("other", "Revenue", "http://example.com")
+	facts := []*xbrl.Fact{
+		xbrl.NewFactForTest(xbrl.FactKindItem, factName, "1", "C1", "", "", "", "", "", false),
+	}
+
+	tests := []struct {
+		name string
+		doc  *xbrl.Document
+		want []xbrl.QName
+	}{
+		{
+			name: "nil document",
+			doc:  nil,
+			want: nil,
+		},
+		{
+			name: "no taxonomy attached",
+			doc:  xbrl.NewDocumentForTest(nil, nil, nil, facts, nil),
+			want: nil,
+		},
+		{
+			name: "unreferenced concepts are listed in sorted order",
+			doc:  xbrl.NewDocumentForTest(nil, nil, nil, facts, tax),
+			want: []xbrl.QName{qAssets, qCost},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.doc.UnusedConcepts())
+		})
+	}
+}
+
+func TestDocument_RequireConcepts(t *testing.T) {
+	t.Parallel()
+
+	qRev := xbrl.NewQNameForTest("ex", "Revenue", "http://example.com")
+	qCost := xbrl.NewQNameForTest("ex", "Cost", "http://example.com")
+	qOtherNS := xbrl.NewQNameForTest("ex", "Revenue", "http://example.org")
+
+	// The fact uses a different prefix; only URI+local should matter.
+	facts := []*xbrl.Fact{
+		xbrl.NewFactForTest(xbrl.FactKindItem, xbrl.NewQNameForTest("other", "Revenue", "http://example.com"), "1", "C1", "", "", "", "", "", false),
+		nil,
+	}
+	doc := xbrl.NewDocumentForTest(nil, nil, nil, facts, nil)
+
+	tests := []struct {
+		name     string
+		doc      *xbrl.Document
+		required []xbrl.QName
+		want     []xbrl.QName
+	}{
+		{name: "one missing", doc: doc, required: []xbrl.QName{qRev, qCost}, want: []xbrl.QName{qCost}},
+		{name: "all present", doc: doc, required: []xbrl.QName{qRev}, want: nil},
+		{name: "namespace must match", doc: doc, required: []xbrl.QName{qOtherNS}, want: []xbrl.QName{qOtherNS}},
+		{name: "nothing required", doc: doc, required: nil, want: nil},
+		{name: "nil document", doc: nil, required: []xbrl.QName{qRev, qCost}, want: []xbrl.QName{qRev, qCost}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.doc.RequireConcepts(tt.required))
+		})
+	}
+}
@@ -0,0 +1,314 @@
+package xbrl
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Validation errors.
+var (
+	ErrInvalidUnit       = errors.New("xbrl: invalid unit")
+	ErrInvalidPeriodDate = errors.New("xbrl: invalid period date")
+	ErrInvalidContext    = errors.New("xbrl: invalid context")
+	ErrInvalidTaxonomy   = errors.New("xbrl: invalid taxonomy")
+)
+
+// Validate checks the structure of the unit.
+//
+// A simple unit must have at least one measure, and a divide unit must
+// have both numerator and denominator measures. Errors wrap ErrInvalidUnit.
+func (u *Unit) Validate() error {
+	if u == nil {
+		return fmt.Errorf("xbrl: unit is nil")
+	}
+	if u.divide {
+		if len(u.numerator) == 0 {
+			return fmt.Errorf("%w: unit %q: divide unit has no numerator measures", ErrInvalidUnit, u.id)
+		}
+		if len(u.denominator) == 0 {
+			return fmt.Errorf("%w: unit %q: divide unit has no denominator measures", ErrInvalidUnit, u.id)
+		}
+		return nil
+	}
+	if len(u.measures) == 0 {
+		return fmt.Errorf("%w: unit %q: simple unit has no measures", ErrInvalidUnit, u.id)
+	}
+	return nil
+}
+
+// ValidateAllUnits validates every unit in the document and returns the
+// errors found, ordered by unit ID. It returns nil if all units are valid.
+func (d *Document) ValidateAllUnits() []error {
+	if d == nil {
+		return nil
+	}
+	var errs []error
+	for _, id := range slices.Sorted(maps.Keys(d.units)) {
+		if err := d.units[id].Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// ValidateStructure checks the context against the structural rules of
+// XBRL 2.1 and returns every violation found, or nil if there is none.
+//
+// The period must be exactly one of an instant, a duration with both
+// startDate and endDate, or forever. The entity identifier must have a
+// non-empty scheme and value, and every explicit member must name both
+// its dimension and its member. Errors wrap ErrInvalidContext.
+func (c *Context) ValidateStructure() []error {
+	if c == nil {
+		return []error{fmt.Errorf("%w: context is nil", ErrInvalidContext)}
+	}
+
+	var errs []error
+	fail := func(msg string) {
+		errs = append(errs, fmt.Errorf("%w: context %q: %s", ErrInvalidContext, c.id, msg))
+	}
+
+	p := c.period
+	kinds := 0
+	if p.instant != nil {
+		kinds++
+	}
+	if p.startDate != nil || p.endDate != nil {
+		kinds++
+		if p.startDate == nil || p.endDate == nil {
+			fail("duration period requires both startDate and endDate")
+		}
+	}
+	if p.forever {
+		kinds++
+	}
+	switch {
+	case kinds == 0:
+		fail("period has no instant, duration or forever")
+	case kinds > 1:
+		fail("period mixes instant, duration and forever")
+	}
+
+	id := c.entity.identifier
+	if id.scheme == "" {
+		fail("entity identifier has no scheme")
+	}
+	if id.value == "" {
+		fail("entity identifier has no value")
+	}
+
+	for _, d := range c.dimensions {
+		if !d.explicit {
+			continue
+		}
+		if d.dimension.local == "" {
+			fail(fmt.Sprintf("explicit member %q has no dimension", d.member.local))
+		}
+		if d.member.local == "" {
+			fail(fmt.Sprintf("explicit member of dimension %q has no member", d.dimension.local))
+		}
+	}
+	return errs
+}
+
+// ValidateContexts validates the structure of every context in the
+// document and returns the errors found, ordered by context ID. It
+// returns nil if all contexts are valid.
+func (d *Document) ValidateContexts() []error {
+	if d == nil {
+		return nil
+	}
+	var errs []error
+	for _, id := range slices.Sorted(maps.Keys(d.contexts)) {
+		errs = append(errs, d.contexts[id].ValidateStructure()...)
+	}
+	return errs
+}
+
+// Namespaces whose elements are substitution group heads defined by the
+// XBRL specifications rather than by taxonomies, such as xbrli:item,
+// xbrli:tuple, xbrldt:hypercubeItem and link:part.
+var specSubstitutionNamespaces = map[string]bool{
+	nsXBRLI:                       true,
+	nsLink:                        true,
+	"http://xbrl.org/2005/xbrldt": true,
+}
+
+// Validate checks the concepts of the taxonomy for problems that usually
+// indicate an authoring mistake, and returns every one found ordered by
+// concept, or nil if there is none.
+//
+// Concept @id values must be unique, and every substitutionGroup must
+// resolve to a concept of the taxonomy or to an element of the XBRL
+// specifications (e.g. xbrli:item). Errors wrap ErrInvalidTaxonomy.
+func (t *Taxonomy) Validate() []error {
+	if t == nil {
+		return nil
+	}
+
+	var errs []error
+	fail := func(c *Concept, format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%w: concept %s: %s", ErrInvalidTaxonomy,
+			c.qname.String(), fmt.Sprintf(format, args...)))
+	}
+
+	keys := conceptsByKey(t)
+	concepts := slices.SortedFunc(maps.Values(keys), compareConcepts)
+	ids := make(map[string]*Concept)
+	for _, c := range concepts {
+		if c.id != "" {
+			if first, ok := ids[c.id]; ok {
+				fail(c, "id %q is also used by %s", c.id, first.qname.String())
+			} else {
+				ids[c.id] = c
+			}
+		}
+
+		sg := c.substitutionGroup
+		switch {
+		case sg.local == "":
+		case sg.uri == "":
+			name := sg.local
+			if sg.prefix != "" {
+				name = sg.prefix + ":" + name
+			}
+			fail(c, "substitutionGroup %q has no namespace", name)
+		case specSubstitutionNamespaces[sg.uri]:
+		case keys[conceptKey{sg.uri, sg.local}] == nil:
+			fail(c, "substitutionGroup %s is not a concept of the taxonomy", sg.String())
+		}
+	}
+	return errs
+}
+
+// FactsMissingUnit returns the numeric and monetary facts without a
+// unitRef, in document order. XBRL requires a unit on every numeric fact,
+// including xsi:nil ones.
+//
+// Whether a fact is numeric is decided by the ValueKind of its concept, so
+// a taxonomy must be attached; facts whose concept is not found are not
+// reported. It returns nil if no taxonomy is attached.
+func (d *Document) FactsMissingUnit() []*Fact {
+	if d == nil || d.taxonomy == nil {
+		return nil
+	}
+	var out []*Fact
+	for _, f := range d.facts {
+		if f == nil || f.unitRef != "" {
+			continue
+		}
+		c, ok := d.ConceptOf(f)
+		if !ok {
+			continue
+		}
+		switch c.ValueKind() {
+		case ConceptValueNumeric, ConceptValueMonetary:
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// ValueIssue describes a fact whose value is not lexically valid for the
+// type of its concept.
+type ValueIssue struct {
+	Fact   *Fact
+	Name   QName
+	Reason string
+}
+
+// ValidateFactValues checks that the value of every non-nil fact is a valid
+// lexical form for its concept's ValueKind: numeric and monetary values
+// must parse as numbers, boolean values must be one of the xsd:boolean
+// tokens "true", "false", "1" or "0" (case-sensitive, unlike AsBool), and
+// date and dateTime values must parse as such. Other kinds are not
+// checked.
+//
+// Issues are returned in document order. Facts whose concept is not found
+// are skipped, and nil is returned if no taxonomy is attached.
+func (d *Document) ValidateFactValues() []ValueIssue {
+	if d == nil || d.taxonomy == nil {
+		return nil
+	}
+	var out []ValueIssue
+	for _, f := range d.facts {
+		if f == nil || f.nil {
+			continue
+		}
+		c, ok := d.ConceptOf(f)
+		if !ok {
+			continue
+		}
+		var err error
+		switch c.ValueKind() {
+		case ConceptValueNumeric, ConceptValueMonetary:
+			_, err = d.AsFloat64(f)
+		case ConceptValueBoolean:
+			switch strings.TrimSpace(f.value) {
+			case "true", "false", "1", "0":
+			default:
+				err = ErrInvalidValue
+			}
+		case ConceptValueDate, ConceptValueDateTime:
+			_, err = d.AsTime(f, nil)
+		default:
+			continue
+		}
+		if err != nil {
+			out = append(out, ValueIssue{
+				Fact:   f,
+				Name:   f.name,
+				Reason: fmt.Sprintf("invalid %s value %q", c.ValueKind(), f.value),
+			})
+		}
+	}
+	return out
+}
+
+// Lexical forms accepted for xsd:date and xsd:dateTime period values.
+// Fractional seconds are accepted by time.Parse without an explicit layout.
+var xsdDateLayouts = []string{
+	"2006-01-02",
+	"2006-01-02Z07:00",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04:05Z07:00",
+}
+
+// isXSDDate reports whether s is a valid xsd:date or xsd:dateTime.
+func isXSDDate(s string) bool {
+	_, ok := parseXSDDate(s)
+	return ok
+}
+
+// parseXSDDate parses s as an xsd:date or xsd:dateTime. Values without
+// a time zone are interpreted as UTC.
+func parseXSDDate(s string) (time.Time, bool) {
+	for _, layout := range xsdDateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// validateDates checks that all dates present in the period are valid
+// xsd:date or xsd:dateTime values. Errors wrap ErrInvalidPeriodDate.
+func (p Period) validateDates(contextID string) error {
+	for _, v := range []struct {
+		name string
+		val  *string
+	}{
+		{"instant", p.instant},
+		{"startDate", p.startDate},
+		{"endDate", p.endDate},
+	} {
+		if v.val != nil && !isXSDDate(*v.val) {
+			return fmt.Errorf("%w: context %q: %s %q", ErrInvalidPeriodDate, contextID, v.name, *v.val)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,404 @@
+package xbrl_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aethiopicuschan/xbrl-go/pkg/xbrl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnit_Validate(t *testing.T) {
+	t.Parallel()
+
+	jpy := xbrl.NewQNameForTest("iso4217", "JPY", "http://www.xbrl.org/2003/iso4217")
+	shares := xbrl.NewQNameForTest("xbrli", "shares", "http://www.xbrl.org/2003/instance")
+
+	tests := []struct {
+		name    string
+		unit    *xbrl.Unit
+		wantErr string
+	}{
+		{
+			name:    "nil unit",
+			unit:    nil,
+			wantErr: "xbrl: unit is nil",
+		},
+		{
+			name: "simple unit",
+			unit: xbrl.NewUnitSimpleForTest("JPY", []xbrl.QName{jpy}),
+		},
+		{
+			name:    "simple unit without measures",
+			unit:    xbrl.NewUnitSimpleForTest("Empty", nil),
+			wantErr: `xbrl: invalid unit: unit "Empty": simple unit has no measures`,
+		},
+		{
+			name: "divide unit",
+			unit: xbrl.NewUnitDivideForTest("JPYPerShare", []xbrl.QName{jpy}, []xbrl.QName{shares}),
+		},
+		{
+			name:    "divide unit without numerator",
+			unit:    xbrl.NewUnitDivideForTest("NoNum", nil, []xbrl.QName{shares}),
+			wantErr: `xbrl: invalid unit: unit "NoNum": divide unit has no numerator measures`,
+		},
+		{
+			name:    "divide unit without denominator",
+			unit:    xbrl.NewUnitDivideForTest("NoDen", []xbrl.QName{jpy}, nil),
+			wantErr: `xbrl: invalid unit: unit "NoDen": divide unit has no denominator measures`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.unit.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+			if tt.unit != nil {
+				assert.ErrorIs(t, err, xbrl.ErrInvalidUnit)
+			}
+		})
+	}
+}
+
+func TestDocument_ValidateAllUnits(t *testing.T) {
+	t.Parallel()
+
+	jpy := xbrl.NewQNameForTest("iso4217", "JPY", "http://www.xbrl.org/2003/iso4217")
+	shares := xbrl.NewQNameForTest("xbrli", "shares", "http://www.xbrl.org/2003/instance")
+
+	valid := map[string]*xbrl.Unit{
+		"JPY":         xbrl.NewUnitSimpleForTest("JPY", []xbrl.QName{jpy}),
+		"JPYPerShare": xbrl.NewUnitDivideForTest("JPYPerShare", []xbrl.QName{jpy}, []xbrl.QName{shares}),
+	}
+	invalid := map[string]*xbrl.Unit{
+		"JPY":   xbrl.NewUnitSimpleForTest("JPY", []xbrl.QName{jpy}),
+		"NoDen": xbrl.NewUnitDivideForTest("NoDen", []xbrl.QName{jpy}, nil),
+		"Empty": xbrl.NewUnitSimpleForTest("Empty", nil),
+	}
+
+	tests := []struct {
+		name string
+		doc  *xbrl.Document
+		want []string
+	}{
+		{"nil document", nil, nil},
+		{"all valid", xbrl.NewDocumentForTest(nil, nil, valid, nil, nil), nil},
+		{
+			name: "invalid units are reported in ID order",
+			doc:  xbrl.NewDocumentForTest(nil, nil, invalid, nil, nil),
+			want: []string{
+				`xbrl: invalid unit: unit "Empty": simple unit has no measures`,
+				`xbrl: invalid unit: unit "NoDen": divide unit has no denominator measures`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			errs := tt.doc.ValidateAllUnits()
+			if tt.want == nil {
+				assert.Nil(t, errs)
+				return
+			}
+			var got []string
+			for _, err := range errs {
+				got = append(got, err.Error())
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestContext_ValidateStructure(t *testing.T) {
+	t.Parallel()
+
+	date := func(s string) *string { return &s }
+	entity := xbrl.NewEntityForTest(xbrl.NewContextIdentifierForTest("http://example.com/entity", "ABC"))
+	instant := xbrl.NewPeriodForTest(date("2025-03-31"), nil, nil, false)
+	region := xbrl.NewQNameForTest("ex", "Region", "http://example.com")
+	japan := xbrl.NewQNameForTest("ex", "Japan", "http://example.com")
+
+	tests := []struct {
+		name string
+		ctx  *xbrl.Context
+		want []string
+	}{
+		{
+			name: "nil context",
+			ctx:  nil,
+			want: []string{"xbrl: invalid context: context is nil"},
+		},
+		{
+			name: "valid instant",
+			ctx:  xbrl.NewContextForTest("C", entity, instant, nil),
+		},
+		{
+			name: "valid duration with explicit member",
+			ctx: xbrl.NewContextForTest("C", entity,
+				xbrl.NewPeriodForTest(nil, date("2025-01-01"), date("2025-12-31"), false),
+				[]xbrl.Dimension{xbrl.NewDimensionForTest(region, true, japan, "")}),
+		},
+		{
+			name: "valid forever",
+			ctx:  xbrl.NewContextForTest("C", entity, xbrl.NewPeriodForTest(nil, nil, nil, true), nil),
+		},
+		{
+			name: "instant mixed with startDate",
+			ctx: xbrl.NewContextForTest("C", entity,
+				xbrl.NewPeriodForTest(date("2025-03-31"), date("2025-01-01"), nil, false), nil),
+			want: []string{
+				`xbrl: invalid context: context "C": duration period requires both startDate and endDate`,
+				`xbrl: invalid context: context "C": period mixes instant, duration and forever`,
+			},
+		},
+		{
+			name: "instant mixed with forever",
+			ctx:  xbrl.NewContextForTest("C", entity, xbrl.NewPeriodForTest(date("2025-03-31"), nil, nil, true), nil),
+			want: []string{`xbrl: invalid context: context "C": period mixes instant, duration and forever`},
+		},
+		{
+			name: "missing period",
+			ctx:  xbrl.NewContextForTest("C", entity, xbrl.Period{}, nil),
+			want: []string{`xbrl: invalid context: context "C": period has no instant, duration or forever`},
+		},
+		{
+			name: "missing scheme and value",
+			ctx:  xbrl.NewContextForTest("C", xbrl.Entity{}, instant, nil),
+			want: []string{
+				`xbrl: invalid context: context "C": entity identifier has no scheme`,
+				`xbrl: invalid context: context "C": entity identifier has no value`,
+			},
+		},
+		{
+			name: "explicit member without dimension",
+			ctx: xbrl.NewContextForTest("C", entity, instant,
+				[]xbrl.Dimension{xbrl.NewDimensionForTest(xbrl.QName{}, true, japan, "")}),
+			want: []string{`xbrl: invalid context: context "C": explicit member "Japan" has no dimension`},
+		},
+		{
+			name: "explicit member without member",
+			ctx: xbrl.NewContextForTest("C", entity, instant,
+				[]xbrl.Dimension{xbrl.NewDimensionForTest(region, true, xbrl.QName{}, "")}),
+			want: []string{`xbrl: invalid context: context "C": explicit member of dimension "Region" has no member`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			errs := tt.ctx.ValidateStructure()
+			if tt.want == nil {
+				assert.Nil(t, errs)
+				return
+			}
+			var got []string
+			for _, err := range errs {
+				assert.ErrorIs(t, err, xbrl.ErrInvalidContext)
+				got = append(got, err.Error())
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDocument_ValidateContexts(t *testing.T) {
+	t.Parallel()
+
+	valid, err := xbrl.Parse(strings.NewReader(extendedInstance))
+	require.NoError(t, err)
+
+	// C2 mixes an instant with a startDate; C1 has an empty scheme.
+	const mixed = `<xbrli:xbrl xmlns:xbrli="http://www.xbrl.org/2003/instance">
+  <xbrli:context id="C2">
+    <xbrli:entity><xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier></xbrli:entity>
+    <xbrli:period>
+      <xbrli:instant>2025-03-31</xbrli:instant>
+      <xbrli:startDate>2025-01-01</xbrli:startDate>
+      <xbrli:endDate>2025-03-31</xbrli:endDate>
+    </xbrli:period>
+  </xbrli:context>
+  <xbrli:context id="C1">
+    <xbrli:entity><xbrli:identifier scheme="">ABC</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:instant>2025-03-31</xbrli:instant></xbrli:period>
+  </xbrli:context>
+</xbrli:xbrl>`
+	invalid, err := xbrl.Parse(strings.NewReader(mixed))
+	require.NoError(t, err, "the parser tolerates malformed periods")
+
+	var nilDoc *xbrl.Document
+	assert.Nil(t, nilDoc.ValidateContexts())
+	assert.Nil(t, valid.ValidateContexts())
+
+	var got []string
+	for _, err := range invalid.ValidateContexts() {
+		got = append(got, err.Error())
+	}
+	assert.Equal(t, []string{
+		`xbrl: invalid context: context "C1": entity identifier has no scheme`,
+		`xbrl: invalid context: context "C2": period mixes instant, duration and forever`,
+	}, got)
+}
+
+func TestDocument_FactsMissingUnit(t *testing.T) {
+	t.Parallel()
+
+	const ns = "http://example.com/xbrl"
+	xbrli := "http://www.xbrl.org/2003/instance"
+	concept := func(local, typ string) (xbrl.QName, *xbrl.Concept) {
+		q := xbrl.NewQNameForTest("ex", local, ns)
+		return q, xbrl.NewConceptForTest(q, "ex_"+local, xbrl.QName{},
+			xbrl.NewQNameForTest("xbrli", typ, xbrli), false, false, "instant", "")
+	}
+	revenue, revenueC := concept("Revenue", "monetaryItemType")
+	ratio, ratioC := concept("Ratio", "pureItemType")
+	name, nameC := concept("CompanyName", "stringItemType")
+	tax := xbrl.NewTaxonomyForTest(map[xbrl.QName]*xbrl.Concept{
+		revenue: revenueC, ratio: ratioC, name: nameC,
+	})
+
+	fact := func(q xbrl.QName, value, unitRef string, isNil bool) *xbrl.Fact {
+		return xbrl.NewFactForTest(xbrl.FactKindItem, q, value, "C1", unitRef, "", "", "", "", isNil)
+	}
+	missing := fact(revenue, "100", "", false)
+	missingNil := fact(ratio, "", "", true)
+	facts := []*xbrl.Fact{
+		fact(revenue, "100", "JPY", false),
+		missing,
+		fact(name, "ACME", "", false),
+		nil,
+		missingNil,
+		fact(xbrl.NewQNameForTest("ex", "Unknown", ns), "1", "", false),
+	}
+
+	doc := xbrl.NewDocumentForTest(nil, nil, nil, facts, tax)
+	assert.Equal(t, []*xbrl.Fact{missing, missingNil}, doc.FactsMissingUnit())
+
+	noTax := xbrl.NewDocumentForTest(nil, nil, nil, facts, nil)
+	assert.Nil(t, noTax.FactsMissingUnit())
+
+	var nilDoc *xbrl.Document
+	assert.Nil(t, nilDoc.FactsMissingUnit())
+}
+
+func TestDocument_ValidateFactValues(t *testing.T) {
+	t.Parallel()
+
+	const ns = "http://example.com/xbrl"
+	xbrli := "http://www.xbrl.org/2003/instance"
+	concept := func(local, typ string) (xbrl.QName, *xbrl.Concept) {
+		q := xbrl.NewQNameForTest("ex", local, ns)
+		return q, xbrl.NewConceptForTest(q, "ex_"+local, xbrl.QName{},
+			xbrl.NewQNameForTest("xbrli", typ, xbrli), false, false, "instant", "")
+	}
+	revenue, revenueC := concept("Revenue", "monetaryItemType")
+	ratio, ratioC := concept("Ratio", "pureItemType")
+	flag, flagC := concept("Flag", "booleanItemType")
+	filed, filedC := concept("FiledOn", "dateItemType")
+	name, nameC := concept("CompanyName", "stringItemType")
+	tax := xbrl.NewTaxonomyForTest(map[xbrl.QName]*xbrl.Concept{
+		revenue: revenueC, ratio: ratioC, flag: flagC, filed: filedC, name: nameC,
+	})
+
+	fact := func(q xbrl.QName, value string, isNil bool) *xbrl.Fact {
+		return xbrl.NewFactForTest(xbrl.FactKindItem, q, value, "C1", "", "", "", "", "", isNil)
+	}
+	badRevenue := fact(revenue, "abc", false)
+	badFlag := fact(flag, "yes", false)
+	upperFlag := fact(flag, "TRUE", false)
+	mixedFlag := fact(flag, " True ", false)
+	badDate := fact(filed, "2025/03/31", false)
+	facts := []*xbrl.Fact{
+		fact(revenue, " 100 ", false),
+		badRevenue,
+		fact(revenue, "", true),
+		fact(ratio, "0.25", false),
+		badFlag,
+		fact(flag, "1", false),
+		fact(flag, " false ", false),
+		upperFlag,
+		mixedFlag,
+		badDate,
+		fact(filed, "2025-03-31", false),
+		fact(name, "abc", false),
+		nil,
+		fact(xbrl.NewQNameForTest("ex", "Unknown", ns), "abc", false),
+	}
+
+	doc := xbrl.NewDocumentForTest(nil, nil, nil, facts, tax)
+	assert.Equal(t, []xbrl.ValueIssue{
+		{Fact: badRevenue, Name: revenue, Reason: `invalid monetary value "abc"`},
+		{Fact: badFlag, Name: flag, Reason: `invalid boolean value "yes"`},
+		{Fact: upperFlag, Name: flag, Reason: `invalid boolean value "TRUE"`},
+		{Fact: mixedFlag, Name: flag, Reason: `invalid boolean value " True "`},
+		{Fact: badDate, Name: filed, Reason: `invalid date value "2025/03/31"`},
+	}, doc.ValidateFactValues())
+
+	valid := xbrl.NewDocumentForTest(nil, nil, nil, facts[:1], tax)
+	assert.Nil(t, valid.ValidateFactValues())
+
+	noTax := xbrl.NewDocumentForTest(nil, nil, nil, facts, nil)
+	assert.Nil(t, noTax.ValidateFactValues())
+
+	var nilDoc *xbrl.Document
+	assert.Nil(t, nilDoc.ValidateFactValues())
+}
+
+func TestTaxonomy_Validate(t *testing.T) {
+	t.Parallel()
+
+	const schema = `<?xml version="1.0" encoding="UTF-8"?>
+<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema"
+           xmlns:xbrli="http://www.xbrl.org/2003/instance"
+           xmlns:xbrldt="http://xbrl.org/2005/xbrldt"
+           xmlns:other="http://example.com/other"
+           xmlns:ex="http://example.com/tax"
+           targetNamespace="http://example.com/tax">
+  <xs:element name="Revenue" id="ex_Revenue" substitutionGroup="xbrli:item" type="xbrli:monetaryItemType"/>
+  <xs:element name="Sales" id="ex_Revenue" substitutionGroup="xbrli:item" type="xbrli:monetaryItemType"/>
+  <xs:element name="RegionAxis" id="ex_RegionAxis" substitutionGroup="xbrldt:dimensionItem" abstract="true"/>
+  <xs:element name="CustomHead" id="ex_CustomHead" substitutionGroup="xbrli:item" abstract="true"/>
+  <xs:element name="Custom" id="ex_Custom" substitutionGroup="ex:CustomHead"/>
+  <xs:element name="Orphan" id="ex_Orphan" substitutionGroup="other:Head"/>
+  <xs:element name="Undeclared" id="ex_Undeclared" substitutionGroup="nope:Head"/>
+  <xs:element name="NoID" substitutionGroup="xbrli:item"/>
+  <xs:element name="NoID2" substitutionGroup="xbrli:item"/>
+</xs:schema>`
+
+	tax, err := xbrl.ParseTaxonomy(strings.NewReader(schema))
+	require.NoError(t, err)
+
+	errs := tax.Validate()
+	var got []string
+	for _, err := range errs {
+		assert.ErrorIs(t, err, xbrl.ErrInvalidTaxonomy)
+		got = append(got, err.Error())
+	}
+	assert.Equal(t, []string{
+		`xbrl: invalid taxonomy: concept {http://example.com/tax}Orphan: substitutionGroup {http://example.com/other}Head is not a concept of the taxonomy`,
+		`xbrl: invalid taxonomy: concept {http://example.com/tax}Sales: id "ex_Revenue" is also used by {http://example.com/tax}Revenue`,
+		`xbrl: invalid taxonomy: concept {http://example.com/tax}Undeclared: substitutionGroup "nope:Head" has no namespace`,
+	}, got)
+
+	// Only the concepts without problems.
+	var lines []string
+	for _, line := range strings.Split(schema, "\n") {
+		if !strings.Contains(line, `name="Sales"`) && !strings.Contains(line, `name="Orphan"`) &&
+			!strings.Contains(line, `name="Undeclared"`) {
+			lines = append(lines, line)
+		}
+	}
+	valid, err := xbrl.ParseTaxonomy(strings.NewReader(strings.Join(lines, "\n")))
+	require.NoError(t, err)
+	assert.Nil(t, valid.Validate())
+
+	var nilTax *xbrl.Taxonomy
+	assert.Nil(t, nilTax.Validate())
+}
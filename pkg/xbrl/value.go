@@ -3,6 +3,8 @@ package xbrl
 import (
 	"errors"
 	"fmt"
+	"math/big"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
@@ -10,8 +12,14 @@ import (
 
 // Namespaces commonly used in XBRL types.
 const (
-	nsXBRLI = "http://www.xbrl.org/2003/instance"
-	nsXSD   = "http://www.w3.org/2001/XMLSchema"
+	nsXBRLI   = "http://www.xbrl.org/2003/instance"
+	nsXSD     = "http://www.w3.org/2001/XMLSchema"
+	nsISO4217 = "http://www.xbrl.org/2003/iso4217"
+	nsXML     = "http://www.w3.org/XML/1998/namespace"
+	nsXLink   = "http://www.w3.org/1999/xlink"
+	nsLink    = "http://www.xbrl.org/2003/linkbase"
+	nsXBRLDI  = "http://xbrl.org/2006/xbrldi"
+	nsXSI     = "http://www.w3.org/2001/XMLSchema-instance"
 )
 
 // ConceptValueKind classifies the conceptual value type of a concept.
@@ -26,6 +34,7 @@ const (
 	ConceptValueBoolean
 	ConceptValueDate
 	ConceptValueDateTime
+	ConceptValueURI
 )
 
 // String implements fmt.Stringer.
@@ -43,13 +52,15 @@ func (k ConceptValueKind) String() string {
 		return "date"
 	case ConceptValueDateTime:
 		return "dateTime"
+	case ConceptValueURI:
+		return "anyURI"
 	default:
 		return "unknown"
 	}
 }
 
 // ValueKind returns a coarse-grained classification of the concept's
-// value type, based on its @type QName.
+// value type, based on its EffectiveType.
 //
 // This function does not look at linkbases or custom types; it only
 // inspects well-known XBRL and XML Schema types and falls back to
@@ -59,7 +70,7 @@ func (c *Concept) ValueKind() ConceptValueKind {
 		return ConceptValueUnknown
 	}
 
-	t := c.Type()
+	t := c.EffectiveType()
 	uri := t.URI()
 	local := t.Local()
 
@@ -80,6 +91,8 @@ func (c *Concept) ValueKind() ConceptValueKind {
 			return ConceptValueDate
 		case "dateTimeItemType":
 			return ConceptValueDateTime
+		case "anyURIItemType":
+			return ConceptValueURI
 		case "stringItemType":
 			return ConceptValueString
 		default:
@@ -98,6 +111,8 @@ func (c *Concept) ValueKind() ConceptValueKind {
 			return ConceptValueDate
 		case "dateTime":
 			return ConceptValueDateTime
+		case "anyURI":
+			return ConceptValueURI
 		case "string", "normalizedString":
 			return ConceptValueString
 		default:
@@ -109,12 +124,83 @@ func (c *Concept) ValueKind() ConceptValueKind {
 	}
 }
 
+// NumericSubtype refines the classification of numeric concepts, so that
+// values can be formatted appropriately (e.g. pure values as percentages).
+type NumericSubtype int
+
+const (
+	// NumericSubtypeNone is returned for concepts that are not numeric.
+	NumericSubtypeNone NumericSubtype = iota
+	// NumericSubtypeDecimal is a plain number, such as an xbrli:decimalItemType
+	// or an xs:integer.
+	NumericSubtypeDecimal
+	// NumericSubtypeMonetary is an amount of money, such as an
+	// xbrli:monetaryItemType.
+	NumericSubtypeMonetary
+	// NumericSubtypeShares is a number of shares, such as an
+	// xbrli:sharesItemType.
+	NumericSubtypeShares
+	// NumericSubtypePerShare is an amount per share, such as an
+	// xbrli:perShareItemType.
+	NumericSubtypePerShare
+	// NumericSubtypePure is a dimensionless number, often a ratio or
+	// percentage.
+	NumericSubtypePure
+)
+
+// String implements fmt.Stringer.
+func (s NumericSubtype) String() string {
+	switch s {
+	case NumericSubtypeDecimal:
+		return "decimal"
+	case NumericSubtypeMonetary:
+		return "monetary"
+	case NumericSubtypeShares:
+		return "shares"
+	case NumericSubtypePerShare:
+		return "perShare"
+	case NumericSubtypePure:
+		return "pure"
+	default:
+		return "none"
+	}
+}
+
+// NumericSubtype returns a finer classification of a numeric concept than
+// ValueKind, distinguishing xbrli:pureItemType, perShareItemType and
+// sharesItemType from other numbers. It returns NumericSubtypeNone if the
+// concept's ValueKind is neither ConceptValueNumeric nor
+// ConceptValueMonetary.
+func (c *Concept) NumericSubtype() NumericSubtype {
+	switch c.ValueKind() {
+	case ConceptValueMonetary:
+		return NumericSubtypeMonetary
+	case ConceptValueNumeric:
+	default:
+		return NumericSubtypeNone
+	}
+
+	if c.typeName.uri == nsXBRLI {
+		switch c.typeName.local {
+		case "pureItemType":
+			return NumericSubtypePure
+		case "perShareItemType":
+			return NumericSubtypePerShare
+		case "sharesItemType":
+			return NumericSubtypeShares
+		}
+	}
+	return NumericSubtypeDecimal
+}
+
 // Errors returned by typed value helpers.
 var (
 	ErrNoTaxonomy      = errors.New("xbrl: no taxonomy attached to document")
 	ErrNoConcept       = errors.New("xbrl: concept not found for fact")
+	ErrNoContext       = errors.New("xbrl: context not found for fact")
 	ErrUnsupportedType = errors.New("xbrl: unsupported value type for this conversion")
 	ErrInvalidValue    = errors.New("xbrl: invalid lexical form for type")
+	ErrNilFact         = errors.New("xbrl: fact is xsi:nil")
 )
 
 // AsInt64 parses the fact's value as an int64, based on its concept type.
@@ -122,6 +208,10 @@ var (
 // The taxonomy must be attached to the Document (via SetTaxonomy or
 // LoadTaxonomyFromSchemaRefs). The concept's ValueKind must be
 // ConceptValueNumeric or ConceptValueMonetary.
+//
+// Values with a fraction or an exponent, such as "1200.00" or "1.2E3",
+// are accepted if they denote an integer within the int64 range; others,
+// such as "1.25E1", return ErrInvalidValue.
 func (d *Document) AsInt64(f *Fact) (int64, error) {
 	if d == nil {
 		return 0, fmt.Errorf("xbrl: document is nil")
@@ -145,7 +235,7 @@ func (d *Document) AsInt64(f *Fact) (int64, error) {
 	case ConceptValueNumeric, ConceptValueMonetary:
 		v := strings.TrimSpace(f.Value())
 		if strings.ContainsAny(v, ".eE") {
-			return 0, ErrInvalidValue
+			return integralInt64(v)
 		}
 		n, err := strconv.ParseInt(v, 10, 64)
 		if err != nil {
@@ -157,6 +247,31 @@ func (d *Document) AsInt64(f *Fact) (int64, error) {
 	}
 }
 
+// integralInt64 parses a decimal or exponent-form value such as "1200.0"
+// or "1.2E3" and returns it as an int64 if it is integral and within the
+// int64 range. Values that cannot be parsed exactly, such as a long
+// fractional part that would round to an integer, are rejected.
+func integralInt64(v string) (int64, error) {
+	if !isDecimalLexical(v, true) {
+		return 0, ErrInvalidValue
+	}
+	x, _, err := new(big.Float).SetPrec(512).Parse(v, 10)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrInvalidValue, err)
+	}
+	if x.IsInf() || !x.IsInt() {
+		return 0, ErrInvalidValue
+	}
+	if x.Acc() != big.Exact {
+		return 0, fmt.Errorf("%w: %s cannot be represented exactly", ErrInvalidValue, v)
+	}
+	n, acc := x.Int64()
+	if acc != big.Exact {
+		return 0, fmt.Errorf("%w: %s is out of int64 range", ErrInvalidValue, v)
+	}
+	return n, nil
+}
+
 // AsFloat64 parses the fact's value as a float64, based on its concept type.
 //
 // The taxonomy must be attached to the Document. The concept's ValueKind
@@ -182,15 +297,110 @@ func (d *Document) AsFloat64(f *Fact) (float64, error) {
 
 	switch c.ValueKind() {
 	case ConceptValueNumeric, ConceptValueMonetary:
-		v := strings.TrimSpace(f.Value())
-		n, err := strconv.ParseFloat(v, 64)
+		return parseDecimalFloat(f.Value())
+	default:
+		return 0, ErrUnsupportedType
+	}
+}
+
+// IsZeroValue reports whether the fact holds the "empty" value of its
+// type: zero for numeric and monetary concepts (so "0", "0.00" and "-0"
+// are all zero), false for booleans, and an empty or whitespace-only
+// string for anything else.
+//
+// The taxonomy must be attached to the Document. Facts marked xsi:nil
+// return ErrNilFact; numeric or boolean values that are not a valid
+// lexical form return ErrInvalidValue.
+func (d *Document) IsZeroValue(f *Fact) (bool, error) {
+	if d == nil {
+		return false, fmt.Errorf("xbrl: document is nil")
+	}
+	if d.taxonomy == nil {
+		return false, ErrNoTaxonomy
+	}
+	if f == nil {
+		return false, fmt.Errorf("xbrl: fact is nil")
+	}
+	if f.IsNil() {
+		return false, ErrNilFact
+	}
+
+	c, ok := d.ConceptOf(f)
+	if !ok || c == nil {
+		return false, ErrNoConcept
+	}
+
+	switch c.ValueKind() {
+	case ConceptValueNumeric, ConceptValueMonetary:
+		r, ok := parseDecimal(strings.TrimSpace(f.Value()))
+		if !ok {
+			return false, ErrInvalidValue
+		}
+		return r.Sign() == 0, nil
+	case ConceptValueBoolean:
+		b, err := d.AsBool(f)
 		if err != nil {
-			return 0, fmt.Errorf("%w: %v", ErrInvalidValue, err)
+			return false, err
 		}
-		return n, nil
+		return !b, nil
+	default:
+		return strings.TrimSpace(f.Value()) == "", nil
+	}
+}
+
+// AsMinorUnits parses the fact's value and returns it scaled by
+// 10^fractionDigits as an integer, e.g. "1234.56" with 2 digits → 123456.
+//
+// The computation is exact (no floating point is involved). If the scaled
+// value is not an integer or does not fit in an int64, ErrInvalidValue is
+// returned. The concept's ValueKind must be ConceptValueNumeric or
+// ConceptValueMonetary.
+func (d *Document) AsMinorUnits(f *Fact, fractionDigits int) (int64, error) {
+	if d == nil {
+		return 0, fmt.Errorf("xbrl: document is nil")
+	}
+	if d.taxonomy == nil {
+		return 0, ErrNoTaxonomy
+	}
+	if f == nil {
+		return 0, fmt.Errorf("xbrl: fact is nil")
+	}
+	if fractionDigits < 0 {
+		return 0, fmt.Errorf("xbrl: negative fractionDigits %d", fractionDigits)
+	}
+	if f.IsNil() {
+		return 0, ErrInvalidValue
+	}
+
+	c, ok := d.ConceptOf(f)
+	if !ok || c == nil {
+		return 0, ErrNoConcept
+	}
+
+	switch c.ValueKind() {
+	case ConceptValueNumeric, ConceptValueMonetary:
 	default:
 		return 0, ErrUnsupportedType
 	}
+
+	v := strings.TrimSpace(f.Value())
+	if !isDecimalLexical(v, false) {
+		return 0, ErrInvalidValue
+	}
+	r, ok := new(big.Rat).SetString(v)
+	if !ok {
+		return 0, ErrInvalidValue
+	}
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(fractionDigits)), nil)
+	r.Mul(r, new(big.Rat).SetInt(scale))
+	if !r.IsInt() {
+		return 0, fmt.Errorf("%w: %s has more than %d fraction digits", ErrInvalidValue, f.Value(), fractionDigits)
+	}
+	n := r.Num()
+	if !n.IsInt64() {
+		return 0, fmt.Errorf("%w: %s overflows int64", ErrInvalidValue, f.Value())
+	}
+	return n.Int64(), nil
 }
 
 // AsBool parses the fact's value as a bool, based on its concept type.
@@ -222,14 +432,26 @@ func (d *Document) AsBool(f *Fact) (bool, error) {
 		return false, ErrUnsupportedType
 	}
 
-	v := strings.TrimSpace(f.Value())
-	switch strings.ToLower(v) {
+	v, ok := parseXSDBool(f.Value())
+	if !ok {
+		return false, ErrInvalidValue
+	}
+	return v, nil
+}
+
+// parseXSDBool parses an xs:boolean lexical form.
+//
+// Surrounding whitespace is ignored and the comparison is case-insensitive.
+// "true"/"1" → true, "false"/"0" → false. The second return value reports
+// whether s was a recognized form.
+func parseXSDBool(s string) (bool, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
 	case "true", "1":
-		return true, nil
+		return true, true
 	case "false", "0":
-		return false, nil
+		return false, true
 	default:
-		return false, ErrInvalidValue
+		return false, false
 	}
 }
 
@@ -237,7 +459,23 @@ func (d *Document) AsBool(f *Fact) (bool, error) {
 //
 // The taxonomy must be attached and the concept's ValueKind must be
 // ConceptValueDate or ConceptValueDateTime.
+//
+// Values without a timezone are interpreted in loc (UTC if nil).
+// Zoned dateTime values are converted to loc, while zoned date values
+// (e.g. "2025-01-02+09:00") keep their own offset so that the calendar
+// date is not shifted.
 func (d *Document) AsTime(f *Fact, loc *time.Location) (time.Time, error) {
+	return d.AsTimeWithLayouts(f, loc, nil)
+}
+
+// AsTimeWithLayouts is like AsTime, but first tries each of layouts in
+// order, so that non-conformant values such as "2006/01/02" seen in some
+// manually prepared filings can be read. Values matching none of them are
+// parsed with the standard xsd:date and xsd:dateTime forms as in AsTime.
+//
+// Layouts are parsed with time.ParseInLocation in loc. As in AsTime,
+// results for dateTime concepts are converted to loc.
+func (d *Document) AsTimeWithLayouts(f *Fact, loc *time.Location, layouts []string) (time.Time, error) {
 	if d == nil {
 		return time.Time{}, fmt.Errorf("xbrl: document is nil")
 	}
@@ -262,19 +500,41 @@ func (d *Document) AsTime(f *Fact, loc *time.Location) (time.Time, error) {
 
 	v := strings.TrimSpace(f.Value())
 
-	switch c.ValueKind() {
+	kind := c.ValueKind()
+	if kind == ConceptValueDate || kind == ConceptValueDateTime {
+		for _, layout := range layouts {
+			t, err := time.ParseInLocation(layout, v, loc)
+			if err != nil {
+				continue
+			}
+			if kind == ConceptValueDateTime {
+				t = t.In(loc)
+			}
+			return t, nil
+		}
+	}
+
+	switch kind {
 	case ConceptValueDate:
 		// ISO 8601 yyyy-mm-dd
 		t, err := time.ParseInLocation("2006-01-02", v, loc)
-		if err != nil {
-			return time.Time{}, fmt.Errorf("%w: %v", ErrInvalidValue, err)
+		if err == nil {
+			return t, nil
+		}
+		// yyyy-mm-dd with timezone (Z, +hh:mm or -hh:mm)
+		if t, zerr := time.Parse("2006-01-02Z07:00", v); zerr == nil {
+			return t, nil
 		}
-		return t, nil
+		return time.Time{}, fmt.Errorf("%w: %v", ErrInvalidValue, err)
 	case ConceptValueDateTime:
 		// Try RFC3339
 		if t, err := time.Parse(time.RFC3339, v); err == nil {
 			return t.In(loc), nil
 		}
+		// Allow offsets without colon (e.g. +0900)
+		if t, err := time.Parse("2006-01-02T15:04:05Z0700", v); err == nil {
+			return t.In(loc), nil
+		}
 		// Allow yyyy-mm-ddThh:mm:ss without timezone
 		if t, err := time.ParseInLocation("2006-01-02T15:04:05", v, loc); err == nil {
 			return t, nil
@@ -284,3 +544,138 @@ func (d *Document) AsTime(f *Fact, loc *time.Location) (time.Time, error) {
 		return time.Time{}, ErrUnsupportedType
 	}
 }
+
+// AsURL parses the fact's value as a URL, based on its concept type.
+//
+// The taxonomy must be attached and the concept's ValueKind must be
+// ConceptValueURI. Relative references are accepted.
+func (d *Document) AsURL(f *Fact) (*url.URL, error) {
+	if d == nil {
+		return nil, fmt.Errorf("xbrl: document is nil")
+	}
+	if d.taxonomy == nil {
+		return nil, ErrNoTaxonomy
+	}
+	if f == nil {
+		return nil, fmt.Errorf("xbrl: fact is nil")
+	}
+	if f.IsNil() {
+		return nil, ErrInvalidValue
+	}
+
+	c, ok := d.ConceptOf(f)
+	if !ok || c == nil {
+		return nil, ErrNoConcept
+	}
+
+	if c.ValueKind() != ConceptValueURI {
+		return nil, ErrUnsupportedType
+	}
+
+	u, err := url.Parse(strings.TrimSpace(f.Value()))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidValue, err)
+	}
+	return u, nil
+}
+
+// TypedValue returns the fact's value as the most specific Go type for its
+// concept's ValueKind:
+//
+//   - numeric/monetary: int64 if the value is integral, float64 otherwise
+//   - boolean: bool
+//   - date/dateTime: time.Time (see AsTime for the meaning of loc)
+//   - anyURI: *url.URL
+//   - anything else: the raw string value
+//
+// Facts marked xsi:nil return (nil, ErrNilFact).
+func (d *Document) TypedValue(f *Fact, loc *time.Location) (any, error) {
+	if d == nil {
+		return nil, fmt.Errorf("xbrl: document is nil")
+	}
+	if d.taxonomy == nil {
+		return nil, ErrNoTaxonomy
+	}
+	if f == nil {
+		return nil, fmt.Errorf("xbrl: fact is nil")
+	}
+	if f.IsNil() {
+		return nil, ErrNilFact
+	}
+
+	c, ok := d.ConceptOf(f)
+	if !ok || c == nil {
+		return nil, ErrNoConcept
+	}
+
+	switch c.ValueKind() {
+	case ConceptValueNumeric, ConceptValueMonetary:
+		if n, err := d.AsInt64(f); err == nil {
+			return n, nil
+		}
+		return d.AsFloat64(f)
+	case ConceptValueBoolean:
+		return d.AsBool(f)
+	case ConceptValueDate, ConceptValueDateTime:
+		return d.AsTime(f, loc)
+	case ConceptValueURI:
+		return d.AsURL(f)
+	default:
+		return f.Value(), nil
+	}
+}
+
+// parseDecimalFloat parses a decimal or exponent-form value as a float64.
+// Forms accepted by strconv.ParseFloat but not by XML Schema, such as
+// "NaN", "Inf" or "1_000", are rejected.
+func parseDecimalFloat(v string) (float64, error) {
+	v = strings.TrimSpace(v)
+	if !isDecimalLexical(v, true) {
+		return 0, fmt.Errorf("%w: %q is not a decimal number", ErrInvalidValue, v)
+	}
+	n, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrInvalidValue, err)
+	}
+	return n, nil
+}
+
+// isDecimalLexical reports whether s is in the lexical form of
+// xs:decimal: an optional sign, then digits with an optional fractional
+// part, such as "-1.5", "+10" or ".5". If exponent is true, an exponent
+// such as "E3" or "e-2" is also allowed, as in finite xs:double values.
+//
+// Forms that big.Rat and big.Float would otherwise accept, such as "1/2",
+// "0x10", "0x1p4" or "1_000", are rejected.
+func isDecimalLexical(s string, exponent bool) bool {
+	i := 0
+	if i < len(s) && (s[i] == '+' || s[i] == '-') {
+		i++
+	}
+	digits := 0
+	for ; i < len(s) && '0' <= s[i] && s[i] <= '9'; i++ {
+		digits++
+	}
+	if i < len(s) && s[i] == '.' {
+		i++
+		for ; i < len(s) && '0' <= s[i] && s[i] <= '9'; i++ {
+			digits++
+		}
+	}
+	if digits == 0 {
+		return false
+	}
+	if exponent && i < len(s) && (s[i] == 'e' || s[i] == 'E') {
+		i++
+		if i < len(s) && (s[i] == '+' || s[i] == '-') {
+			i++
+		}
+		start := i
+		for ; i < len(s) && '0' <= s[i] && s[i] <= '9'; i++ {
+		}
+		if i == start {
+			return false
+		}
+	}
+	return i == len(s)
+}
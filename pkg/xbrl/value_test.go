@@ -2,6 +2,8 @@ package xbrl_test
 
 import (
 	"errors"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
 
@@ -84,6 +86,7 @@ func TestConceptValueKind_String(t *testing.T) {
 		{"Boolean", xbrl.ConceptValueBoolean, "boolean"},
 		{"Date", xbrl.ConceptValueDate, "date"},
 		{"DateTime", xbrl.ConceptValueDateTime, "dateTime"},
+		{"URI", xbrl.ConceptValueURI, "anyURI"},
 	}
 
 	for _, tc := range tests {
@@ -118,6 +121,7 @@ func TestConcept_ValueKind(t *testing.T) {
 		{"XBRLI_Date", args{nsXBRLI, "dateItemType"}, xbrl.ConceptValueDate},
 		{"XBRLI_DateTime", args{nsXBRLI, "dateTimeItemType"}, xbrl.ConceptValueDateTime},
 		{"XBRLI_String", args{nsXBRLI, "stringItemType"}, xbrl.ConceptValueString},
+		{"XBRLI_AnyURI", args{nsXBRLI, "anyURIItemType"}, xbrl.ConceptValueURI},
 		{"XBRLI_UnknownLocal", args{nsXBRLI, "unknownItemType"}, xbrl.ConceptValueString},
 
 		// nsXSD
@@ -127,6 +131,7 @@ func TestConcept_ValueKind(t *testing.T) {
 		{"XSD_Date", args{nsXSD, "date"}, xbrl.ConceptValueDate},
 		{"XSD_DateTime", args{nsXSD, "dateTime"}, xbrl.ConceptValueDateTime},
 		{"XSD_String", args{nsXSD, "string"}, xbrl.ConceptValueString},
+		{"XSD_AnyURI", args{nsXSD, "anyURI"}, xbrl.ConceptValueURI},
 		{"XSD_NormalizedString", args{nsXSD, "normalizedString"}, xbrl.ConceptValueString},
 		{"XSD_UnknownLocal", args{nsXSD, "someType"}, xbrl.ConceptValueString},
 
@@ -163,6 +168,58 @@ func TestConcept_ValueKind(t *testing.T) {
 	})
 }
 
+//------------------------------------------------------------
+// (*Concept).NumericSubtype
+//------------------------------------------------------------
+
+func TestConcept_NumericSubtype(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		typeURI   string
+		typeLocal string
+		want      xbrl.NumericSubtype
+		wantStr   string
+	}{
+		{"Pure", nsXBRLI, "pureItemType", xbrl.NumericSubtypePure, "pure"},
+		{"PerShare", nsXBRLI, "perShareItemType", xbrl.NumericSubtypePerShare, "perShare"},
+		{"Shares", nsXBRLI, "sharesItemType", xbrl.NumericSubtypeShares, "shares"},
+		{"Monetary", nsXBRLI, "monetaryItemType", xbrl.NumericSubtypeMonetary, "monetary"},
+		{"DecimalItem", nsXBRLI, "decimalItemType", xbrl.NumericSubtypeDecimal, "decimal"},
+		{"XSD_Integer", nsXSD, "integer", xbrl.NumericSubtypeDecimal, "decimal"},
+		{"String", nsXBRLI, "stringItemType", xbrl.NumericSubtypeNone, "none"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			q := xbrl.NewQNameForTest("x", "Concept", "http://example.com")
+			typeQName := xbrl.NewQNameForTest("t", tc.typeLocal, tc.typeURI)
+			concept := xbrl.NewConceptForTest(q, "id", xbrl.NewQNameForTest("", "", ""), typeQName, false, false, "", "")
+
+			assert.Equal(t, tc.want, concept.NumericSubtype())
+			assert.Equal(t, tc.wantStr, concept.NumericSubtype().String())
+		})
+	}
+
+	t.Run("NilConcept", func(t *testing.T) {
+		t.Parallel()
+		var c *xbrl.Concept
+		assert.Equal(t, xbrl.NumericSubtypeNone, c.NumericSubtype())
+	})
+
+	t.Run("PureStillConvertsToFloat", func(t *testing.T) {
+		t.Parallel()
+		doc, f := newDocFactWithType(t, nsXBRLI, "pureItemType", "0.25", xbrl.ConceptValueNumeric)
+		v, err := doc.AsFloat64(f)
+		if assert.NoError(t, err) {
+			assert.InDelta(t, 0.25, v, 1e-12)
+		}
+	})
+}
+
 //------------------------------------------------------------
 // Document.AsInt64
 //------------------------------------------------------------
@@ -275,6 +332,61 @@ func TestDocument_AsInt64(t *testing.T) {
 			},
 			want: 1000,
 		},
+		{
+			name: "OK_Exponent",
+			setup: func(t *testing.T) (*xbrl.Document, *xbrl.Fact) {
+				return newDocFactWithType(t, nsXBRLI, "monetaryItemType", "1.2E3", xbrl.ConceptValueMonetary)
+			},
+			want: 1200,
+		},
+		{
+			name: "OK_IntegralFraction",
+			setup: func(t *testing.T) (*xbrl.Document, *xbrl.Fact) {
+				return newDocFactWithType(t, nsXBRLI, "monetaryItemType", "-1200.00", xbrl.ConceptValueMonetary)
+			},
+			want: -1200,
+		},
+		{
+			name: "NonIntegralExponent",
+			setup: func(t *testing.T) (*xbrl.Document, *xbrl.Fact) {
+				return newDocFactWithType(t, nsXBRLI, "monetaryItemType", "1.25E1", xbrl.ConceptValueMonetary)
+			},
+			wantErr: xbrl.ErrInvalidValue,
+		},
+		{
+			name: "ExponentOutOfRange",
+			setup: func(t *testing.T) (*xbrl.Document, *xbrl.Fact) {
+				return newDocFactWithType(t, nsXBRLI, "monetaryItemType", "1E19", xbrl.ConceptValueMonetary)
+			},
+			checkIs: func(err error) bool {
+				return errors.Is(err, xbrl.ErrInvalidValue)
+			},
+		},
+		{
+			name: "LongNonIntegralFraction",
+			setup: func(t *testing.T) (*xbrl.Document, *xbrl.Fact) {
+				return newDocFactWithType(t, nsXBRLI, "monetaryItemType", "1."+strings.Repeat("0", 200)+"1", xbrl.ConceptValueMonetary)
+			},
+			checkIs: func(err error) bool {
+				return errors.Is(err, xbrl.ErrInvalidValue)
+			},
+		},
+		{
+			name: "LongIntegralFraction",
+			setup: func(t *testing.T) (*xbrl.Document, *xbrl.Fact) {
+				return newDocFactWithType(t, nsXBRLI, "monetaryItemType", "42."+strings.Repeat("0", 200), xbrl.ConceptValueMonetary)
+			},
+			want: 42,
+		},
+		{
+			name: "ExponentInfinity",
+			setup: func(t *testing.T) (*xbrl.Document, *xbrl.Fact) {
+				return newDocFactWithType(t, nsXBRLI, "monetaryItemType", "Inf", xbrl.ConceptValueMonetary)
+			},
+			checkIs: func(err error) bool {
+				return errors.Is(err, xbrl.ErrInvalidValue)
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -398,6 +510,26 @@ func TestDocument_AsFloat64(t *testing.T) {
 				return errors.Is(err, xbrl.ErrInvalidValue)
 			},
 		},
+		{
+			name: "NaN",
+			setup: func(t *testing.T) (*xbrl.Document, *xbrl.Fact) {
+				doc, f := newDocFactWithType(t, nsXSD, "decimal", "NaN", xbrl.ConceptValueNumeric)
+				return doc, f
+			},
+			checkIs: func(err error) bool {
+				return errors.Is(err, xbrl.ErrInvalidValue)
+			},
+		},
+		{
+			name: "Underscore",
+			setup: func(t *testing.T) (*xbrl.Document, *xbrl.Fact) {
+				doc, f := newDocFactWithType(t, nsXSD, "decimal", "1_000", xbrl.ConceptValueNumeric)
+				return doc, f
+			},
+			checkIs: func(err error) bool {
+				return errors.Is(err, xbrl.ErrInvalidValue)
+			},
+		},
 		{
 			name: "OK_Numeric",
 			setup: func(t *testing.T) (*xbrl.Document, *xbrl.Fact) {
@@ -406,6 +538,14 @@ func TestDocument_AsFloat64(t *testing.T) {
 			},
 			want: 123.45,
 		},
+		{
+			name: "OK_Exponent",
+			setup: func(t *testing.T) (*xbrl.Document, *xbrl.Fact) {
+				doc, f := newDocFactWithType(t, nsXSD, "decimal", "1.5E3", xbrl.ConceptValueNumeric)
+				return doc, f
+			},
+			want: 1500,
+		},
 		{
 			name: "OK_Monetary",
 			setup: func(t *testing.T) (*xbrl.Document, *xbrl.Fact) {
@@ -578,6 +718,194 @@ func TestDocument_AsBool(t *testing.T) {
 	}
 }
 
+// ------------------------------------------------------------
+// Document.AsMinorUnits
+// ------------------------------------------------------------
+
+func TestDocument_AsMinorUnits(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		setup      func(t *testing.T) (*xbrl.Document, *xbrl.Fact)
+		digits     int
+		want       int64
+		wantErr    error
+		wantErrMsg string
+	}{
+		{
+			name: "NilDocument",
+			setup: func(t *testing.T) (*xbrl.Document, *xbrl.Fact) {
+				return nil, nil
+			},
+			wantErrMsg: "xbrl: document is nil",
+		},
+		{
+			name: "NoTaxonomy",
+			setup: func(t *testing.T) (*xbrl.Document, *xbrl.Fact) {
+				doc := xbrl.NewDocumentForTest(nil, nil, nil, nil, nil)
+				f := xbrl.NewFactForTest(0, xbrl.NewQNameForTest("", "n", ""), "1", "ctx", "", "", "", "id", "", false)
+				return doc, f
+			},
+			wantErr: xbrl.ErrNoTaxonomy,
+		},
+		{
+			name: "NegativeDigits",
+			setup: func(t *testing.T) (*xbrl.Document, *xbrl.Fact) {
+				return newDocFactWithType(t, nsXBRLI, "monetaryItemType", "1", xbrl.ConceptValueMonetary)
+			},
+			digits:     -1,
+			wantErrMsg: "xbrl: negative fractionDigits -1",
+		},
+		{
+			name: "Monetary_TwoDigits",
+			setup: func(t *testing.T) (*xbrl.Document, *xbrl.Fact) {
+				return newDocFactWithType(t, nsXBRLI, "monetaryItemType", "1234.56", xbrl.ConceptValueMonetary)
+			},
+			digits: 2,
+			want:   123456,
+		},
+		{
+			name: "Monetary_Negative",
+			setup: func(t *testing.T) (*xbrl.Document, *xbrl.Fact) {
+				return newDocFactWithType(t, nsXBRLI, "monetaryItemType", " -0.5 ", xbrl.ConceptValueMonetary)
+			},
+			digits: 2,
+			want:   -50,
+		},
+		{
+			name: "Numeric_ZeroDigits",
+			setup: func(t *testing.T) (*xbrl.Document, *xbrl.Fact) {
+				return newDocFactWithType(t, nsXSD, "decimal", "42", xbrl.ConceptValueNumeric)
+			},
+			digits: 0,
+			want:   42,
+		},
+		{
+			name: "TooManyFractionDigits",
+			setup: func(t *testing.T) (*xbrl.Document, *xbrl.Fact) {
+				return newDocFactWithType(t, nsXBRLI, "monetaryItemType", "1.234", xbrl.ConceptValueMonetary)
+			},
+			digits:  2,
+			wantErr: xbrl.ErrInvalidValue,
+		},
+		{
+			name: "Overflow",
+			setup: func(t *testing.T) (*xbrl.Document, *xbrl.Fact) {
+				return newDocFactWithType(t, nsXBRLI, "monetaryItemType", "92233720368547758.08", xbrl.ConceptValueMonetary)
+			},
+			digits:  3,
+			wantErr: xbrl.ErrInvalidValue,
+		},
+		{
+			name: "InvalidLexical",
+			setup: func(t *testing.T) (*xbrl.Document, *xbrl.Fact) {
+				return newDocFactWithType(t, nsXBRLI, "monetaryItemType", "12a", xbrl.ConceptValueMonetary)
+			},
+			digits:  2,
+			wantErr: xbrl.ErrInvalidValue,
+		},
+		{
+			name: "NonDecimal_Fraction",
+			setup: func(t *testing.T) (*xbrl.Document, *xbrl.Fact) {
+				return newDocFactWithType(t, nsXBRLI, "monetaryItemType", "1/2", xbrl.ConceptValueMonetary)
+			},
+			digits:  2,
+			wantErr: xbrl.ErrInvalidValue,
+		},
+		{
+			name: "NonDecimal_HexPrefix",
+			setup: func(t *testing.T) (*xbrl.Document, *xbrl.Fact) {
+				return newDocFactWithType(t, nsXBRLI, "monetaryItemType", "0x10", xbrl.ConceptValueMonetary)
+			},
+			digits:  2,
+			wantErr: xbrl.ErrInvalidValue,
+		},
+		{
+			name: "NonDecimal_HexFloat",
+			setup: func(t *testing.T) (*xbrl.Document, *xbrl.Fact) {
+				return newDocFactWithType(t, nsXBRLI, "monetaryItemType", "0x1p4", xbrl.ConceptValueMonetary)
+			},
+			digits:  2,
+			wantErr: xbrl.ErrInvalidValue,
+		},
+		{
+			name: "NonDecimal_Underscore",
+			setup: func(t *testing.T) (*xbrl.Document, *xbrl.Fact) {
+				return newDocFactWithType(t, nsXBRLI, "monetaryItemType", "1_000", xbrl.ConceptValueMonetary)
+			},
+			digits:  2,
+			wantErr: xbrl.ErrInvalidValue,
+		},
+		{
+			name: "NonDecimal_Exponent",
+			setup: func(t *testing.T) (*xbrl.Document, *xbrl.Fact) {
+				return newDocFactWithType(t, nsXBRLI, "monetaryItemType", "1E2", xbrl.ConceptValueMonetary)
+			},
+			digits:  2,
+			wantErr: xbrl.ErrInvalidValue,
+		},
+		{
+			name: "BooleanRejected",
+			setup: func(t *testing.T) (*xbrl.Document, *xbrl.Fact) {
+				return newDocFactWithType(t, nsXSD, "boolean", "true", xbrl.ConceptValueBoolean)
+			},
+			digits:  2,
+			wantErr: xbrl.ErrUnsupportedType,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			doc, fact := tc.setup(t)
+			got, err := doc.AsMinorUnits(fact, tc.digits)
+
+			switch {
+			case tc.wantErrMsg != "":
+				assert.EqualError(t, err, tc.wantErrMsg)
+			case tc.wantErr != nil:
+				assert.ErrorIs(t, err, tc.wantErr)
+			default:
+				assert.NoError(t, err)
+				assert.Equal(t, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestParseXSDBool(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		in     string
+		want   bool
+		wantOK bool
+	}{
+		{"true", "true", true, true},
+		{"one", "1", true, true},
+		{"false", "false", false, true},
+		{"zero", "0", false, true},
+		{"upper case", "TRUE", true, true},
+		{"mixed case with spaces", "  False\n", false, true},
+		{"empty", "", false, false},
+		{"only spaces", "   ", false, false},
+		{"yes is invalid", "yes", false, false},
+		{"inner space is invalid", "tr ue", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, ok := xbrl.ParseXSDBool(tt.in)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantOK, ok)
+		})
+	}
+}
+
 // ------------------------------------------------------------
 // Document.AsTime
 // ------------------------------------------------------------
@@ -701,6 +1029,36 @@ func TestDocument_AsTime(t *testing.T) {
 			loc:  jst,
 			want: time.Date(2025, 1, 2, 15, 4, 5, 0, jst),
 		},
+		{
+			name: "Date_WithOffset_KeepsOffset",
+			setup: func(t *testing.T) (*xbrl.Document, *xbrl.Fact) {
+				doc, f := newDocFactWithType(t, nsXSD, "date", "2025-01-02+09:00", xbrl.ConceptValueDate)
+				return doc, f
+			},
+			loc: time.UTC,
+			want: func() time.Time {
+				tm, _ := time.Parse("2006-01-02Z07:00", "2025-01-02+09:00")
+				return tm
+			}(),
+		},
+		{
+			name: "Date_WithZ",
+			setup: func(t *testing.T) (*xbrl.Document, *xbrl.Fact) {
+				doc, f := newDocFactWithType(t, nsXSD, "date", "2025-01-02Z", xbrl.ConceptValueDate)
+				return doc, f
+			},
+			loc:  jst,
+			want: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "DateTime_OffsetWithoutColon_OK",
+			setup: func(t *testing.T) (*xbrl.Document, *xbrl.Fact) {
+				doc, f := newDocFactWithType(t, nsXSD, "dateTime", "2025-01-02T15:04:05+0900", xbrl.ConceptValueDateTime)
+				return doc, f
+			},
+			loc:  time.UTC,
+			want: time.Date(2025, 1, 2, 6, 4, 5, 0, time.UTC),
+		},
 		{
 			name: "DateTime_Invalid",
 			setup: func(t *testing.T) (*xbrl.Document, *xbrl.Fact) {
@@ -749,3 +1107,392 @@ func TestDocument_AsTime(t *testing.T) {
 		})
 	}
 }
+
+// ------------------------------------------------------------
+// Document.AsTimeWithLayouts
+// ------------------------------------------------------------
+
+func TestDocument_AsTimeWithLayouts(t *testing.T) {
+	t.Parallel()
+
+	jst := time.FixedZone("JST", 9*60*60)
+	slash := []string{"2006/01/02"}
+
+	tests := []struct {
+		name    string
+		typ     string
+		value   string
+		kind    xbrl.ConceptValueKind
+		layouts []string
+		want    time.Time
+		wantErr error
+	}{
+		{
+			name:    "CustomLayout",
+			typ:     "date",
+			value:   "2025/01/02",
+			kind:    xbrl.ConceptValueDate,
+			layouts: slash,
+			want:    time.Date(2025, 1, 2, 0, 0, 0, 0, jst),
+		},
+		{
+			name:    "LayoutsInOrder",
+			typ:     "date",
+			value:   "01/02/2025",
+			kind:    xbrl.ConceptValueDate,
+			layouts: []string{"2006/01/02", "02/01/2006", "01/02/2006"},
+			want:    time.Date(2025, 2, 1, 0, 0, 0, 0, jst),
+		},
+		{
+			name:    "FallsBackToStandardForms",
+			typ:     "date",
+			value:   "2025-01-02",
+			kind:    xbrl.ConceptValueDate,
+			layouts: slash,
+			want:    time.Date(2025, 1, 2, 0, 0, 0, 0, jst),
+		},
+		{
+			name:    "DateTimeConvertedToLoc",
+			typ:     "dateTime",
+			value:   "2025/01/02 00:30 +0000",
+			kind:    xbrl.ConceptValueDateTime,
+			layouts: []string{"2006/01/02 15:04 -0700"},
+			want:    time.Date(2025, 1, 2, 9, 30, 0, 0, jst),
+		},
+		{
+			name:    "NoLayoutMatches",
+			typ:     "date",
+			value:   "2 Jan 2025",
+			kind:    xbrl.ConceptValueDate,
+			layouts: slash,
+			wantErr: xbrl.ErrInvalidValue,
+		},
+		{
+			name:    "WithoutLayoutsLikeAsTime",
+			typ:     "date",
+			value:   "2025/01/02",
+			kind:    xbrl.ConceptValueDate,
+			wantErr: xbrl.ErrInvalidValue,
+		},
+		{
+			name:    "UnsupportedType",
+			typ:     "string",
+			value:   "2025/01/02",
+			kind:    xbrl.ConceptValueString,
+			layouts: slash,
+			wantErr: xbrl.ErrUnsupportedType,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			doc, f := newDocFactWithType(t, nsXSD, tc.typ, tc.value, tc.kind)
+			got, err := doc.AsTimeWithLayouts(f, jst, tc.layouts)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.True(t, got.Equal(tc.want), "got=%v want=%v", got, tc.want)
+				assert.Equal(t, jst, got.Location())
+			}
+		})
+	}
+}
+
+// ------------------------------------------------------------
+// Document.AsURL
+// ------------------------------------------------------------
+
+func TestDocument_AsURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		setup      func(t *testing.T) (*xbrl.Document, *xbrl.Fact)
+		want       string
+		wantErr    error
+		wantErrMsg string
+	}{
+		{
+			name: "NilDocument",
+			setup: func(t *testing.T) (*xbrl.Document, *xbrl.Fact) {
+				return nil, nil
+			},
+			wantErrMsg: "xbrl: document is nil",
+		},
+		{
+			name: "NoTaxonomy",
+			setup: func(t *testing.T) (*xbrl.Document, *xbrl.Fact) {
+				doc := xbrl.NewDocumentForTest(nil, nil, nil, nil, nil)
+				f := xbrl.NewFactForTest(0, xbrl.NewQNameForTest("", "n", ""), "http://example.com", "ctx", "", "", "", "id", "", false)
+				return doc, f
+			},
+			wantErr: xbrl.ErrNoTaxonomy,
+		},
+		{
+			name: "NilFact",
+			setup: func(t *testing.T) (*xbrl.Document, *xbrl.Fact) {
+				tax := xbrl.NewTaxonomyForTest(map[xbrl.QName]*xbrl.Concept{})
+				return xbrl.NewDocumentForTest(nil, nil, nil, nil, tax), nil
+			},
+			wantErrMsg: "xbrl: fact is nil",
+		},
+		{
+			name: "UnsupportedType",
+			setup: func(t *testing.T) (*xbrl.Document, *xbrl.Fact) {
+				return newDocFactWithType(t, nsXSD, "string", "http://example.com", xbrl.ConceptValueString)
+			},
+			wantErr: xbrl.ErrUnsupportedType,
+		},
+		{
+			name: "ValidURI",
+			setup: func(t *testing.T) (*xbrl.Document, *xbrl.Fact) {
+				return newDocFactWithType(t, nsXSD, "anyURI", " https://example.com/ir?lang=ja ", xbrl.ConceptValueURI)
+			},
+			want: "https://example.com/ir?lang=ja",
+		},
+		{
+			name: "InvalidURI",
+			setup: func(t *testing.T) (*xbrl.Document, *xbrl.Fact) {
+				return newDocFactWithType(t, nsXSD, "anyURI", "http://exa mple.com/%zz", xbrl.ConceptValueURI)
+			},
+			wantErr: xbrl.ErrInvalidValue,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			doc, fact := tc.setup(t)
+			got, err := doc.AsURL(fact)
+
+			switch {
+			case tc.wantErrMsg != "":
+				assert.EqualError(t, err, tc.wantErrMsg)
+			case tc.wantErr != nil:
+				assert.ErrorIs(t, err, tc.wantErr)
+			default:
+				if assert.NoError(t, err) {
+					assert.Equal(t, tc.want, got.String())
+				}
+			}
+		})
+	}
+}
+
+// ------------------------------------------------------------
+// Document.TypedValue
+// ------------------------------------------------------------
+
+func TestDocument_TypedValue(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		setup      func(t *testing.T) (*xbrl.Document, *xbrl.Fact)
+		want       any
+		wantErr    error
+		wantErrMsg string
+	}{
+		{
+			name: "NilDocument",
+			setup: func(t *testing.T) (*xbrl.Document, *xbrl.Fact) {
+				return nil, nil
+			},
+			wantErrMsg: "xbrl: document is nil",
+		},
+		{
+			name: "NoTaxonomy",
+			setup: func(t *testing.T) (*xbrl.Document, *xbrl.Fact) {
+				doc := xbrl.NewDocumentForTest(nil, nil, nil, nil, nil)
+				f := xbrl.NewFactForTest(0, xbrl.NewQNameForTest("", "n", ""), "1", "ctx", "", "", "", "id", "", false)
+				return doc, f
+			},
+			wantErr: xbrl.ErrNoTaxonomy,
+		},
+		{
+			name: "NilFactValue",
+			setup: func(t *testing.T) (*xbrl.Document, *xbrl.Fact) {
+				q := xbrl.NewQNameForTest("x", "c", "http://example.com")
+				typeQName := xbrl.NewQNameForTest("t", "decimal", nsXSD)
+				concept := xbrl.NewConceptForTest(q, "id", xbrl.QName{}, typeQName, false, true, "", "")
+				tax := xbrl.NewTaxonomyForTest(map[xbrl.QName]*xbrl.Concept{q: concept})
+				f := xbrl.NewFactForTest(0, q, "", "ctx", "", "", "", "id", "", true)
+				return xbrl.NewDocumentForTest(nil, nil, nil, []*xbrl.Fact{f}, tax), f
+			},
+			wantErr: xbrl.ErrNilFact,
+		},
+		{
+			name: "NoConcept",
+			setup: func(t *testing.T) (*xbrl.Document, *xbrl.Fact) {
+				tax := xbrl.NewTaxonomyForTest(map[xbrl.QName]*xbrl.Concept{})
+				f := xbrl.NewFactForTest(0, xbrl.NewQNameForTest("x", "c", "http://example.com"), "1", "ctx", "", "", "", "id", "", false)
+				return xbrl.NewDocumentForTest(nil, nil, nil, []*xbrl.Fact{f}, tax), f
+			},
+			wantErr: xbrl.ErrNoConcept,
+		},
+		{
+			name: "IntegralMonetary",
+			setup: func(t *testing.T) (*xbrl.Document, *xbrl.Fact) {
+				return newDocFactWithType(t, nsXBRLI, "monetaryItemType", "1000", xbrl.ConceptValueMonetary)
+			},
+			want: int64(1000),
+		},
+		{
+			name: "FractionalNumeric",
+			setup: func(t *testing.T) (*xbrl.Document, *xbrl.Fact) {
+				return newDocFactWithType(t, nsXSD, "decimal", "12.5", xbrl.ConceptValueNumeric)
+			},
+			want: 12.5,
+		},
+		{
+			name: "InvalidNumeric",
+			setup: func(t *testing.T) (*xbrl.Document, *xbrl.Fact) {
+				return newDocFactWithType(t, nsXSD, "decimal", "abc", xbrl.ConceptValueNumeric)
+			},
+			wantErr: xbrl.ErrInvalidValue,
+		},
+		{
+			name: "Boolean",
+			setup: func(t *testing.T) (*xbrl.Document, *xbrl.Fact) {
+				return newDocFactWithType(t, nsXSD, "boolean", "true", xbrl.ConceptValueBoolean)
+			},
+			want: true,
+		},
+		{
+			name: "Date",
+			setup: func(t *testing.T) (*xbrl.Document, *xbrl.Fact) {
+				return newDocFactWithType(t, nsXSD, "date", "2025-01-02", xbrl.ConceptValueDate)
+			},
+			want: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "URI",
+			setup: func(t *testing.T) (*xbrl.Document, *xbrl.Fact) {
+				return newDocFactWithType(t, nsXSD, "anyURI", "https://example.com", xbrl.ConceptValueURI)
+			},
+			want: &url.URL{Scheme: "https", Host: "example.com"},
+		},
+		{
+			name: "String",
+			setup: func(t *testing.T) (*xbrl.Document, *xbrl.Fact) {
+				return newDocFactWithType(t, nsXSD, "string", "hello", xbrl.ConceptValueString)
+			},
+			want: "hello",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			doc, fact := tc.setup(t)
+			got, err := doc.TypedValue(fact, nil)
+
+			switch {
+			case tc.wantErrMsg != "":
+				assert.EqualError(t, err, tc.wantErrMsg)
+			case tc.wantErr != nil:
+				assert.ErrorIs(t, err, tc.wantErr)
+			default:
+				assert.NoError(t, err)
+				assert.IsType(t, tc.want, got)
+				assert.Equal(t, tc.want, got)
+			}
+		})
+	}
+}
+
+// ------------------------------------------------------------
+// Document.IsZeroValue
+// ------------------------------------------------------------
+
+func TestDocument_IsZeroValue(t *testing.T) {
+	t.Parallel()
+
+	typed := func(typeURI, typeLocal, value string, kind xbrl.ConceptValueKind) func(t *testing.T) (*xbrl.Document, *xbrl.Fact) {
+		return func(t *testing.T) (*xbrl.Document, *xbrl.Fact) {
+			return newDocFactWithType(t, typeURI, typeLocal, value, kind)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		setup   func(t *testing.T) (*xbrl.Document, *xbrl.Fact)
+		want    bool
+		wantErr error
+		wantMsg string
+	}{
+		{name: "integer zero", setup: typed(nsXBRLI, "monetaryItemType", "0", xbrl.ConceptValueMonetary), want: true},
+		{name: "decimal zero", setup: typed(nsXBRLI, "monetaryItemType", " 0.00 ", xbrl.ConceptValueMonetary), want: true},
+		{name: "negative zero", setup: typed(nsXSD, "decimal", "-0", xbrl.ConceptValueNumeric), want: true},
+		{name: "non-zero", setup: typed(nsXBRLI, "monetaryItemType", "0.01", xbrl.ConceptValueMonetary), want: false},
+		{name: "invalid number", setup: typed(nsXSD, "decimal", "zero", xbrl.ConceptValueNumeric), wantErr: xbrl.ErrInvalidValue},
+		{name: "false", setup: typed(nsXSD, "boolean", "false", xbrl.ConceptValueBoolean), want: true},
+		{name: "true", setup: typed(nsXSD, "boolean", "1", xbrl.ConceptValueBoolean), want: false},
+		{name: "invalid boolean", setup: typed(nsXSD, "boolean", "no", xbrl.ConceptValueBoolean), wantErr: xbrl.ErrInvalidValue},
+		{name: "empty string", setup: typed(nsXBRLI, "stringItemType", "  ", xbrl.ConceptValueString), want: true},
+		{name: "non-empty string", setup: typed(nsXBRLI, "stringItemType", "0", xbrl.ConceptValueString), want: false},
+		{
+			name: "nil fact value",
+			setup: func(t *testing.T) (*xbrl.Document, *xbrl.Fact) {
+				doc, f := newDocFactWithType(t, nsXBRLI, "monetaryItemType", "0", xbrl.ConceptValueMonetary)
+				nilFact := xbrl.NewFactForTest(0, f.Name(), "", "ctx1", "", "", "", "", "", true)
+				return doc, nilFact
+			},
+			wantErr: xbrl.ErrNilFact,
+		},
+		{
+			name: "no taxonomy",
+			setup: func(t *testing.T) (*xbrl.Document, *xbrl.Fact) {
+				f := xbrl.NewFactForTest(0, xbrl.NewQNameForTest("", "n", ""), "0", "ctx", "", "", "", "", "", false)
+				return xbrl.NewDocumentForTest(nil, nil, nil, nil, nil), f
+			},
+			wantErr: xbrl.ErrNoTaxonomy,
+		},
+		{
+			name: "no concept",
+			setup: func(t *testing.T) (*xbrl.Document, *xbrl.Fact) {
+				doc, _ := newDocFactWithType(t, nsXBRLI, "monetaryItemType", "0", xbrl.ConceptValueMonetary)
+				f := xbrl.NewFactForTest(0, xbrl.NewQNameForTest("x", "Other", "http://example.com"), "0", "ctx1", "", "", "", "", "", false)
+				return doc, f
+			},
+			wantErr: xbrl.ErrNoConcept,
+		},
+		{
+			name: "nil fact",
+			setup: func(t *testing.T) (*xbrl.Document, *xbrl.Fact) {
+				doc, _ := newDocFactWithType(t, nsXBRLI, "monetaryItemType", "0", xbrl.ConceptValueMonetary)
+				return doc, nil
+			},
+			wantMsg: "xbrl: fact is nil",
+		},
+		{
+			name:    "nil document",
+			setup:   func(t *testing.T) (*xbrl.Document, *xbrl.Fact) { return nil, nil },
+			wantMsg: "xbrl: document is nil",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			doc, fact := tc.setup(t)
+			got, err := doc.IsZeroValue(fact)
+			switch {
+			case tc.wantErr != nil:
+				assert.ErrorIs(t, err, tc.wantErr)
+			case tc.wantMsg != "":
+				assert.EqualError(t, err, tc.wantMsg)
+			default:
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package xbrl
+
+import (
+	"encoding/xml"
+	"fmt"
+	"slices"
+)
+
+// Warnings returns the recoverable anomalies found while parsing the
+// document, in document order. They do not fail the parse but usually
+// point at a problem in the instance:
+//
+//   - a context ID defined more than once (the last definition is used)
+//   - a unit measure whose namespace prefix is not declared
+//   - a fact with both decimals and precision
+//   - an xsi:nil value that is not a valid xs:boolean
+//
+// The returned slice is a copy, or nil if there are no warnings.
+func (d *Document) Warnings() []string {
+	if d == nil {
+		return nil
+	}
+	return slices.Clone(d.warnings)
+}
+
+func (d *Document) warnf(format string, args ...any) {
+	d.warnings = append(d.warnings, fmt.Sprintf(format, args...))
+}
+
+// checkUnit records warnings for measures of u with unresolved prefixes.
+func (d *Document) checkUnit(u *Unit) {
+	for _, m := range u.AllMeasures() {
+		if m.prefix != "" && m.uri == "" {
+			d.warnf("unit %q: measure %q has an undeclared namespace prefix", u.id, m.String())
+		}
+	}
+}
+
+// checkFact records warnings for the fact f parsed from start.
+func (d *Document) checkFact(f *Fact, start xml.StartElement) {
+	if f.decimals != "" && f.precision != "" {
+		d.warnf("fact %s (#%d): both decimals and precision are set", f.name.String(), f.index)
+	}
+	for _, a := range start.Attr {
+		if a.Name.Space != nsXSI || a.Name.Local != "nil" {
+			continue
+		}
+		if _, ok := parseXSDBool(a.Value); !ok {
+			d.warnf("fact %s (#%d): xsi:nil value %q is not a valid boolean", f.name.String(), f.index, a.Value)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package xbrl_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aethiopicuschan/xbrl-go/pkg/xbrl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocument_Warnings(t *testing.T) {
+	t.Parallel()
+
+	const head = `<xbrli:xbrl xmlns:xbrli="http://www.xbrl.org/2003/instance"
+    xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"
+    xmlns:iso4217="http://www.xbrl.org/2003/iso4217" xmlns:ex="http://example.com/xbrl">`
+	context := func(id, instant string) string {
+		return `<xbrli:context id="` + id + `">
+    <xbrli:entity><xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:instant>` + instant + `</xbrli:instant></xbrli:period>
+  </xbrli:context>`
+	}
+
+	tests := []struct {
+		name string
+		body string
+		want []string
+	}{
+		{
+			name: "clean document",
+			body: context("C1", "2025-03-31") + `
+  <xbrli:unit id="JPY"><xbrli:measure>iso4217:JPY</xbrli:measure></xbrli:unit>
+  <ex:Revenue contextRef="C1" unitRef="JPY" decimals="0">100</ex:Revenue>
+  <ex:Note contextRef="C1" xsi:nil="1"/>`,
+			want: nil,
+		},
+		{
+			name: "duplicate context ID",
+			body: context("C1", "2024-03-31") + context("C1", "2025-03-31"),
+			want: []string{`duplicate context ID "C1"; the last definition is used`},
+		},
+		{
+			name: "unresolved measure prefix",
+			body: `<xbrli:unit id="U1"><xbrli:divide>
+    <xbrli:unitNumerator><xbrli:measure>iso4217:JPY</xbrli:measure></xbrli:unitNumerator>
+    <xbrli:unitDenominator><xbrli:measure>undeclared:shares</xbrli:measure></xbrli:unitDenominator>
+  </xbrli:divide></xbrli:unit>`,
+			want: []string{`unit "U1": measure "undeclared:shares" has an undeclared namespace prefix`},
+		},
+		{
+			name: "decimals and precision",
+			body: context("C1", "2025-03-31") + `
+  <ex:Revenue contextRef="C1" decimals="0" precision="3">100</ex:Revenue>`,
+			want: []string{`fact {http://example.com/xbrl}Revenue (#0): both decimals and precision are set`},
+		},
+		{
+			name: "unknown nil lexical form",
+			body: context("C1", "2025-03-31") + `
+  <ex:Revenue contextRef="C1" decimals="0">100</ex:Revenue>
+  <ex:Note contextRef="C1" xsi:nil="yes"/>`,
+			want: []string{`fact {http://example.com/xbrl}Note (#1): xsi:nil value "yes" is not a valid boolean`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			doc, err := xbrl.Parse(strings.NewReader(head + tt.body + `</xbrli:xbrl>`))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, doc.Warnings())
+		})
+	}
+}
+
+func TestDocument_Warnings_NilValues(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value   string
+		wantNil bool
+		warned  bool
+	}{
+		{value: "true", wantNil: true},
+		{value: "1", wantNil: true},
+		{value: "TRUE", wantNil: true},
+		{value: "false", wantNil: false},
+		{value: "0", wantNil: false},
+		{value: "yes", wantNil: false, warned: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Parallel()
+
+			src := `<xbrli:xbrl xmlns:xbrli="http://www.xbrl.org/2003/instance"
+    xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xmlns:ex="http://example.com/xbrl">
+  <ex:Note contextRef="C1" xsi:nil="` + tt.value + `"/>
+</xbrli:xbrl>`
+			doc, err := xbrl.Parse(strings.NewReader(src))
+			require.NoError(t, err)
+			require.Len(t, doc.Facts(), 1)
+			assert.Equal(t, tt.wantNil, doc.Facts()[0].IsNil())
+			assert.Equal(t, tt.warned, len(doc.Warnings()) > 0, doc.Warnings())
+		})
+	}
+}
+
+func TestDocument_Warnings_DuplicateContextLastWins(t *testing.T) {
+	t.Parallel()
+
+	const src = `<xbrli:xbrl xmlns:xbrli="http://www.xbrl.org/2003/instance">
+  <xbrli:context id="C1">
+    <xbrli:entity><xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:instant>2024-03-31</xbrli:instant></xbrli:period>
+  </xbrli:context>
+  <xbrli:context id="C1">
+    <xbrli:entity><xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:instant>2025-03-31</xbrli:instant></xbrli:period>
+  </xbrli:context>
+</xbrli:xbrl>`
+
+	doc, err := xbrl.Parse(strings.NewReader(src))
+	require.NoError(t, err)
+	require.Len(t, doc.Warnings(), 1)
+	assert.Contains(t, doc.Warnings()[0], `"C1"`)
+
+	ctx, ok := doc.ContextByID("C1")
+	require.True(t, ok)
+	instant, _ := ctx.Period().Instant()
+	assert.Equal(t, "2025-03-31", instant)
+
+	// Warnings are carried over by Clone.
+	assert.Equal(t, doc.Warnings(), doc.Clone().Warnings())
+}
+
+func TestDocument_Warnings_Nil(t *testing.T) {
+	t.Parallel()
+
+	var doc *xbrl.Document
+	assert.Nil(t, doc.Warnings())
+}
@@ -0,0 +1,324 @@
+package xbrl
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// WriteXML writes the document to w as an XBRL instance.
+//
+// Namespace prefixes are taken from Namespaces, so a parsed document is
+// written with the prefixes of its source and the output diffs cleanly
+// against it. Namespaces without a declared prefix get a synthesized one
+// (ns1, ns2, ...), numbered in order of first use. The other attributes
+// of the root element are kept as well.
+//
+// Schema and linkbase references come first, then contexts and units
+// sorted by ID, then facts in document order, then a footnoteLink with
+// the footnotes of each fact ID (see Footnotes). Typed dimension values
+// are written verbatim, so prefixes used inside them must be declared on
+// the root element of the source. Footnotes are written as plain text,
+// so XHTML markup in the source footnotes is not kept.
+//
+// A nil document writes nothing.
+func (d *Document) WriteXML(w io.Writer) error {
+	if d == nil {
+		return nil
+	}
+
+	xw := newXMLWriter(d.Namespaces())
+	rootLang := ""
+	for _, a := range d.rootAttrs {
+		if a.Name.Space == nsXML && a.Name.Local == "lang" {
+			rootLang = a.Value
+		}
+	}
+
+	// The body is written first so that all namespaces it uses are known
+	// when the root element is written.
+	var body bytes.Buffer
+	for _, sr := range d.schemaRefs {
+		fmt.Fprintf(&body, "  <%s %s=\"simple\" %s=\"%s\"/>\n",
+			xw.name(nsLink, "schemaRef"), xw.name(nsXLink, "type"), xw.name(nsXLink, "href"), escapeXML(sr.href))
+	}
+	for _, lr := range d.linkbaseRefs {
+		fmt.Fprintf(&body, "  <%s %s=\"simple\" %s=\"%s\"",
+			xw.name(nsLink, "linkbaseRef"), xw.name(nsXLink, "type"), xw.name(nsXLink, "href"), escapeXML(lr.href))
+		if lr.role != "" {
+			fmt.Fprintf(&body, " %s=\"%s\"", xw.name(nsXLink, "role"), escapeXML(lr.role))
+		}
+		if lr.arcrole != "" {
+			fmt.Fprintf(&body, " %s=\"%s\"", xw.name(nsXLink, "arcrole"), escapeXML(lr.arcrole))
+		}
+		body.WriteString("/>\n")
+	}
+	for _, id := range slices.Sorted(maps.Keys(d.contexts)) {
+		if c := d.contexts[id]; c != nil {
+			xw.writeContext(&body, c)
+		}
+	}
+	for _, id := range slices.Sorted(maps.Keys(d.units)) {
+		if u := d.units[id]; u != nil {
+			xw.writeUnit(&body, u)
+		}
+	}
+	for _, f := range d.facts {
+		if f != nil {
+			xw.writeFact(&body, f, rootLang)
+		}
+	}
+	if len(d.footnotes) > 0 {
+		xw.writeFootnotes(&body, d.footnotes)
+	}
+
+	var out bytes.Buffer
+	root := xw.name(nsXBRLI, "xbrl")
+	out.WriteString(xml.Header)
+	out.WriteString("<" + root)
+	for _, a := range xw.rootAttrs(d.rootAttrs) {
+		fmt.Fprintf(&out, "\n    %s=\"%s\"", a.Name.Local, escapeXML(a.Value))
+	}
+	out.WriteString(">\n")
+	out.Write(body.Bytes())
+	out.WriteString("</" + root + ">\n")
+
+	if _, err := w.Write(out.Bytes()); err != nil {
+		return fmt.Errorf("xbrl: write xml: %w", err)
+	}
+	return nil
+}
+
+// xmlWriter assigns namespace prefixes while writing a document.
+type xmlWriter struct {
+	prefixes map[string]string // URI -> prefix
+	taken    map[string]bool   // prefixes in use
+	added    []string          // URIs with synthesized prefixes, in order
+	next     int
+}
+
+func newXMLWriter(namespaces map[string]string) *xmlWriter {
+	xw := &xmlWriter{
+		prefixes: make(map[string]string),
+		taken:    make(map[string]bool),
+	}
+	for _, prefix := range slices.Sorted(maps.Keys(namespaces)) {
+		xw.taken[prefix] = true
+		uri := namespaces[prefix]
+		// Prefer a named prefix over the default namespace.
+		if p, ok := xw.prefixes[uri]; !ok || (p == "" && prefix != "") {
+			xw.prefixes[uri] = prefix
+		}
+	}
+	return xw
+}
+
+// prefix returns the prefix for uri, synthesizing one if necessary.
+func (xw *xmlWriter) prefix(uri string) string {
+	if uri == nsXML {
+		return "xml"
+	}
+	if p, ok := xw.prefixes[uri]; ok {
+		return p
+	}
+	var p string
+	for {
+		xw.next++
+		p = "ns" + strconv.Itoa(xw.next)
+		if !xw.taken[p] {
+			break
+		}
+	}
+	xw.taken[p] = true
+	xw.prefixes[uri] = p
+	xw.added = append(xw.added, uri)
+	return p
+}
+
+// name returns the prefixed name of local in the namespace uri.
+func (xw *xmlWriter) name(uri, local string) string {
+	if p := xw.prefix(uri); p != "" {
+		return p + ":" + local
+	}
+	return local
+}
+
+// qname returns the prefixed form of q. QNames without a namespace URI
+// keep their original prefix, if any.
+func (xw *xmlWriter) qname(q QName) string {
+	if q.uri == "" {
+		if q.prefix != "" {
+			return q.prefix + ":" + q.local
+		}
+		return q.local
+	}
+	return xw.name(q.uri, q.local)
+}
+
+// rootAttrs returns the attributes of the root element with names in
+// their prefixed form: the original attributes followed by declarations
+// of synthesized prefixes.
+func (xw *xmlWriter) rootAttrs(attrs []xml.Attr) []xml.Attr {
+	var out []xml.Attr
+	for _, a := range attrs {
+		name := a.Name.Local
+		if prefix, ok := namespaceDecl(a); ok {
+			if prefix != "" {
+				name = "xmlns:" + prefix
+			}
+		} else if a.Name.Space != "" {
+			name = xw.name(a.Name.Space, a.Name.Local)
+		}
+		out = append(out, xml.Attr{Name: xml.Name{Local: name}, Value: a.Value})
+	}
+	// Attribute names above may synthesize prefixes too, so declarations
+	// are collected last.
+	for _, uri := range xw.added {
+		out = append(out, xml.Attr{Name: xml.Name{Local: "xmlns:" + xw.prefixes[uri]}, Value: uri})
+	}
+	return out
+}
+
+func (xw *xmlWriter) writeContext(b *bytes.Buffer, c *Context) {
+	var segment, scenario []Dimension
+	for _, dim := range c.dimensions {
+		if dim.source == DimensionSourceSegment {
+			segment = append(segment, dim)
+		} else {
+			scenario = append(scenario, dim)
+		}
+	}
+
+	fmt.Fprintf(b, "  <%s id=\"%s\">\n", xw.name(nsXBRLI, "context"), escapeXML(c.id))
+	fmt.Fprintf(b, "    <%s>\n", xw.name(nsXBRLI, "entity"))
+	fmt.Fprintf(b, "      <%s scheme=\"%s\">%s</%[1]s>\n",
+		xw.name(nsXBRLI, "identifier"), escapeXML(c.entity.identifier.scheme), escapeXML(c.entity.identifier.value))
+	xw.writeDimensions(b, "segment", segment, "      ")
+	fmt.Fprintf(b, "    </%s>\n", xw.name(nsXBRLI, "entity"))
+
+	fmt.Fprintf(b, "    <%s>\n", xw.name(nsXBRLI, "period"))
+	p := c.period
+	if p.forever {
+		fmt.Fprintf(b, "      <%s/>\n", xw.name(nsXBRLI, "forever"))
+	}
+	for _, v := range []struct {
+		local string
+		val   *string
+	}{
+		{"instant", p.instant},
+		{"startDate", p.startDate},
+		{"endDate", p.endDate},
+	} {
+		if v.val != nil {
+			fmt.Fprintf(b, "      <%s>%s</%[1]s>\n", xw.name(nsXBRLI, v.local), escapeXML(*v.val))
+		}
+	}
+	fmt.Fprintf(b, "    </%s>\n", xw.name(nsXBRLI, "period"))
+
+	xw.writeDimensions(b, "scenario", scenario, "    ")
+	fmt.Fprintf(b, "  </%s>\n", xw.name(nsXBRLI, "context"))
+}
+
+func (xw *xmlWriter) writeDimensions(b *bytes.Buffer, container string, dims []Dimension, indent string) {
+	if len(dims) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "%s<%s>\n", indent, xw.name(nsXBRLI, container))
+	for _, dim := range dims {
+		if dim.explicit {
+			fmt.Fprintf(b, "%s  <%s dimension=\"%s\">%s</%[2]s>\n", indent,
+				xw.name(nsXBRLDI, "explicitMember"), xw.qname(dim.dimension), xw.qname(dim.member))
+		} else {
+			fmt.Fprintf(b, "%s  <%s dimension=\"%s\">%s</%[2]s>\n", indent,
+				xw.name(nsXBRLDI, "typedMember"), xw.qname(dim.dimension), dim.typedValue)
+		}
+	}
+	fmt.Fprintf(b, "%s</%s>\n", indent, xw.name(nsXBRLI, container))
+}
+
+func (xw *xmlWriter) writeUnit(b *bytes.Buffer, u *Unit) {
+	measures := func(indent string, qs []QName) {
+		for _, q := range qs {
+			fmt.Fprintf(b, "%s<%s>%s</%[2]s>\n", indent, xw.name(nsXBRLI, "measure"), xw.qname(q))
+		}
+	}
+
+	fmt.Fprintf(b, "  <%s id=\"%s\">\n", xw.name(nsXBRLI, "unit"), escapeXML(u.id))
+	if u.divide {
+		fmt.Fprintf(b, "    <%s>\n", xw.name(nsXBRLI, "divide"))
+		fmt.Fprintf(b, "      <%s>\n", xw.name(nsXBRLI, "unitNumerator"))
+		measures("        ", u.numerator)
+		fmt.Fprintf(b, "      </%s>\n", xw.name(nsXBRLI, "unitNumerator"))
+		fmt.Fprintf(b, "      <%s>\n", xw.name(nsXBRLI, "unitDenominator"))
+		measures("        ", u.denominator)
+		fmt.Fprintf(b, "      </%s>\n", xw.name(nsXBRLI, "unitDenominator"))
+		fmt.Fprintf(b, "    </%s>\n", xw.name(nsXBRLI, "divide"))
+	} else {
+		measures("    ", u.measures)
+	}
+	fmt.Fprintf(b, "  </%s>\n", xw.name(nsXBRLI, "unit"))
+}
+
+func (xw *xmlWriter) writeFact(b *bytes.Buffer, f *Fact, rootLang string) {
+	name := xw.qname(f.name)
+	fmt.Fprintf(b, "  <%s contextRef=\"%s\"", name, escapeXML(f.contextRef))
+	for _, a := range []struct{ name, val string }{
+		{"unitRef", f.unitRef},
+		{"decimals", f.decimals},
+		{"precision", f.precision},
+		{"id", f.id},
+	} {
+		if a.val != "" {
+			fmt.Fprintf(b, " %s=\"%s\"", a.name, escapeXML(a.val))
+		}
+	}
+	// Facts inherit xml:lang from the root, so it is only repeated when it
+	// differs.
+	if f.lang != "" && f.lang != rootLang {
+		fmt.Fprintf(b, " xml:lang=\"%s\"", escapeXML(f.lang))
+	}
+	if f.nil {
+		fmt.Fprintf(b, " %s=\"true\"/>\n", xw.name(nsXSI, "nil"))
+		return
+	}
+	fmt.Fprintf(b, ">%s</%s>\n", escapeXML(f.value), name)
+}
+
+// writeFootnotes writes a single footnoteLink linking each fact ID, in
+// sorted order, to its footnotes.
+func (xw *xmlWriter) writeFootnotes(b *bytes.Buffer, footnotes map[string][]Footnote) {
+	xtype, label := xw.name(nsXLink, "type"), xw.name(nsXLink, "label")
+	fmt.Fprintf(b, "  <%s %s=\"extended\" %s=\"http://www.xbrl.org/2003/role/link\">\n",
+		xw.name(nsLink, "footnoteLink"), xtype, xw.name(nsXLink, "role"))
+	for i, id := range slices.Sorted(maps.Keys(footnotes)) {
+		loc, fn := "fact"+strconv.Itoa(i+1), "footnote"+strconv.Itoa(i+1)
+		fmt.Fprintf(b, "    <%s %s=\"locator\" %s=\"#%s\" %s=\"%s\"/>\n",
+			xw.name(nsLink, "loc"), xtype, xw.name(nsXLink, "href"), escapeXML(id), label, loc)
+		for _, note := range footnotes[id] {
+			fmt.Fprintf(b, "    <%s %s=\"resource\" %s=\"%s\"", xw.name(nsLink, "footnote"), xtype, label, fn)
+			if note.role != "" {
+				fmt.Fprintf(b, " %s=\"%s\"", xw.name(nsXLink, "role"), escapeXML(note.role))
+			}
+			if note.lang != "" {
+				fmt.Fprintf(b, " xml:lang=\"%s\"", escapeXML(note.lang))
+			}
+			fmt.Fprintf(b, ">%s</%s>\n", escapeXML(note.text), xw.name(nsLink, "footnote"))
+		}
+		fmt.Fprintf(b, "    <%s %s=\"arc\" %s=\"http://www.xbrl.org/2003/arcrole/fact-footnote\" %s=\"%s\" %s=\"%s\"/>\n",
+			xw.name(nsLink, "footnoteArc"), xtype, xw.name(nsXLink, "arcrole"),
+			xw.name(nsXLink, "from"), loc, xw.name(nsXLink, "to"), fn)
+	}
+	fmt.Fprintf(b, "  </%s>\n", xw.name(nsLink, "footnoteLink"))
+}
+
+// escapeXML escapes s for use in XML text and attribute values.
+func escapeXML(s string) string {
+	var sb strings.Builder
+	_ = xml.EscapeText(&sb, []byte(s))
+	return sb.String()
+}
@@ -0,0 +1,144 @@
+package xbrl_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/aethiopicuschan/xbrl-go/pkg/xbrl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocument_Namespaces(t *testing.T) {
+	t.Parallel()
+
+	doc, err := xbrl.Parse(strings.NewReader(extendedInstance))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"xbrli":   "http://www.xbrl.org/2003/instance",
+		"link":    "http://www.xbrl.org/2003/linkbase",
+		"ex":      "http://example.com/xbrl",
+		"xsi":     "http://www.w3.org/2001/XMLSchema-instance",
+		"iso4217": "urn:iso:std:iso:4217",
+	}, doc.Namespaces())
+
+	def, err := xbrl.Parse(strings.NewReader(`<xbrl xmlns="http://www.xbrl.org/2003/instance"/>`))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"": "http://www.xbrl.org/2003/instance"}, def.Namespaces())
+
+	var nilDoc *xbrl.Document
+	assert.Nil(t, nilDoc.Namespaces())
+}
+
+func TestDocument_WriteXML_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	doc, err := xbrl.Parse(strings.NewReader(extendedInstance))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, doc.WriteXML(&buf))
+	out := buf.String()
+
+	// Original prefixes are declared and used.
+	assert.Contains(t, out, `<xbrli:xbrl`)
+	assert.Contains(t, out, `xmlns:ex="http://example.com/xbrl"`)
+	assert.Contains(t, out, `xmlns:iso4217="urn:iso:std:iso:4217"`)
+	assert.Contains(t, out, `<xbrli:measure>iso4217:JPY</xbrli:measure>`)
+	assert.Contains(t, out, `<xbrli:measure>iso4217:USD</xbrli:measure>`)
+	assert.Contains(t, out, `dimension="ex:Region">ex:Japan<`)
+	assert.Contains(t, out, `<ex:Revenue contextRef="C1"`)
+	assert.Contains(t, out, `<ex:NilFact contextRef="C1" xsi:nil="true"/>`)
+	assert.Contains(t, out, `<link:schemaRef`)
+	// xlink is only declared locally in the source, so it gets a
+	// synthesized prefix.
+	assert.Contains(t, out, `xmlns:ns1="http://www.w3.org/1999/xlink"`)
+	assert.Contains(t, out, `ns1:href="http://example.com/schema.xsd"`)
+
+	again, err := xbrl.Parse(strings.NewReader(out))
+	require.NoError(t, err)
+	assert.Equal(t, doc.Namespaces()["ex"], again.Namespaces()["ex"])
+	assert.Equal(t, doc.SchemaRefs(), again.SchemaRefs())
+	require.Len(t, again.Units(), len(doc.Units()))
+	for id, u := range doc.Units() {
+		got, ok := again.UnitByID(id)
+		require.True(t, ok, id)
+		// U2 used the default namespace, so only URIs and local names
+		// are compared.
+		assert.Equal(t, u.String(), got.String(), id)
+		for i, m := range u.Measures() {
+			assert.Equal(t, m.URI(), got.Measures()[i].URI(), id)
+		}
+	}
+	require.Len(t, again.Contexts(), len(doc.Contexts()))
+	for id, c := range doc.Contexts() {
+		got, ok := again.ContextByID(id)
+		require.True(t, ok, id)
+		assert.Equal(t, c.Signature(), got.Signature(), id)
+		assert.Equal(t, c.Dimensions(), got.Dimensions(), id)
+	}
+	require.Len(t, again.Facts(), len(doc.Facts()))
+	for i, f := range doc.Facts() {
+		g := again.Facts()[i]
+		assert.Equal(t, f.Name(), g.Name())
+		assert.Equal(t, f.Value(), g.Value())
+		assert.Equal(t, f.ContextRef(), g.ContextRef())
+		assert.Equal(t, f.UnitRef(), g.UnitRef())
+		assert.Equal(t, f.Decimals(), g.Decimals())
+		assert.Equal(t, f.Precision(), g.Precision())
+		assert.Equal(t, f.ID(), g.ID())
+		assert.Equal(t, f.Lang(), g.Lang())
+		assert.Equal(t, f.IsNil(), g.IsNil())
+	}
+}
+
+func TestDocument_WriteXML_SynthesizedPrefixes(t *testing.T) {
+	t.Parallel()
+
+	concept := xbrl.NewQNameForTest("", "Revenue", "http://example.com/a")
+	other := xbrl.NewQNameForTest("", "Profit", "http://example.com/b")
+	doc := xbrl.NewDocumentForTest(nil, nil, nil, []*xbrl.Fact{
+		xbrl.NewFactForTest(xbrl.FactKindItem, concept, "1 < 2", "C1", "", "", "", "", "", false),
+		xbrl.NewFactForTest(xbrl.FactKindItem, other, "3", "C1", "", "", "", "", "", false),
+		xbrl.NewFactForTest(xbrl.FactKindItem, concept, "4", "C1", "", "", "", "", "", false),
+	}, nil)
+
+	var buf bytes.Buffer
+	require.NoError(t, doc.WriteXML(&buf))
+	out := buf.String()
+
+	// Prefixes are numbered in order of first use: the body is written
+	// before the root element.
+	assert.Contains(t, out, `xmlns:ns1="http://example.com/a"`)
+	assert.Contains(t, out, `xmlns:ns2="http://example.com/b"`)
+	assert.Contains(t, out, `xmlns:ns3="http://www.xbrl.org/2003/instance"`)
+	assert.Contains(t, out, `<ns1:Revenue contextRef="C1">1 &lt; 2</ns1:Revenue>`)
+	assert.Contains(t, out, `<ns2:Profit contextRef="C1">3</ns2:Profit>`)
+
+	again, err := xbrl.Parse(strings.NewReader(out))
+	require.NoError(t, err)
+	require.Len(t, again.Facts(), 3)
+	assert.Equal(t, "1 < 2", again.Facts()[0].Value())
+	assert.Equal(t, "http://example.com/b", again.Facts()[1].Name().URI())
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("boom") }
+
+func TestDocument_WriteXML_Errors(t *testing.T) {
+	t.Parallel()
+
+	doc, err := xbrl.Parse(strings.NewReader(minimalInstance))
+	require.NoError(t, err)
+	err = doc.WriteXML(failingWriter{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "xbrl: write xml")
+
+	var nilDoc *xbrl.Document
+	var buf bytes.Buffer
+	require.NoError(t, nilDoc.WriteXML(&buf))
+	assert.Empty(t, buf.String())
+}
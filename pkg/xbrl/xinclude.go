@@ -0,0 +1,125 @@
+package xbrl
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// nsXInclude is the XInclude 1.0 namespace.
+const nsXInclude = "http://www.w3.org/2001/XInclude"
+
+// expandXInclude replaces the xi:include elements in data with the
+// documents they reference, recursively. base is the href data was
+// loaded from ("" for the top-level document) and is used to resolve
+// relative hrefs; stack holds the hrefs being expanded, to detect cycles.
+//
+// Inclusion is textual: an included XML document contributes its root
+// element, which is parsed in the namespace scope of the xi:include
+// element. Documents are assumed to be UTF-8.
+func expandXInclude(
+	data []byte,
+	base string,
+	opener func(href string) (io.ReadCloser, error),
+	stack []string,
+) ([]byte, error) {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	dec.CharsetReader = charsetReader
+
+	var out bytes.Buffer
+	var last int64
+	for {
+		start := dec.InputOffset()
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, positionError(dec, "decode token", err)
+		}
+		se, ok := tok.(xml.StartElement)
+		if !ok || se.Name.Space != nsXInclude || se.Name.Local != "include" {
+			continue
+		}
+		// Fallback content is discarded along with the element.
+		if err := dec.Skip(); err != nil {
+			return nil, positionError(dec, "skip xi:include", err)
+		}
+		end := dec.InputOffset()
+
+		href := attrValue(se.Attr, "href")
+		if href == "" {
+			return nil, positionError(dec, "xi:include", fmt.Errorf("missing href"))
+		}
+		target := resolveHref(base, href)
+		if slices.Contains(stack, target) {
+			return nil, fmt.Errorf("xbrl: xi:include cycle: %s", strings.Join(append(stack, target), " -> "))
+		}
+
+		content, err := readInclude(opener, target)
+		if err != nil {
+			return nil, err
+		}
+
+		var frag []byte
+		switch parse := attrValue(se.Attr, "parse"); parse {
+		case "", "xml":
+			body, err := rootElementBytes(content)
+			if err != nil {
+				return nil, fmt.Errorf("xbrl: xi:include %q: %w", target, err)
+			}
+			frag, err = expandXInclude(body, target, opener, append(stack, target))
+			if err != nil {
+				return nil, err
+			}
+		case "text":
+			frag = []byte(escapeXML(string(content)))
+		default:
+			return nil, fmt.Errorf("xbrl: xi:include %q: unsupported parse %q", target, parse)
+		}
+
+		out.Write(data[last:start])
+		out.Write(frag)
+		last = end
+	}
+	out.Write(data[last:])
+	return out.Bytes(), nil
+}
+
+// readInclude reads the document referenced by an xi:include.
+func readInclude(opener func(href string) (io.ReadCloser, error), href string) ([]byte, error) {
+	rc, err := opener(href)
+	if err != nil {
+		return nil, fmt.Errorf("xbrl: open xi:include %q: %w", href, err)
+	}
+	defer rc.Close()
+
+	content, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, fmt.Errorf("xbrl: read xi:include %q: %w", href, err)
+	}
+	return bytes.TrimPrefix(content, utf8BOM), nil
+}
+
+// rootElementBytes returns the bytes of data from the start of its root
+// element, dropping the XML declaration and anything else in the prolog.
+func rootElementBytes(data []byte) ([]byte, error) {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	dec.CharsetReader = charsetReader
+	for {
+		start := dec.InputOffset()
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return nil, fmt.Errorf("no root element")
+		}
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := tok.(xml.StartElement); ok {
+			return bytes.TrimSpace(data[start:]), nil
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package xbrl_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aethiopicuschan/xbrl-go/pkg/xbrl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const xincludeRoot = `<?xml version="1.0" encoding="UTF-8"?>
+<xbrli:xbrl xmlns:xbrli="http://www.xbrl.org/2003/instance"
+    xmlns:xi="http://www.w3.org/2001/XInclude"
+    xmlns:iso4217="http://www.xbrl.org/2003/iso4217" xmlns:ex="http://example.com/xbrl">
+  <xi:include href="parts/contexts.xml"/>
+  <xbrli:unit id="JPY"><xbrli:measure>iso4217:JPY</xbrli:measure></xbrli:unit>
+  <xi:include href="parts/facts.xml"><xi:fallback/></xi:include>
+</xbrli:xbrl>`
+
+func TestParseWithOptions_ResolveXInclude(t *testing.T) {
+	t.Parallel()
+
+	files := map[string]string{
+		"parts/contexts.xml": "\ufeff" + `<?xml version="1.0" encoding="UTF-8"?>
+<xbrli:contexts xmlns:xbrli="http://www.xbrl.org/2003/instance" xmlns:xi="http://www.w3.org/2001/XInclude">
+  <xbrli:context id="C1">
+    <xbrli:entity><xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:instant>2025-03-31</xbrli:instant></xbrli:period>
+  </xbrli:context>
+  <xi:include href="prior.xml"/>
+</xbrli:contexts>`,
+		"parts/prior.xml": `<xbrli:context xmlns:xbrli="http://www.xbrl.org/2003/instance" id="C0">
+  <xbrli:entity><xbrli:identifier scheme="http://example.com/entity">ABC</xbrli:identifier></xbrli:entity>
+  <xbrli:period><xbrli:instant>2024-03-31</xbrli:instant></xbrli:period>
+</xbrli:context>`,
+		"parts/facts.xml": `<ex:facts xmlns:ex="http://example.com/xbrl">
+  <ex:Revenue contextRef="C1" unitRef="JPY" decimals="0">100</ex:Revenue>
+  <ex:Revenue contextRef="C0" unitRef="JPY" decimals="0">90</ex:Revenue>
+</ex:facts>`,
+	}
+
+	var opened []string
+	doc, err := xbrl.ParseWithOptions(strings.NewReader(xincludeRoot), xbrl.ParseOptions{
+		ResolveXInclude: true,
+		XIncludeOpener:  mapOpener(files, &opened),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"parts/contexts.xml", "parts/prior.xml", "parts/facts.xml"}, opened,
+		"nested hrefs are resolved against the including document")
+	assert.Len(t, doc.Contexts(), 2)
+	assert.Len(t, doc.Units(), 1)
+	require.Len(t, doc.Facts(), 2)
+	assert.Equal(t, "90", doc.Facts()[1].Value())
+
+	t.Run("off by default", func(t *testing.T) {
+		t.Parallel()
+
+		doc, err := xbrl.Parse(strings.NewReader(xincludeRoot))
+		require.NoError(t, err)
+		assert.Empty(t, doc.Contexts())
+		assert.Empty(t, doc.Facts())
+	})
+}
+
+func TestParseWithOptions_ResolveXIncludeErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		files   map[string]string
+		opener  bool
+		wantErr string
+	}{
+		{
+			name:    "nil opener",
+			wantErr: "xbrl: XIncludeOpener is nil",
+		},
+		{
+			name:    "missing file",
+			files:   map[string]string{"parts/contexts.xml": `<a/>`},
+			opener:  true,
+			wantErr: `xbrl: open xi:include "parts/facts.xml": not found`,
+		},
+		{
+			name: "cycle",
+			files: map[string]string{
+				"parts/contexts.xml": `<a xmlns:xi="http://www.w3.org/2001/XInclude"><xi:include href="loop.xml"/></a>`,
+				"parts/loop.xml":     `<b xmlns:xi="http://www.w3.org/2001/XInclude"><xi:include href="contexts.xml"/></b>`,
+			},
+			opener:  true,
+			wantErr: "xbrl: xi:include cycle: parts/contexts.xml -> parts/loop.xml -> parts/contexts.xml",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := xbrl.ParseOptions{ResolveXInclude: true}
+			if tt.opener {
+				opts.XIncludeOpener = mapOpener(tt.files, nil)
+			}
+			_, err := xbrl.ParseWithOptions(strings.NewReader(xincludeRoot), opts)
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}